@@ -1,11 +1,20 @@
 package main
 
 import (
+	"context"
 	"donor-api/internal/delivery/routes"
+	"donor-api/internal/delivery/scheduler"
 	"donor-api/internal/entity"
 	"donor-api/internal/infrastructure/database"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	_ "donor-api/docs"
 
@@ -64,6 +73,31 @@ func main() {
 		&entity.Stock{},
 		&entity.Donation{},
 		&entity.BloodRequest{},
+		&entity.BloodUnit{},
+		&entity.StockMovement{},
+		&entity.Transfer{},
+		&entity.TransferUnit{},
+		&entity.StockThreshold{},
+		&entity.StockAlert{},
+		&entity.StorageDevice{},
+		&entity.TemperatureReading{},
+		&entity.TemperatureExcursion{},
+		&entity.StockReservation{},
+		&entity.ScreeningResult{},
+		&entity.DonorDeferral{},
+		&entity.BloodRequestStatusHistory{},
+		&entity.Notification{},
+		&entity.BloodRequestFulfillment{},
+		&entity.BloodRequestFulfillmentUnit{},
+		&entity.Hospital{},
+		&entity.DonorCallUp{},
+		&entity.DonorCallUpRecipient{},
+		&entity.EligibilityPolicy{},
+		&entity.Questionnaire{},
+		&entity.QuestionnaireQuestion{},
+		&entity.QuestionnaireSubmission{},
+		&entity.QuestionnaireAnswer{},
+		&entity.VitalSigns{},
 	)
 	if err != nil {
 	}
@@ -72,11 +106,41 @@ func main() {
 	}
 	fmt.Println("✅ Migrasi database berhasil!")
 
-	router := routes.NewAPIRoutes(db)
+	router, jobs := routes.NewAPIRoutes(db)
 	swaggerURL := ginSwagger.URL("https://donor-darah.duckdns.org/swagger/doc.json")
 	// swaggerURL := ginSwagger.URL("http://localhost:8080/swagger/doc.json")
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, swaggerURL))
 
-	log.Printf("🚀 Server berjalan di http://localhost:8080/api/v1")
-	router.Run(":8080")
+	// Scheduler berhenti bersama server saat proses menerima SIGINT/SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	scheduler.StartStockExpirySweep(ctx, jobs.StockUsecase, intervalFromEnv("STOCK_EXPIRY_SWEEP_INTERVAL_MINUTES", 60))
+	scheduler.StartReservationExpirySweep(ctx, jobs.BloodRequestUsecase, intervalFromEnv("STOCK_RESERVATION_SWEEP_INTERVAL_MINUTES", 5))
+	scheduler.StartRequestSLAEscalation(ctx, jobs.BloodRequestUsecase, intervalFromEnv("REQUEST_SLA_CHECK_INTERVAL_MINUTES", 5))
+
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		log.Printf("🚀 Server berjalan di http://localhost:8080/api/v1")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Server berhenti: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("🛑 Menghentikan server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ Gagal menghentikan server dengan bersih: %v", err)
+	}
+}
+
+// intervalFromEnv membaca interval scheduler dalam menit dari env, dengan nilai bawaan bila kosong atau tidak valid.
+func intervalFromEnv(key string, fallbackMinutes int) time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || minutes <= 0 {
+		minutes = fallbackMinutes
+	}
+	return time.Duration(minutes) * time.Minute
 }
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+// Actor adalah identitas pemanggil yang dibaca dari token JWT.
+type Actor struct {
+	UserID   uuid.UUID
+	TenantID uuid.UUID // kosong untuk superadmin
+	Role     string
+}
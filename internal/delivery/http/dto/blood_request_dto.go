@@ -1,26 +1,160 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type BloodRequestRequest struct {
 	LocationID  string `json:"location_id" binding:"required"`
 	BloodType   string `json:"blood_type" binding:"required"`
-	Quantity    int    `json:"quantity" binding:"required"`
+	Rhesus      string `json:"rhesus" binding:"omitempty,oneof=+ -"`
+	Component   string `json:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	Quantity    int    `json:"quantity" binding:"required,min=1"`
+	Urgency     string `json:"urgency" binding:"omitempty,oneof=routine urgent emergency"`
 	Description string `json:"description" binding:"required"`
+	HospitalID  string `json:"hospital_id" binding:"omitempty,uuid"`
+	Ward        string `json:"ward"`
+	DoctorName  string `json:"doctor_name"`
+	// Data pasien hanya dikembalikan kepada admin dan superadmin.
+	PatientMRN  string   `json:"patient_mrn"`
+	PatientName string   `json:"patient_name"`
+	PatientAge  *int     `json:"patient_age" binding:"omitempty,min=0,max=150"`
+	Diagnosis   string   `json:"diagnosis"`
+	PatientHb   *float64 `json:"patient_hb" binding:"omitempty,gt=0,lt=30"`
 }
 
 type BloodRequestResponse struct {
 	ID          string    `json:"id"`
 	LocationID  string    `json:"location_id"`
 	BloodType   string    `json:"blood_type"`
+	Rhesus      string    `json:"rhesus"`
+	Component   string    `json:"component"`
 	Quantity    int       `json:"quantity"`
 	Status      string    `json:"status"`
+	Urgency     string    `json:"urgency"`
 	Description string    `json:"description"`
+	HospitalID  string    `json:"hospital_id,omitempty"`
+	Ward        string    `json:"ward"`
+	DoctorName  string    `json:"doctor_name"`
 	CreatedBy   string    `json:"created_by"`
+	Version     int       `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// DueAt adalah batas pemenuhan menurut SLA urgensi; Overdue bernilai true bila permintaan masih berjalan melewati batas itu.
+	DueAt       time.Time  `json:"due_at"`
+	Overdue     bool       `json:"overdue"`
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+	// ReservedQuantity adalah jumlah kantong yang masih ditahan untuk permintaan ini,
+	// FulfilledQuantity adalah total kantong yang sudah diserahkan.
+	ReservedQuantity  int                        `json:"reserved_quantity"`
+	FulfilledQuantity int                        `json:"fulfilled_quantity"`
+	Reservations      []StockReservationResponse `json:"reservations,omitempty"`
+	// StatusHistory berisi riwayat perpindahan status, urut dari yang paling lama.
+	StatusHistory []BloodRequestStatusHistoryResponse `json:"status_history,omitempty"`
+	Fulfillments  []BloodRequestFulfillmentResponse   `json:"fulfillments,omitempty"`
+	// Patient kosong bila peran pemanggil tidak berhak melihat data pasien.
+	Patient *BloodRequestPatientResponse `json:"patient,omitempty"`
+}
+
+type BloodRequestPatientResponse struct {
+	MedicalRecordNumber string   `json:"medical_record_number"`
+	Name                string   `json:"name"`
+	Age                 *int     `json:"age,omitempty"`
+	Diagnosis           string   `json:"diagnosis"`
+	Hb                  *float64 `json:"hb,omitempty"`
+}
+
+type BloodRequestFulfillmentResponse struct {
+	ID         string                    `json:"id"`
+	LocationID string                    `json:"location_id"`
+	Quantity   int                       `json:"quantity"`
+	Note       string                    `json:"note,omitempty"`
+	IssuedBy   string                    `json:"issued_by"`
+	IssuedAt   time.Time                 `json:"issued_at"`
+	Units      []FulfillmentUnitResponse `json:"units"`
 }
 
+type FulfillmentUnitResponse struct {
+	BloodUnitID string `json:"blood_unit_id"`
+	BagNumber   string `json:"bag_number"`
+	BloodType   string `json:"blood_type"`
+	Rhesus      string `json:"rhesus"`
+	Component   string `json:"component"`
+	FromReserve bool   `json:"from_reserve"`
+}
+
+type BloodRequestStatusHistoryResponse struct {
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Note       string     `json:"note,omitempty"`
+	ChangedBy  *uuid.UUID `json:"changed_by,omitempty"`
+	ChangedAt  time.Time  `json:"changed_at"`
+}
+
+type StockReservationResponse struct {
+	ID          string     `json:"id"`
+	BloodUnitID string     `json:"blood_unit_id"`
+	BagNumber   string     `json:"bag_number"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// UpdateBloodRequestStatusDTO memindahkan status permintaan. Perpindahan yang diizinkan:
+// pending → approved/rejected/cancelled, approved/partially_fulfilled → cancelled.
+// partially_fulfilled dan fulfilled diatur otomatis oleh IssueBloodRequestDTO.
 type UpdateBloodRequestStatusDTO struct {
-	Status string `json:"status" binding:"required"`
+	Status string `json:"status" binding:"required,oneof=approved cancelled rejected"`
+	Note   string `json:"note"`
+}
+
+// IssueBloodRequestDTO menyerahkan kantong untuk permintaan yang sudah disetujui.
+// LocationID kosong berarti kantong diambil dari lokasi permintaan.
+type IssueBloodRequestDTO struct {
+	LocationID string `json:"location_id" binding:"omitempty,uuid"`
+	Quantity   int    `json:"quantity" binding:"required,min=1"`
+	Note       string `json:"note"`
+}
+
+// BloodRequestMatchPlanResponse adalah rencana pemenuhan permintaan dari stok kompatibel di lokasi terdekat.
+// Kantong yang sudah ditahan di lokasi permintaan dihitung lebih dulu; sisanya dicari dari golongan yang sama persis,
+// lalu golongan pengganti, masing-masing berurutan menurut jarak.
+type BloodRequestMatchPlanResponse struct {
+	BloodRequestID   string                  `json:"blood_request_id"`
+	BloodType        string                  `json:"blood_type"`
+	Rhesus           string                  `json:"rhesus"`
+	Component        string                  `json:"component"`
+	Quantity         int                     `json:"quantity"`
+	ReservedQuantity int                     `json:"reserved_quantity"`
+	PlannedQuantity  int                     `json:"planned_quantity"`
+	Shortfall        int                     `json:"shortfall"`
+	Items            []MatchPlanItemResponse `json:"items"`
+}
+
+type MatchPlanItemResponse struct {
+	Rank         int       `json:"rank"`
+	Match        string    `json:"match"` // exact, substitute
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationName string    `json:"location_name"`
+	City         string    `json:"city"`
+	// DistanceKm kosong bila koordinat lokasi belum diisi.
+	DistanceKm *float64 `json:"distance_km"`
+	BloodType  string   `json:"blood_type"`
+	Rhesus     string   `json:"rhesus"`
+	Component  string   `json:"component"`
+	Available  int      `json:"available"`
+	Quantity   int      `json:"quantity"`
+}
+
+// PublicBloodRequestResponse adalah permintaan darah berjalan untuk umpan publik, tanpa data pasien maupun pemohon.
+type PublicBloodRequestResponse struct {
+	BloodType    string `json:"blood_type"`
+	Rhesus       string `json:"rhesus"`
+	Quantity     int    `json:"quantity"` // sisa kantong yang masih dibutuhkan
+	City         string `json:"city"`
+	LocationName string `json:"location_name"`
+	Urgency      string `json:"urgency"`
 }
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BloodUnitRequest struct {
+	BagNumber      string     `json:"bag_number" binding:"required"`
+	BloodType      string     `json:"blood_type" binding:"required,oneof=A B AB O"`
+	Rhesus         string     `json:"rhesus" binding:"required,oneof=+ -"`
+	Component      string     `json:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	CollectionDate time.Time  `json:"collection_date" binding:"required"`
+	ExpiryDate     time.Time  `json:"expiry_date" binding:"omitempty,gtfield=CollectionDate"`
+	LocationID     uuid.UUID  `json:"location_id" binding:"required"`
+	DonationID     *uuid.UUID `json:"donation_id" binding:"required"`
+	// StorageDeviceID adalah alat simpan di lokasi yang sama tempat unit disimpan.
+	StorageDeviceID *uuid.UUID `json:"storage_device_id"`
+}
+
+// ProcessDonationRequest memecah satu donasi whole blood menjadi beberapa komponen.
+// Golongan darah diambil dari profil pendonor bila tidak diisi.
+type ProcessDonationRequest struct {
+	BagNumber  string   `json:"bag_number" binding:"required"`
+	Components []string `json:"components" binding:"required,min=1,dive,oneof=WB PRC FFP TC CRYO"`
+	BloodType  string   `json:"blood_type" binding:"omitempty,oneof=A B AB O"`
+	Rhesus     string   `json:"rhesus" binding:"omitempty,oneof=+ -"`
+}
+
+// UpdateBloodUnitStatusRequest mengubah status unit secara manual. Tahanan dan transfer
+// mengubah status unit lewat alurnya sendiri, jadi reserved tidak bisa dipilih di sini.
+type UpdateBloodUnitStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=issued expired discarded"`
+	Reason string `json:"reason"`
+}
+
+// ReviewBloodUnitRequest menyelesaikan review unit yang terkena excursion suhu:
+// release mengembalikan unit ke stok yang bisa dialokasikan, discard memusnahkannya.
+type ReviewBloodUnitRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=release discard"`
+	Note     string `json:"note"`
+}
+
+type BloodUnitResponse struct {
+	ID              string     `json:"id"`
+	BagNumber       string     `json:"bag_number"`
+	BloodType       string     `json:"blood_type"`
+	Rhesus          string     `json:"rhesus"`
+	Component       string     `json:"component"`
+	CollectionDate  time.Time  `json:"collection_date"`
+	ExpiryDate      time.Time  `json:"expiry_date"`
+	LocationID      string     `json:"location_id"`
+	DonationID      *uuid.UUID `json:"donation_id,omitempty"`
+	Status          string     `json:"status"`
+	StorageDeviceID *uuid.UUID `json:"storage_device_id,omitempty"`
+	NeedsReview     bool       `json:"needs_review"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -12,7 +12,7 @@ type CreateDonationRequest struct {
 	EventID      *uuid.UUID `json:"event_id"`
 	DonationDate time.Time  `json:"donation_date" binding:"required"`
 	Name         string     `json:"name" binding:"required"`
-	Status       string     `json:"status" binding:"required,oneof=selesai batal pending"`
+	DonationType string     `json:"donation_type" binding:"omitempty,oneof=whole_blood apheresis"`
 }
 
 type UpdateDonationRequest struct {
@@ -20,12 +20,15 @@ type UpdateDonationRequest struct {
 }
 
 type DonationResponse struct {
-	ID           string    `json:"id"`
-	UserID       string    `json:"user_id"`
-	LocationID   string    `json:"location_id"`
-	EventID      *string   `json:"event_id,omitempty"`
-	Name         string    `json:"name" `
-	DonationDate time.Time `json:"donation_date"`
-	Status       string    `json:"status"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	LocationID      string    `json:"location_id"`
+	EventID         *string   `json:"event_id,omitempty"`
+	Name            string    `json:"name" `
+	DonationDate    time.Time `json:"donation_date"`
+	Status          string    `json:"status"`
+	StatusReason    string    `json:"status_reason,omitempty"`
+	DonationType    string    `json:"donation_type"`
+	ScreeningStatus string    `json:"screening_status"` // pending (karantina), cleared, reactive
+	CreatedAt       time.Time `json:"created_at"`
 }
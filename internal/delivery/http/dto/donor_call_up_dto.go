@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+)
+
+// BroadcastDonorCallUpRequest mengatur jangkauan panggilan donor. Nilai kosong memakai radius 10 km dan 100 pendonor.
+type BroadcastDonorCallUpRequest struct {
+	RadiusKm      float64 `json:"radius_km" binding:"omitempty,gt=0,max=200"`
+	MaxRecipients int     `json:"max_recipients" binding:"omitempty,min=1,max=1000"`
+	Message       string  `json:"message"`
+}
+
+type RespondDonorCallUpRequest struct {
+	Response string `json:"response" binding:"required,oneof=accept decline"`
+}
+
+type DonorCallUpResponse struct {
+	ID             string    `json:"id"`
+	BloodRequestID string    `json:"blood_request_id"`
+	LocationID     string    `json:"location_id"`
+	RadiusKm       float64   `json:"radius_km"`
+	Shortfall      int       `json:"shortfall"`
+	Message        string    `json:"message"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	InvitedCount   int       `json:"invited_count"`
+	RespondedCount int       `json:"responded_count"`
+	AcceptedCount  int       `json:"accepted_count"`
+	DeclinedCount  int       `json:"declined_count"`
+	ArrivedCount   int       `json:"arrived_count"`
+	// Recipients hanya diisi pada detail panggilan.
+	Recipients []DonorCallUpRecipientResponse `json:"recipients,omitempty"`
+}
+
+type DonorCallUpRecipientResponse struct {
+	UserID      string     `json:"user_id"`
+	FullName    string     `json:"full_name"`
+	PhoneNumber string     `json:"phone_number"`
+	BloodType   string     `json:"blood_type"`
+	Rhesus      string     `json:"rhesus"`
+	DistanceKm  float64    `json:"distance_km"`
+	Status      string     `json:"status"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	ArrivedAt   *time.Time `json:"arrived_at,omitempty"`
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EligibilityPolicyRequest mengatur ambang kelayakan donor. TenantID hanya dipakai superadmin;
+// pengguna tenant selalu mengatur kebijakan tenant-nya sendiri.
+type EligibilityPolicyRequest struct {
+	TenantID                     *uuid.UUID `json:"tenant_id"`
+	MinAge                       int        `json:"min_age" binding:"required,min=1"`
+	MaxAge                       int        `json:"max_age" binding:"required,min=1"`
+	MinWeightKg                  float64    `json:"min_weight_kg" binding:"required,gt=0"`
+	WholeBloodIntervalMaleDays   int        `json:"whole_blood_interval_male_days" binding:"required,min=1"`
+	WholeBloodIntervalFemaleDays int        `json:"whole_blood_interval_female_days" binding:"required,min=1"`
+	ApheresisIntervalMaleDays    int        `json:"apheresis_interval_male_days" binding:"required,min=1"`
+	ApheresisIntervalFemaleDays  int        `json:"apheresis_interval_female_days" binding:"required,min=1"`
+	MinHaemoglobinMaleGdl        float64    `json:"min_haemoglobin_male_gdl" binding:"required,gt=0"`
+	MinHaemoglobinFemaleGdl      float64    `json:"min_haemoglobin_female_gdl" binding:"required,gt=0"`
+	MaxHaemoglobinGdl            float64    `json:"max_haemoglobin_gdl" binding:"required,gt=0"`
+	MinSystolicMmHg              int        `json:"min_systolic_mmhg" binding:"required,min=1"`
+	MaxSystolicMmHg              int        `json:"max_systolic_mmhg" binding:"required,min=1"`
+	MinDiastolicMmHg             int        `json:"min_diastolic_mmhg" binding:"required,min=1"`
+	MaxDiastolicMmHg             int        `json:"max_diastolic_mmhg" binding:"required,min=1"`
+	MinPulseBpm                  int        `json:"min_pulse_bpm" binding:"required,min=1"`
+	MaxPulseBpm                  int        `json:"max_pulse_bpm" binding:"required,min=1"`
+	MinTemperatureC              float64    `json:"min_temperature_c" binding:"required,gt=0"`
+	MaxTemperatureC              float64    `json:"max_temperature_c" binding:"required,gt=0"`
+}
+
+// EligibilityPolicyResponse adalah kebijakan kelayakan yang berlaku; IsDefault bernilai true
+// bila tenant belum mengatur kebijakan sendiri.
+type EligibilityPolicyResponse struct {
+	TenantID                     string     `json:"tenant_id"`
+	MinAge                       int        `json:"min_age"`
+	MaxAge                       int        `json:"max_age"`
+	MinWeightKg                  float64    `json:"min_weight_kg"`
+	WholeBloodIntervalMaleDays   int        `json:"whole_blood_interval_male_days"`
+	WholeBloodIntervalFemaleDays int        `json:"whole_blood_interval_female_days"`
+	ApheresisIntervalMaleDays    int        `json:"apheresis_interval_male_days"`
+	ApheresisIntervalFemaleDays  int        `json:"apheresis_interval_female_days"`
+	MinHaemoglobinMaleGdl        float64    `json:"min_haemoglobin_male_gdl"`
+	MinHaemoglobinFemaleGdl      float64    `json:"min_haemoglobin_female_gdl"`
+	MaxHaemoglobinGdl            float64    `json:"max_haemoglobin_gdl"`
+	MinSystolicMmHg              int        `json:"min_systolic_mmhg"`
+	MaxSystolicMmHg              int        `json:"max_systolic_mmhg"`
+	MinDiastolicMmHg             int        `json:"min_diastolic_mmhg"`
+	MaxDiastolicMmHg             int        `json:"max_diastolic_mmhg"`
+	MinPulseBpm                  int        `json:"min_pulse_bpm"`
+	MaxPulseBpm                  int        `json:"max_pulse_bpm"`
+	MinTemperatureC              float64    `json:"min_temperature_c"`
+	MaxTemperatureC              float64    `json:"max_temperature_c"`
+	IsDefault                    bool       `json:"is_default"`
+	UpdatedAt                    *time.Time `json:"updated_at,omitempty"`
+}
+
+// EligibilityRuleResult adalah satu aturan kelayakan yang tidak terpenuhi. EligibleOn kosong
+// berarti aturan tidak akan terpenuhi dengan berjalannya waktu saja.
+type EligibilityRuleResult struct {
+	Rule       string     `json:"rule"` // min_age, max_age, min_weight, donation_interval, deferral
+	Message    string     `json:"message"`
+	EligibleOn *time.Time `json:"eligible_on,omitempty"`
+}
+
+type EligibilityResponse struct {
+	UserID           string                  `json:"user_id"`
+	EvaluatedOn      time.Time               `json:"evaluated_on"`
+	Eligible         bool                    `json:"eligible"`
+	FailedRules      []EligibilityRuleResult `json:"failed_rules"`
+	NextEligibleDate *time.Time              `json:"next_eligible_date"`
+}
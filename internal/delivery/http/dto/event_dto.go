@@ -21,5 +21,19 @@ type EventResponse struct {
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
 	LocationID  string    `json:"location_id"`
+	TenantID    string    `json:"tenant_id"`
+	CreatedBy   string    `json:"created_by"`
+	Version     int       `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 }
+
+// PublicEventResponse adalah acara donor yang ditampilkan tanpa autentikasi.
+type PublicEventResponse struct {
+	EventName    string    `json:"event_name"`
+	Slug         string    `json:"slug"`
+	Description  string    `json:"description"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	LocationName string    `json:"location_name"`
+	City         string    `json:"city"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HospitalRequest membuat atau memperbarui rumah sakit. TenantID hanya dipakai superadmin;
+// pengguna tenant selalu membuat rumah sakit untuk tenant-nya sendiri.
+type HospitalRequest struct {
+	TenantID    *uuid.UUID `json:"tenant_id"`
+	Name        string     `json:"name" binding:"required"`
+	Address     string     `json:"address"`
+	City        string     `json:"city"`
+	PhoneNumber string     `json:"phone_number"`
+}
+
+type HospitalResponse struct {
+	ID          string    `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	City        string    `json:"city"`
+	PhoneNumber string    `json:"phone_number"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -19,6 +19,7 @@ type LocationResponse struct {
 	City         string    `json:"city"`
 	Latitude     *float64  `json:"latitude,omitempty"`
 	Longitude    *float64  `json:"longitude,omitempty"`
+	Version      int       `json:"version"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
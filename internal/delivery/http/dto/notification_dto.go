@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationResponse struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	Message     string     `json:"message"`
+	ReferenceID *uuid.UUID `json:"reference_id,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
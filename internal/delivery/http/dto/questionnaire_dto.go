@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuestionnaireRequest menerbitkan versi baru kuesioner pra-donor. TenantID hanya dipakai superadmin;
+// pengguna tenant selalu menerbitkan kuesioner untuk tenant-nya sendiri.
+type QuestionnaireRequest struct {
+	TenantID  *uuid.UUID        `json:"tenant_id"`
+	Title     string            `json:"title" binding:"required"`
+	Questions []QuestionRequest `json:"questions" binding:"required,min=1,dive"`
+}
+
+// QuestionRequest adalah satu pertanyaan kuesioner. Options wajib untuk single_choice; DeferAnswers
+// hanya berlaku untuk yes_no dan single_choice, dan DeferDays kosong berarti penundaan permanen.
+type QuestionRequest struct {
+	Code         string   `json:"code" binding:"required,max=50"`
+	Text         string   `json:"text" binding:"required"`
+	Type         string   `json:"type" binding:"required,oneof=yes_no single_choice number text date"`
+	Required     bool     `json:"required"`
+	Options      []string `json:"options"`
+	DeferAnswers []string `json:"defer_answers"`
+	DeferDays    *int     `json:"defer_days" binding:"omitempty,min=1"`
+	DeferReason  string   `json:"defer_reason"`
+}
+
+type QuestionResponse struct {
+	ID           string   `json:"id"`
+	Position     int      `json:"position"`
+	Code         string   `json:"code"`
+	Text         string   `json:"text"`
+	Type         string   `json:"type"`
+	Required     bool     `json:"required"`
+	Options      []string `json:"options,omitempty"`
+	DeferAnswers []string `json:"defer_answers,omitempty"`
+	DeferDays    *int     `json:"defer_days,omitempty"`
+	DeferReason  string   `json:"defer_reason,omitempty"`
+}
+
+type QuestionnaireResponse struct {
+	ID        string             `json:"id"`
+	TenantID  string             `json:"tenant_id"`
+	Version   int                `json:"version"`
+	Title     string             `json:"title"`
+	Questions []QuestionResponse `json:"questions,omitempty"`
+	CreatedBy string             `json:"created_by"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type SubmitQuestionnaireRequest struct {
+	Answers []AnswerRequest `json:"answers" binding:"required,min=1,dive"`
+}
+
+// AnswerRequest adalah jawaban satu pertanyaan: yes/no untuk yes_no, salah satu opsi untuk single_choice,
+// angka untuk number, dan YYYY-MM-DD untuk date.
+type AnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id" binding:"required"`
+	Value      string    `json:"value"`
+}
+
+type AnswerResponse struct {
+	QuestionID string `json:"question_id"`
+	Code       string `json:"code"`
+	Value      string `json:"value"`
+	Defers     bool   `json:"defers"`
+}
+
+type QuestionnaireSubmissionResponse struct {
+	ID              string           `json:"id"`
+	DonationID      string           `json:"donation_id"`
+	QuestionnaireID string           `json:"questionnaire_id"`
+	Deferred        bool             `json:"deferred"`
+	Answers         []AnswerResponse `json:"answers"`
+	SubmittedBy     string           `json:"submitted_by"`
+	SubmittedAt     time.Time        `json:"submitted_at"`
+}
+
+// DonationQuestionnaireResponse adalah kuesioner yang harus diisi untuk donasi beserta jawabannya
+// bila sudah dikirim. Bila sudah dikirim, Questionnaire adalah versi yang dijawab.
+type DonationQuestionnaireResponse struct {
+	DonationID    string                           `json:"donation_id"`
+	Questionnaire QuestionnaireResponse            `json:"questionnaire"`
+	Submission    *QuestionnaireSubmissionResponse `json:"submission"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningResultInput struct {
+	Marker   string    `json:"marker" binding:"required,oneof=HIV HBV HCV SYPHILIS"`
+	Result   string    `json:"result" binding:"required,oneof=negative reactive"`
+	Method   string    `json:"method"`
+	TestedAt time.Time `json:"tested_at" binding:"required"`
+	Note     string    `json:"note"`
+}
+
+// RecordScreeningRequest memuat satu atau beberapa hasil uji saring untuk satu donasi.
+type RecordScreeningRequest struct {
+	Results []ScreeningResultInput `json:"results" binding:"required,min=1,dive"`
+}
+
+type ScreeningResultResponse struct {
+	ID        string     `json:"id"`
+	Marker    string     `json:"marker"`
+	Result    string     `json:"result"`
+	Method    string     `json:"method"`
+	TestedAt  time.Time  `json:"tested_at"`
+	Note      string     `json:"note"`
+	EnteredBy *uuid.UUID `json:"entered_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ScreeningSummaryResponse merangkum status skrining donasi dan penanda yang belum diuji.
+type ScreeningSummaryResponse struct {
+	DonationID      string                    `json:"donation_id"`
+	ScreeningStatus string                    `json:"screening_status"`
+	PendingMarkers  []string                  `json:"pending_markers"`
+	Results         []ScreeningResultResponse `json:"results"`
+}
+
+type CompleteFollowUpRequest struct {
+	Note string `json:"note" binding:"required"`
+	// DeferredUntil mengubah penundaan permanen menjadi sementara setelah uji konfirmasi.
+	DeferredUntil *time.Time `json:"deferred_until"`
+}
+
+type DonorDeferralResponse struct {
+	ID                  string     `json:"id"`
+	UserID              string     `json:"user_id"`
+	DonationID          *uuid.UUID `json:"donation_id,omitempty"`
+	Reason              string     `json:"reason"`
+	Permanent           bool       `json:"permanent"`
+	DeferredUntil       *time.Time `json:"deferred_until,omitempty"`
+	FollowUpRequired    bool       `json:"follow_up_required"`
+	FollowUpCompletedAt *time.Time `json:"follow_up_completed_at,omitempty"`
+	FollowUpNote        string     `json:"follow_up_note,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// RecordVitalSignsRequest memuat hasil pemeriksaan fisik pra-donor. Batas binding hanya menolak
+// angka yang mustahil; rentang kelayakan donor dinilai di usecase.
+type RecordVitalSignsRequest struct {
+	HaemoglobinGdl float64    `json:"haemoglobin_gdl" binding:"required,gt=0,lte=25"`
+	SystolicMmHg   int        `json:"systolic_mmhg" binding:"required,min=40,max=300"`
+	DiastolicMmHg  int        `json:"diastolic_mmhg" binding:"required,min=20,max=200"`
+	PulseBpm       int        `json:"pulse_bpm" binding:"required,min=20,max=250"`
+	TemperatureC   float64    `json:"temperature_c" binding:"required,min=30,max=45"`
+	WeightKg       float64    `json:"weight_kg" binding:"required,gt=0,lte=300"`
+	MeasuredAt     *time.Time `json:"measured_at"`
+}
+
+type VitalSignsResponse struct {
+	ID             string    `json:"id"`
+	DonationID     string    `json:"donation_id"`
+	DonationStatus string    `json:"donation_status,omitempty"`
+	HaemoglobinGdl float64   `json:"haemoglobin_gdl"`
+	SystolicMmHg   int       `json:"systolic_mmhg"`
+	DiastolicMmHg  int       `json:"diastolic_mmhg"`
+	PulseBpm       int       `json:"pulse_bpm"`
+	TemperatureC   float64   `json:"temperature_c"`
+	WeightKg       float64   `json:"weight_kg"`
+	Outcome        string    `json:"outcome"` // accepted, cancelled, deferred
+	Reason         string    `json:"reason,omitempty"`
+	MeasuredBy     string    `json:"measured_by"`
+	MeasuredAt     time.Time `json:"measured_at"`
+}
@@ -7,21 +7,194 @@ import (
 )
 
 type StockRequest struct {
-	BloodType   string    `json:"blood_type" binding:"required,oneof=A B AB O"`
-	Rhesus      string    `json:"rhesus" binding:"required,oneof=+ -"`
-	BagQuantity int       `json:"bag_quantity" binding:"required,gte=0"`
-	LocationID  uuid.UUID `json:"location_id" binding:"required"`
+	BloodType  string    `json:"blood_type" binding:"required,oneof=A B AB O"`
+	Rhesus     string    `json:"rhesus" binding:"required,oneof=+ -"`
+	Component  string    `json:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	LocationID uuid.UUID `json:"location_id" binding:"required"`
 }
 
+// IncrementStockRequest menambah stok dengan mendaftarkan kantong baru ke baris stok; golongan,
+// komponen, dan lokasi kantong mengikuti baris stok tersebut, dan tanggal kedaluwarsa dihitung dari
+// masa simpan komponennya.
+type IncrementStockRequest struct {
+	Bags   []StockBagInput `json:"bags" binding:"required,min=1,dive"`
+	Reason string          `json:"reason"`
+}
+
+type StockBagInput struct {
+	BagNumber      string    `json:"bag_number" binding:"required"`
+	DonationID     uuid.UUID `json:"donation_id" binding:"required"`
+	CollectionDate time.Time `json:"collection_date" binding:"required"`
+}
+
+// UpdateQuantityRequest mengurangi stok dengan memusnahkan sejumlah kantong available.
 type UpdateQuantityRequest struct {
-	BagQuantity int `json:"bag_quantity" binding:"required,gte=0"`
+	BagQuantity int    `json:"bag_quantity" binding:"required,gt=0"`
+	Reason      string `json:"reason"`
 }
 
 type StockResponse struct {
 	ID          string    `json:"id"`
 	BloodType   string    `json:"blood_type"`
 	Rhesus      string    `json:"rhesus"`
+	Component   string    `json:"component"`
 	BagQuantity int       `json:"bag_quantity"`
 	LocationID  string    `json:"location_id"`
+	Version     int       `json:"version"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
+
+type AllocateStockRequest struct {
+	BloodType  string    `json:"blood_type" binding:"required,oneof=A B AB O"`
+	Rhesus     string    `json:"rhesus" binding:"required,oneof=+ -"`
+	Component  string    `json:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	LocationID uuid.UUID `json:"location_id" binding:"required"`
+	Quantity   int       `json:"quantity" binding:"required,gt=0"`
+	Reason     string    `json:"reason"`
+}
+
+type StockSummaryResponse struct {
+	BloodType   string `json:"blood_type"`
+	Rhesus      string `json:"rhesus"`
+	Component   string `json:"component"`
+	BagQuantity int64  `json:"bag_quantity"`
+}
+
+type AllocateStockResponse struct {
+	Quantity int                 `json:"quantity"`
+	Units    []BloodUnitResponse `json:"units"`
+}
+
+type ExpiredStockItem struct {
+	BloodType string `json:"blood_type"`
+	Rhesus    string `json:"rhesus"`
+	Component string `json:"component"`
+	Quantity  int    `json:"quantity"`
+}
+
+type LocationExpiryReport struct {
+	LocationID string             `json:"location_id"`
+	Total      int                `json:"total"`
+	Items      []ExpiredStockItem `json:"items"`
+}
+
+type ExpiryReportResponse struct {
+	RunAt      time.Time              `json:"run_at"`
+	TotalUnits int                    `json:"total_units"`
+	Locations  []LocationExpiryReport `json:"locations"`
+}
+
+type StockMovementResponse struct {
+	ID           string     `json:"id"`
+	StockID      string     `json:"stock_id"`
+	Type         string     `json:"type"`
+	Quantity     int        `json:"quantity"`
+	BalanceAfter int        `json:"balance_after"`
+	Reason       string     `json:"reason"`
+	ReferenceID  *uuid.UUID `json:"reference_id,omitempty"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type StockReconciliationResponse struct {
+	StockID        string `json:"stock_id"`
+	BagQuantity    int    `json:"bag_quantity"`
+	LedgerBalance  int64  `json:"ledger_balance"`
+	AvailableUnits int64  `json:"available_units"`
+	Consistent     bool   `json:"consistent"`
+}
+
+type StockThresholdRequest struct {
+	LocationID    uuid.UUID `json:"location_id" binding:"required"`
+	BloodType     string    `json:"blood_type" binding:"required,oneof=A B AB O"`
+	Rhesus        string    `json:"rhesus" binding:"required,oneof=+ -"`
+	MinimumLevel  int       `json:"minimum_level" binding:"gte=0"`
+	CriticalLevel int       `json:"critical_level" binding:"gte=0"`
+}
+
+type StockThresholdResponse struct {
+	ID            string    `json:"id"`
+	LocationID    string    `json:"location_id"`
+	BloodType     string    `json:"blood_type"`
+	Rhesus        string    `json:"rhesus"`
+	MinimumLevel  int       `json:"minimum_level"`
+	CriticalLevel int       `json:"critical_level"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type StockAlertResponse struct {
+	ID             string     `json:"id"`
+	LocationID     string     `json:"location_id"`
+	BloodType      string     `json:"blood_type"`
+	Rhesus         string     `json:"rhesus"`
+	Level          string     `json:"level"`
+	Quantity       int        `json:"quantity"`
+	Threshold      int        `json:"threshold"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type AvailabilityCell struct {
+	BloodType   string `json:"blood_type"`
+	Rhesus      string `json:"rhesus"`
+	BagQuantity int64  `json:"bag_quantity"`
+}
+
+type LocationAvailability struct {
+	LocationID   string             `json:"location_id"`
+	LocationName string             `json:"location_name"`
+	City         string             `json:"city"`
+	TenantID     string             `json:"tenant_id"`
+	Total        int64              `json:"total"`
+	Items        []AvailabilityCell `json:"items"`
+}
+
+type TenantAvailability struct {
+	TenantID string             `json:"tenant_id"`
+	Total    int64              `json:"total"`
+	Items    []AvailabilityCell `json:"items"`
+}
+
+type PlatformAvailability struct {
+	Total int64              `json:"total"`
+	Items []AvailabilityCell `json:"items"`
+}
+
+type AvailabilityMatrixResponse struct {
+	City        string                 `json:"city,omitempty"`
+	Component   string                 `json:"component,omitempty"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	Locations   []LocationAvailability `json:"locations"`
+	Tenants     []TenantAvailability   `json:"tenants"`
+	Platform    PlatformAvailability   `json:"platform"`
+}
+
+type StockForecastQuery struct {
+	LocationID string `form:"location_id" binding:"omitempty,uuid"`
+	Component  string `form:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	Rhesus     string `form:"rhesus" binding:"omitempty,oneof=+ -"`
+	WindowDays int    `form:"window_days" binding:"omitempty,min=7,max=365"`
+	TargetDays int    `form:"target_days" binding:"omitempty,min=1,max=90"`
+}
+
+type BloodTypeForecast struct {
+	BloodType             string     `json:"blood_type"`
+	Rhesus                string     `json:"rhesus"`
+	CurrentStock          int64      `json:"current_stock"`
+	AverageDailyUsage     float64    `json:"average_daily_usage"`
+	DaysOfSupply          *float64   `json:"days_of_supply"`
+	ProjectedStockOutDate *time.Time `json:"projected_stock_out_date"`
+	RecommendedDonors     int        `json:"recommended_donors"`
+}
+
+type StockForecastResponse struct {
+	LocationID  string              `json:"location_id,omitempty"`
+	Component   string              `json:"component,omitempty"`
+	Rhesus      string              `json:"rhesus,omitempty"`
+	Method      string              `json:"method"` // moving_average, seasonal
+	WindowDays  int                 `json:"window_days"`
+	TargetDays  int                 `json:"target_days"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Items       []BloodTypeForecast `json:"items"`
+}
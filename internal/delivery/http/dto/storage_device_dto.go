@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StorageDeviceRequest struct {
+	LocationID   uuid.UUID `json:"location_id" binding:"required"`
+	Name         string    `json:"name" binding:"required"`
+	DeviceClass  string    `json:"device_class" binding:"required,oneof=refrigerator freezer platelet_agitator"`
+	SerialNumber string    `json:"serial_number"`
+	Active       *bool     `json:"active"`
+}
+
+type StorageDeviceResponse struct {
+	ID             string    `json:"id"`
+	TenantID       string    `json:"tenant_id"`
+	LocationID     string    `json:"location_id"`
+	Name           string    `json:"name"`
+	DeviceClass    string    `json:"device_class"`
+	SerialNumber   string    `json:"serial_number"`
+	Active         bool      `json:"active"`
+	MinTemperature float64   `json:"min_temperature"`
+	MaxTemperature float64   `json:"max_temperature"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TemperatureReadingInput adalah satu bacaan suhu (°C). RecordedAt kosong berarti waktu diterima server.
+type TemperatureReadingInput struct {
+	Temperature *float64  `json:"temperature" binding:"required"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+type RecordTemperatureRequest struct {
+	Readings []TemperatureReadingInput `json:"readings" binding:"required,min=1,dive"`
+}
+
+type TemperatureReadingResponse struct {
+	ID          string    `json:"id"`
+	Temperature float64   `json:"temperature"`
+	RecordedAt  time.Time `json:"recorded_at"`
+	OutOfRange  bool      `json:"out_of_range"`
+}
+
+type TemperatureExcursionResponse struct {
+	ID                 string     `json:"id"`
+	LocationID         string     `json:"location_id"`
+	DeviceID           string     `json:"device_id"`
+	Status             string     `json:"status"`
+	StartedAt          time.Time  `json:"started_at"`
+	EndedAt            *time.Time `json:"ended_at,omitempty"`
+	MinAllowed         float64    `json:"min_allowed"`
+	MaxAllowed         float64    `json:"max_allowed"`
+	LowestTemperature  float64    `json:"lowest_temperature"`
+	HighestTemperature float64    `json:"highest_temperature"`
+	ReadingCount       int        `json:"reading_count"`
+	FlaggedUnits       int        `json:"flagged_units"`
+}
+
+// RecordTemperatureResponse merangkum hasil pengiriman bacaan beserta excursion yang dibuka atau ditutup.
+type RecordTemperatureResponse struct {
+	DeviceID   string                         `json:"device_id"`
+	Accepted   int                            `json:"accepted"`
+	OutOfRange int                            `json:"out_of_range"`
+	Excursions []TemperatureExcursionResponse `json:"excursions"`
+}
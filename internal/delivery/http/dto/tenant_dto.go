@@ -7,6 +7,7 @@ type TenantRequest struct {
 
 // DTO untuk response (data aman untuk publik)
 type TenantResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
 }
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateTransferRequest struct {
+	SourceLocationID      uuid.UUID `json:"source_location_id" binding:"required"`
+	DestinationLocationID uuid.UUID `json:"destination_location_id" binding:"required"`
+	BloodType             string    `json:"blood_type" binding:"required,oneof=A B AB O"`
+	Rhesus                string    `json:"rhesus" binding:"required,oneof=+ -"`
+	Component             string    `json:"component" binding:"omitempty,oneof=WB PRC FFP TC CRYO"`
+	Quantity              int       `json:"quantity" binding:"required,gt=0"`
+	Note                  string    `json:"note"`
+}
+
+// ReceiveTransferRequest mencatat penerimaan; ReceivedQuantity boleh lebih kecil dari jumlah kiriman.
+type ReceiveTransferRequest struct {
+	ReceivedQuantity int    `json:"received_quantity" binding:"gte=0"`
+	Note             string `json:"note"`
+}
+
+type RejectTransferRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+type TransferResponse struct {
+	ID                    string     `json:"id"`
+	SourceLocationID      string     `json:"source_location_id"`
+	DestinationLocationID string     `json:"destination_location_id"`
+	BloodType             string     `json:"blood_type"`
+	Rhesus                string     `json:"rhesus"`
+	Component             string     `json:"component"`
+	Quantity              int        `json:"quantity"`
+	ReceivedQuantity      int        `json:"received_quantity"`
+	Status                string     `json:"status"`
+	Note                  string     `json:"note"`
+	ReceiptNote           string     `json:"receipt_note"`
+	DispatchedBy          *uuid.UUID `json:"dispatched_by,omitempty"`
+	ReceivedBy            *uuid.UUID `json:"received_by,omitempty"`
+	DispatchedAt          time.Time  `json:"dispatched_at"`
+	ShippedAt             *time.Time `json:"shipped_at,omitempty"`
+	ReceivedAt            *time.Time `json:"received_at,omitempty"`
+}
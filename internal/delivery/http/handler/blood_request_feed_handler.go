@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"donor-api/pkg/compatibility"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxPublicFeedLimit = 50
+
+type BloodRequestFeedHandler struct {
+	usecase usecase.BloodRequestFeedUsecase
+}
+
+func NewBloodRequestFeedHandler(usecase usecase.BloodRequestFeedUsecase) *BloodRequestFeedHandler {
+	return &BloodRequestFeedHandler{usecase: usecase}
+}
+
+// GetPublic godoc
+// @Summary      Get public blood request feed
+// @Description  Menampilkan permintaan darah yang masih berjalan untuk situs publik dan aplikasi mitra tanpa autentikasi. Hanya golongan darah, sisa kantong yang dibutuhkan, kota, nama lokasi, dan urgensi yang ditampilkan; data pasien dan pemohon tidak pernah disertakan. Hasil di-cache sebentar dan jumlah akses per IP dibatasi.
+// @Tags         Public
+// @Produce      json
+// @Param        city        query     string  false  "Kota lokasi permintaan"
+// @Param        blood_type  query     string  false  "Golongan darah"  Enums(A, B, AB, O)
+// @Param        page        query     int     false  "Nomor halaman"  default(1)
+// @Param        limit       query     int     false  "Jumlah item per halaman (maks. 50)"  default(10)
+// @Success      200         {object}  dto.SuccessWrapper  "Berhasil mengambil umpan permintaan darah"
+// @Failure      400         {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      429         {object}  dto.ErrorWrapper    "Terlalu banyak permintaan"
+// @Failure      500         {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /public/blood-requests [get]
+func (h *BloodRequestFeedHandler) GetPublic(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page = max(page, 1)
+	limit = min(max(limit, 1), maxPublicFeedLimit)
+
+	filter := repository.PublicFeedFilter{
+		City:      strings.TrimSpace(c.Query("city")),
+		BloodType: strings.ToUpper(c.Query("blood_type")),
+	}
+	if filter.BloodType != "" && !compatibility.Valid(filter.BloodType, "") {
+		helper.SendErrorResponse(c, http.StatusBadRequest, entity.ErrInvalidBloodGroup.Error())
+		return
+	}
+
+	paginatedResponse, err := h.usecase.FindPublic(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved public blood request feed", paginatedResponse)
+}
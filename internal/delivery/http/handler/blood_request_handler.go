@@ -3,12 +3,15 @@ package handler
 import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
 	"donor-api/internal/usecase"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type BloodRequestHandler struct {
@@ -21,7 +24,7 @@ func NewBloodRequestHandler(usecase usecase.BloodRequestUsecase) *BloodRequestHa
 
 // Create godoc
 // @Summary      Create a new blood request
-// @Description  Menambahkan permintaan darah baru ke sistem
+// @Description  Menambahkan permintaan darah baru ke sistem. Urgensi (routine, urgent, emergency) menentukan target waktu pemenuhan: 24 jam, 6 jam, dan 1 jam. Bila rhesus diisi, kantong yang cocok di lokasi permintaan ditahan sementara (TTL) untuk permintaan ini. Pembuat dan tenant dicatat dari token; pengguna tenant hanya dapat membuat permintaan untuk lokasi tenantnya. Rumah sakit asal harus milik tenant yang sama dengan lokasi. Data pasien hanya dikembalikan kepada admin dan superadmin.
 // @Tags         Blood Requests
 // @Accept       json
 // @Produce      json
@@ -29,6 +32,7 @@ func NewBloodRequestHandler(usecase usecase.BloodRequestUsecase) *BloodRequestHa
 // @Param        body  body      dto.BloodRequestRequest  true  "Data Permintaan Darah"
 // @Success      201   {object}  dto.SuccessWrapper       "Permintaan darah berhasil dibuat"
 // @Failure      400   {object}  dto.ErrorWrapper         "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper         "Lokasi atau rumah sakit tidak ditemukan"
 // @Failure      500   {object}  dto.ErrorWrapper         "Terjadi kesalahan internal"
 // @Router       /blood-requests [post]
 func (h *BloodRequestHandler) Create(c *gin.Context) {
@@ -38,18 +42,32 @@ func (h *BloodRequestHandler) Create(c *gin.Context) {
 		return
 	}
 
-	res, err := h.usecase.Create(c.Request.Context(), req)
+	actor, err := helper.GetActor(c)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Create(c.Request.Context(), req, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidBloodGroup):
+			helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			helper.SendErrorResponse(c, http.StatusNotFound, "Location or hospital not found")
+		default:
+			helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
+	redactPatient(c, &res)
 
 	helper.SendSuccessResponse(c, http.StatusCreated, "BloodRequest created successfully", res)
 }
 
 // GetAll godoc
 // @Summary      Get all blood requests
-// @Description  Mengambil daftar semua permintaan darah dengan paginasi
+// @Description  Mengambil daftar permintaan darah dengan paginasi. Superadmin melihat semua permintaan, pengguna tenant melihat permintaan tenantnya, dan pengguna tanpa tenant hanya melihat permintaan yang dibuatnya. Umpan publik tersedia di /public/blood-requests. Data pasien hanya dikembalikan kepada admin dan superadmin.
 // @Tags         Blood Requests
 // @Produce      json
 // @Security     BearerAuth
@@ -62,23 +80,66 @@ func (h *BloodRequestHandler) GetAll(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	paginatedResponse, err := h.usecase.FindAll(c.Request.Context(), page, limit)
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	paginatedResponse, err := h.usecase.FindAll(c.Request.Context(), actor, page, limit)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	for i := range paginatedResponse.Data {
+		redactPatient(c, &paginatedResponse.Data[i])
+	}
 
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood_requests", paginatedResponse)
 }
 
+// GetQueue godoc
+// @Summary      Get blood request work queue
+// @Description  Mengambil antrean permintaan darah tenant pemanggil yang masih berjalan, diurutkan dari urgensi tertinggi (emergency/cito, urgent, routine) lalu dari yang paling lama. Permintaan yang melewati target SLA ditandai overdue.
+// @Tags         Blood Requests
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Nomor halaman"  default(1)
+// @Param        limit  query     int  false  "Jumlah item per halaman"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil antrean permintaan darah"
+// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-requests/queue [get]
+func (h *BloodRequestHandler) GetQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	paginatedResponse, err := h.usecase.FindQueue(c.Request.Context(), actor, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for i := range paginatedResponse.Data {
+		redactPatient(c, &paginatedResponse.Data[i])
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood request queue", paginatedResponse)
+}
+
 // GetByID godoc
 // @Summary      Get blood request by ID
-// @Description  Mengambil satu permintaan darah berdasarkan ID
+// @Description  Mengambil satu permintaan darah berdasarkan ID beserta kantong yang ditahan, riwayat status, dan catatan penyerahan kantong. Data pasien hanya dikembalikan kepada admin dan superadmin.
 // @Tags         Blood Requests
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "ID Permintaan Darah"  format(uuid)
+// @Param        id   path      string              true  "ID Permintaan Darah"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data permintaan darah"
+// @Header       200  {string}  ETag                "Versi data untuk If-Match"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
 // @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
 // @Router       /blood-requests/{id} [get]
@@ -88,27 +149,40 @@ func (h *BloodRequestHandler) GetByID(c *gin.Context) {
 		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
-	res, err := h.usecase.FindByID(c.Request.Context(), id)
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.FindByID(c.Request.Context(), id, actor)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
 		return
 	}
 
+	redactPatient(c, &res)
+	helper.SetETag(c, res.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood_request", res)
 }
 
 // Update godoc
 // @Summary      Update a blood request
-// @Description  Memperbarui permintaan darah yang sudah ada berdasarkan ID
+// @Description  Memperbarui permintaan darah yang masih terbuka berdasarkan ID. Jumlah yang diturunkan sampai sama dengan kantong yang sudah diserahkan menutup permintaan sebagai fulfilled. Hanya pembuat, admin tenant yang sama, atau superadmin yang boleh mengubah.
 // @Tags         Blood Requests
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id    path      string                   true  "ID Permintaan Darah"  format(uuid)
-// @Param        body  body      dto.BloodRequestRequest  true  "Data Permintaan Darah yang Diperbarui"
-// @Success      200   {object}  dto.SuccessWrapper       "Permintaan darah berhasil diperbarui"
-// @Failure      400   {object}  dto.ErrorWrapper         "Format ID atau request tidak valid"
-// @Failure      500   {object}  dto.ErrorWrapper         "Terjadi kesalahan internal"
+// @Param        id        path      string                   true  "ID Permintaan Darah"  format(uuid)
+// @Param        body      body      dto.BloodRequestRequest  true  "Data Permintaan Darah yang Diperbarui"
+// @Param        If-Match  header    string                   false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper       "Permintaan darah berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper         "Format ID atau request tidak valid"
+// @Failure      403       {object}  dto.ErrorWrapper         "Tidak berhak mengubah permintaan ini"
+// @Failure      404       {object}  dto.ErrorWrapper         "Data tidak ditemukan"
+// @Failure      409       {object}  dto.ErrorWrapper         "Permintaan sudah ditutup atau Quantity lebih kecil dari kantong yang sudah diserahkan"
+// @Failure      412       {object}  dto.ErrorWrapper         "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper         "Terjadi kesalahan internal"
 // @Router       /blood-requests/{id} [put]
 func (h *BloodRequestHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -122,24 +196,231 @@ func (h *BloodRequestHandler) Update(c *gin.Context) {
 		return
 	}
 
-	res, err := h.usecase.Update(c.Request.Context(), id, req)
+	actor, err := helper.GetActor(c)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Update(c.Request.Context(), id, req, actor, helper.ParseIfMatch(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidBloodGroup):
+			helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrExceedsRequestQuantity),
+			errors.Is(err, entity.ErrInvalidRequestStatus):
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			sendUpdateError(c, err)
+		}
 		return
 	}
 
+	redactPatient(c, &res)
+	helper.SetETag(c, res.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "BloodRequest updated successfully", res)
 }
 
+// UpdateStatus godoc
+// @Summary      Transition blood request status
+// @Description  Memindahkan status permintaan darah sesuai alur: pending → approved/rejected/cancelled, approved/partially_fulfilled → cancelled. Setiap perpindahan dicatat beserta waktu, pelaku, dan catatan. cancelled/rejected melepas kantong yang ditahan. partially_fulfilled dan fulfilled diatur otomatis saat kantong diserahkan. Pembuat hanya dapat membatalkan; approved dan rejected diberikan oleh admin tenant atau superadmin.
+// @Tags         Blood Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                           true  "ID Permintaan Darah"  format(uuid)
+// @Param        body      body      dto.UpdateBloodRequestStatusDTO  true  "Status Baru"
+// @Param        If-Match  header    string                           false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper               "Status permintaan darah berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper                 "Format ID atau request tidak valid"
+// @Failure      403       {object}  dto.ErrorWrapper                 "Tidak berhak mengubah status permintaan ini"
+// @Failure      404       {object}  dto.ErrorWrapper                 "Data tidak ditemukan"
+// @Failure      409       {object}  dto.ErrorWrapper                 "Perpindahan status tidak diizinkan"
+// @Failure      412       {object}  dto.ErrorWrapper                 "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper                 "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/status [patch]
+func (h *BloodRequestHandler) UpdateStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.UpdateBloodRequestStatusDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.UpdateStatus(c.Request.Context(), id, req, actor, helper.ParseIfMatch(c))
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRequestStatus) {
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		sendUpdateError(c, err)
+		return
+	}
+
+	redactPatient(c, &res)
+	helper.SetETag(c, res.Version)
+	helper.SendSuccessResponse(c, http.StatusOK, "BloodRequest status updated successfully", res)
+}
+
+// Issue godoc
+// @Summary      Issue bags for a blood request
+// @Description  Menyerahkan kantong untuk permintaan darah yang sudah disetujui dan mencatatnya sebagai fulfillment. Kantong yang ditahan untuk permintaan ini didahulukan, sisanya diambil dari stok dengan kedaluwarsa terdekat. Status menjadi partially_fulfilled atau fulfilled sesuai total kantong yang sudah diserahkan dibandingkan Quantity.
+// @Tags         Blood Requests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                    true  "ID Permintaan Darah"  format(uuid)
+// @Param        body      body      dto.IssueBloodRequestDTO  true  "Data Penyerahan"
+// @Param        If-Match  header    string                    false  "ETag dari respons GET"
+// @Success      201       {object}  dto.SuccessWrapper        "Kantong berhasil diserahkan"
+// @Failure      400       {object}  dto.ErrorWrapper          "Format ID atau request tidak valid"
+// @Failure      403       {object}  dto.ErrorWrapper          "Permintaan milik tenant lain"
+// @Failure      404       {object}  dto.ErrorWrapper          "Data tidak ditemukan"
+// @Failure      409       {object}  dto.ErrorWrapper          "Status permintaan, jumlah, atau stok tidak memungkinkan"
+// @Failure      412       {object}  dto.ErrorWrapper          "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper          "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/fulfillments [post]
+func (h *BloodRequestHandler) Issue(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.IssueBloodRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Issue(c.Request.Context(), id, req, actor, helper.ParseIfMatch(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidBloodGroup):
+			helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, entity.ErrInvalidRequestStatus),
+			errors.Is(err, entity.ErrExceedsRequestQuantity),
+			errors.Is(err, entity.ErrInsufficientStock):
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			sendUpdateError(c, err)
+		}
+		return
+	}
+
+	redactPatient(c, &res)
+	helper.SetETag(c, res.Version)
+	helper.SendSuccessResponse(c, http.StatusCreated, "BloodRequest bags issued successfully", res)
+}
+
+// MatchPlan godoc
+// @Summary      Get fulfillment plan for a blood request
+// @Description  Mencari stok yang cocok untuk permintaan darah, dimulai dari lokasi permintaan lalu meluas ke lokasi lain milik tenant yang sama berdasarkan jarak. Golongan yang sama persis didahulukan dari golongan pengganti yang kompatibel. Hasilnya berupa rencana berperingkat berisi lokasi, golongan, dan jumlah kantong yang dapat disuplai, serta kekurangan bila stok tidak mencukupi.
+// @Tags         Blood Requests
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Permintaan Darah"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Rencana pemenuhan berhasil disusun"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Permintaan sudah selesai"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/match-plan [get]
+func (h *BloodRequestHandler) MatchPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.MatchPlan(c.Request.Context(), id, actor)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRequestStatus) {
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		sendUpdateError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Match plan retrieved successfully", res)
+}
+
+// ApproveMatchPlan godoc
+// @Summary      Approve fulfillment plan for a blood request
+// @Description  Menyusun ulang rencana pemenuhan dari stok terkini lalu menahan seluruh kantong yang direncanakan untuk permintaan ini dalam satu transaksi. Tiap item dibatasi sisa kebutuhan yang belum terpenuhi maupun tertahan. Bila salah satu item rencana tidak lagi tersedia, tidak ada kantong yang ditahan. Hanya admin tenant permintaan atau superadmin yang dapat menyetujui.
+// @Tags         Blood Requests
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Permintaan Darah"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Rencana pemenuhan berhasil disetujui"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Permintaan milik tenant lain"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Permintaan sudah selesai, sudah tertahan penuh, atau stok tidak mencukupi"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/match-plan/approve [post]
+func (h *BloodRequestHandler) ApproveMatchPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.ApproveMatchPlan(c.Request.Context(), id, actor)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidRequestStatus) || errors.Is(err, entity.ErrInsufficientStock) ||
+			errors.Is(err, entity.ErrRequestFullyReserved) {
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		sendUpdateError(c, err)
+		return
+	}
+
+	redactPatient(c, &res)
+	helper.SetETag(c, res.Version)
+	helper.SendSuccessResponse(c, http.StatusOK, "Match plan approved successfully", res)
+}
+
 // Delete godoc
 // @Summary      Delete a blood request
-// @Description  Menghapus permintaan darah berdasarkan ID
+// @Description  Menghapus permintaan darah berdasarkan ID. Hanya pembuat, admin tenant yang sama, atau superadmin yang boleh menghapus. Hanya permintaan pending atau dibatalkan yang belum memiliki penyerahan yang dapat dihapus.
 // @Tags         Blood Requests
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID Permintaan Darah"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Permintaan darah berhasil dihapus"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Tidak berhak menghapus permintaan ini"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Permintaan sudah diproses atau memiliki penyerahan"
 // @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /blood-requests/{id} [delete]
 func (h *BloodRequestHandler) Delete(c *gin.Context) {
@@ -149,10 +430,29 @@ func (h *BloodRequestHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.Delete(c.Request.Context(), id)
+	actor, err := helper.GetActor(c)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.usecase.Delete(c.Request.Context(), id, actor)
+	if err != nil {
+		if errors.Is(err, entity.ErrRequestNotDeletable) {
+			helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		sendUpdateError(c, err)
 		return
 	}
 	helper.SendSuccessResponse(c, http.StatusOK, "BloodRequest deleted successfully", "")
 }
+
+// redactPatient menghapus data pasien dari respons bila pemanggil bukan admin atau superadmin.
+func redactPatient(c *gin.Context, res *dto.BloodRequestResponse) {
+	switch c.GetString("role") {
+	case "superadmin", "admin":
+		return
+	}
+	res.Patient = nil
+}
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type BloodUnitHandler struct {
+	usecase usecase.BloodUnitUsecase
+}
+
+func NewBloodUnitHandler(usecase usecase.BloodUnitUsecase) *BloodUnitHandler {
+	return &BloodUnitHandler{usecase: usecase}
+}
+
+// Create godoc
+// @Summary      Create a new blood unit
+// @Description  Mendaftarkan satu kantong darah baru dari donasi selesai di tenant yang sama, dengan golongan pendonor yang cocok dan belum pernah menjadi unit. Unit dikarantina sampai skrining donasi lengkap negatif; stok terkait bertambah begitu unit available.
+// @Tags         Blood Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.BloodUnitRequest  true  "Data Kantong Darah"
+// @Success      201   {object}  dto.SuccessWrapper    "Kantong darah berhasil dibuat"
+// @Failure      400   {object}  dto.ErrorWrapper      "Request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper      "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      404   {object}  dto.ErrorWrapper      "Donasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper      "Donasi reaktif, belum selesai, sudah diproses, atau golongan pendonor berbeda"
+// @Failure      500   {object}  dto.ErrorWrapper      "Terjadi kesalahan internal"
+// @Router       /blood-units [post]
+func (h *BloodUnitHandler) Create(c *gin.Context) {
+	var req dto.BloodUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Create(c.Request.Context(), req, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Blood unit created successfully", toBloodUnitResponse(result))
+}
+
+// GetAll godoc
+// @Summary      Get all blood units
+// @Description  Mengambil daftar kantong darah di tenant pemanggil dengan filter dan paginasi, diurutkan dari tanggal kedaluwarsa terdekat
+// @Tags         Blood Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status        query     string  false  "Status unit"
+// @Param        blood_type    query     string  false  "Golongan darah"
+// @Param        rhesus        query     string  false  "Rhesus"
+// @Param        component     query     string  false  "Komponen darah"
+// @Param        location_id   query     string  false  "ID Lokasi"  format(uuid)
+// @Param        needs_review  query     bool    false  "Hanya unit yang menunggu review excursion suhu"
+// @Param        page          query     int     false  "Nomor halaman"  default(1)
+// @Param        limit         query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200           {object}  dto.SuccessWrapper  "Berhasil mengambil daftar kantong darah"
+// @Failure      400           {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500           {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-units [get]
+func (h *BloodUnitHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filter := repository.BloodUnitFilter{
+		Status:    c.Query("status"),
+		BloodType: c.Query("blood_type"),
+		Rhesus:    c.Query("rhesus"),
+		Component: c.Query("component"),
+	}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+	if needsReview := c.Query("needs_review"); needsReview != "" {
+		value, err := strconv.ParseBool(needsReview)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid needs_review value")
+			return
+		}
+		filter.NeedsReview = &value
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit, actor)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.BloodUnitResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toBloodUnitResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.BloodUnitResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood units", paginatedResponse)
+}
+
+// GetByID godoc
+// @Summary      Get blood unit by ID
+// @Description  Mengambil satu data kantong darah berdasarkan ID
+// @Tags         Blood Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Kantong Darah"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data kantong darah"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /blood-units/{id} [get]
+func (h *BloodUnitHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := h.usecase.FindByID(c.Request.Context(), id, actor)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood unit", toBloodUnitResponse(result))
+}
+
+// Update godoc
+// @Summary      Update a blood unit
+// @Description  Mengoreksi data kantong darah yang masih available. Lokasi hanya berpindah lewat transfer dan donasi asal tidak dapat diubah.
+// @Tags         Blood Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                true  "ID Kantong Darah"  format(uuid)
+// @Param        body  body      dto.BloodUnitRequest  true  "Data Kantong Darah yang Diperbarui"
+// @Success      200   {object}  dto.SuccessWrapper    "Kantong darah berhasil diperbarui"
+// @Failure      400   {object}  dto.ErrorWrapper      "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper      "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      409   {object}  dto.ErrorWrapper      "Unit tidak available, lokasi atau donasi asal berubah, atau stok tidak mencukupi"
+// @Failure      412   {object}  dto.ErrorWrapper      "Unit telah diubah oleh permintaan lain"
+// @Failure      500   {object}  dto.ErrorWrapper      "Terjadi kesalahan internal"
+// @Router       /blood-units/{id} [put]
+func (h *BloodUnitHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.BloodUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Update(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Blood unit updated successfully", toBloodUnitResponse(result))
+}
+
+// UpdateStatus godoc
+// @Summary      Update blood unit status
+// @Description  Mengubah status kantong darah available menjadi issued, expired, atau discarded. Stok dihitung ulang otomatis. Unit karantina hanya bisa dimusnahkan; issued, expired, dan discarded adalah status akhir.
+// @Tags         Blood Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                            true  "ID Kantong Darah"  format(uuid)
+// @Param        body  body      dto.UpdateBloodUnitStatusRequest  true  "Status Baru"
+// @Success      200   {object}  dto.SuccessWrapper                "Status kantong darah berhasil diperbarui"
+// @Failure      400   {object}  dto.ErrorWrapper                  "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper                  "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      409   {object}  dto.ErrorWrapper                  "Status tidak bisa diubah atau unit masih dikarantina"
+// @Failure      500   {object}  dto.ErrorWrapper                  "Terjadi kesalahan internal"
+// @Router       /blood-units/{id}/status [put]
+func (h *BloodUnitHandler) UpdateStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.UpdateBloodUnitStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.UpdateStatus(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Blood unit status updated successfully", toBloodUnitResponse(result))
+}
+
+// Review godoc
+// @Summary      Review a flagged blood unit
+// @Description  Menyelesaikan review unit yang ditandai akibat excursion suhu: release mengembalikan unit ke stok, discard memusnahkannya
+// @Tags         Blood Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                      true  "ID Kantong Darah"  format(uuid)
+// @Param        body  body      dto.ReviewBloodUnitRequest  true  "Keputusan Review"
+// @Success      200   {object}  dto.SuccessWrapper          "Review kantong darah berhasil disimpan"
+// @Failure      400   {object}  dto.ErrorWrapper            "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper            "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      404   {object}  dto.ErrorWrapper            "Data tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper            "Unit tidak sedang menunggu review"
+// @Failure      500   {object}  dto.ErrorWrapper            "Terjadi kesalahan internal"
+// @Router       /blood-units/{id}/review [put]
+func (h *BloodUnitHandler) Review(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.ReviewBloodUnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Review(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Blood unit reviewed successfully", toBloodUnitResponse(result))
+}
+
+// Delete godoc
+// @Summary      Delete a blood unit
+// @Description  Menghapus kantong darah karantina atau available berdasarkan ID. Tahanan aktif atas kantong ikut dilepas.
+// @Tags         Blood Units
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Kantong Darah"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Kantong darah berhasil dihapus"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Unit sudah ditahan, dikirim, atau keluar dari stok"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-units/{id} [delete]
+func (h *BloodUnitHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.usecase.Delete(c.Request.Context(), id, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Blood unit deleted successfully", "")
+}
+
+// ProcessDonation godoc
+// @Summary      Process donation into components
+// @Description  Memecah satu donasi yang sudah selesai menjadi beberapa komponen (PRC, FFP, TC, CRYO), masing-masing dengan tanggal kedaluwarsa sendiri. Unit berstatus quarantine sampai hasil skrining lengkap negatif.
+// @Tags         Blood Units
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                      true  "ID Donasi"  format(uuid)
+// @Param        body  body      dto.ProcessDonationRequest  true  "Komponen yang Diproses"
+// @Success      201   {object}  dto.SuccessWrapper          "Komponen berhasil dibuat"
+// @Failure      400   {object}  dto.ErrorWrapper            "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper            "Tidak berhak mengelola unit di lokasi ini"
+// @Failure      404   {object}  dto.ErrorWrapper            "Donasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper            "Donasi belum selesai, sudah diproses, atau reaktif"
+// @Failure      500   {object}  dto.ErrorWrapper            "Terjadi kesalahan internal"
+// @Router       /donations/{id}/components [post]
+func (h *BloodUnitHandler) ProcessDonation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.ProcessDonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	units, err := h.usecase.ProcessDonation(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendBloodUnitError(c, err)
+		return
+	}
+
+	res := make([]dto.BloodUnitResponse, 0, len(units))
+	for _, unit := range units {
+		res = append(res, toBloodUnitResponse(unit))
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Donation processed successfully", res)
+}
+
+func toBloodUnitResponse(unit entity.BloodUnit) dto.BloodUnitResponse {
+	var res dto.BloodUnitResponse
+	copier.Copy(&res, &unit)
+	res.ID = unit.ID.String()
+	res.LocationID = unit.LocationID.String()
+	return res
+}
+
+func sendBloodUnitError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrForbidden):
+		helper.SendErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrUnknownBloodGroup),
+		errors.Is(err, entity.ErrStorageDeviceMismatch):
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrInsufficientStock),
+		errors.Is(err, entity.ErrDonationNotCompleted),
+		errors.Is(err, entity.ErrDonationAlreadyProcessed),
+		errors.Is(err, entity.ErrUnitNotUnderReview),
+		errors.Is(err, entity.ErrDonationReactive),
+		errors.Is(err, entity.ErrUnitInQuarantine),
+		errors.Is(err, entity.ErrInvalidUnitStatus),
+		errors.Is(err, entity.ErrDonorBloodGroupMismatch),
+		errors.Is(err, entity.ErrUnitNotEditable),
+		errors.Is(err, entity.ErrUnitNotDeletable),
+		errors.Is(err, entity.ErrUnitLocationFixed),
+		errors.Is(err, entity.ErrUnitDonationFixed):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrVersionConflict):
+		helper.SendErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
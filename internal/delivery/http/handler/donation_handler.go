@@ -3,13 +3,16 @@ package handler
 import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
 	"donor-api/internal/usecase"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
 type DonationHandler struct {
@@ -22,7 +25,7 @@ func NewDonationHandler(usecase usecase.DonationUsecase) *DonationHandler {
 
 // Create godoc
 // @Summary      Create a new donation
-// @Description  Menambahkan data donasi baru ke sistem
+// @Description  Mendaftarkan (booking) donasi baru dengan status pending. Pendonor harus layak mendonor pada tanggal donasi menurut kebijakan kelayakan tenant lokasi (usia, berat badan, jarak donasi, dan penundaan). Donasi diselesaikan lewat PUT /donations/{id}.
 // @Tags         Donations
 // @Accept       json
 // @Produce      json
@@ -30,6 +33,8 @@ func NewDonationHandler(usecase usecase.DonationUsecase) *DonationHandler {
 // @Param        body  body      dto.CreateDonationRequest  true  "Data Donasi Baru"
 // @Success      201   {object}  dto.SuccessWrapper         "Donasi berhasil dibuat"
 // @Failure      400   {object}  dto.ErrorWrapper           "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Lokasi atau profil pendonor tidak ditemukan"
+// @Failure      422   {object}  dto.ErrorWrapper           "Pendonor tidak layak mendonor"
 // @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
 // @Router       /donations [post]
 func (h *DonationHandler) Create(c *gin.Context) {
@@ -41,12 +46,7 @@ func (h *DonationHandler) Create(c *gin.Context) {
 
 	result, err := h.usecase.Create(c.Request.Context(), req)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		sendDonationError(c, err)
 		return
 	}
 
@@ -127,7 +127,7 @@ func (h *DonationHandler) GetByID(c *gin.Context) {
 
 // Update godoc
 // @Summary      Update a donation
-// @Description  Memperbarui data donasi yang sudah ada berdasarkan ID
+// @Description  Memperbarui data donasi yang sudah ada berdasarkan ID. Menyelesaikan donasi (status selesai) menilai ulang kelayakan pendonor pada tanggal donasi serta mensyaratkan kuesioner pra-donor sudah diisi dan pemeriksaan pra-donor diterima (accepted).
 // @Tags         Donations
 // @Accept       json
 // @Produce      json
@@ -136,6 +136,9 @@ func (h *DonationHandler) GetByID(c *gin.Context) {
 // @Param        body  body      dto.UpdateDonationRequest  true  "Data Donasi yang Diperbarui"
 // @Success      200   {object}  dto.SuccessWrapper         "Donasi berhasil diperbarui"
 // @Failure      400   {object}  dto.ErrorWrapper           "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Data tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper           "Kuesioner pra-donor belum diisi atau pemeriksaan pra-donor belum diterima"
+// @Failure      422   {object}  dto.ErrorWrapper           "Pendonor tidak layak mendonor"
 // @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
 // @Router       /donations/{id} [put]
 func (h *DonationHandler) Update(c *gin.Context) {
@@ -152,7 +155,7 @@ func (h *DonationHandler) Update(c *gin.Context) {
 
 	result, err := h.usecase.Update(c.Request.Context(), id, req)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		sendDonationError(c, err)
 		return
 	}
 
@@ -188,3 +191,18 @@ func (h *DonationHandler) Delete(c *gin.Context) {
 	}
 	helper.SendSuccessResponse(c, http.StatusOK, "Donation deleted successfully", "")
 }
+
+// sendDonationError memetakan error pembuatan dan pembaruan donasi ke status HTTP.
+func sendDonationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrDonorNotEligible):
+		helper.SendErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, entity.ErrQuestionnaireMissing),
+		errors.Is(err, entity.ErrVitalSignsMissing):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type DonorCallUpHandler struct {
+	usecase usecase.DonorCallUpUsecase
+}
+
+func NewDonorCallUpHandler(usecase usecase.DonorCallUpUsecase) *DonorCallUpHandler {
+	return &DonorCallUpHandler{usecase: usecase}
+}
+
+// Broadcast godoc
+// @Summary      Broadcast a donor call-up
+// @Description  Mengirim panggilan donor untuk permintaan darah yang tidak dapat dipenuhi dari stok. Penerima adalah pendonor aktif yang golongannya cocok, berada dalam radius lokasi permintaan, dan layak mendonor menurut kebijakan kelayakan tenant, yang terdekat lebih dulu. Tiap penerima mendapat notifikasi. Pendonor yang sudah dipanggil untuk permintaan yang sama tidak dipanggil lagi.
+// @Tags         Donor Call-ups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                           true  "ID Permintaan Darah"  format(uuid)
+// @Param        body  body      dto.BroadcastDonorCallUpRequest  true  "Jangkauan Panggilan"
+// @Success      201   {object}  dto.SuccessWrapper               "Panggilan donor berhasil dikirim"
+// @Failure      400   {object}  dto.ErrorWrapper                 "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper                 "Permintaan darah tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper                 "Stok mencukupi, permintaan sudah ditutup, atau tidak ada pendonor dalam radius"
+// @Failure      500   {object}  dto.ErrorWrapper                 "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/call-ups [post]
+func (h *DonorCallUpHandler) Broadcast(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.BroadcastDonorCallUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	callUp, err := h.usecase.Broadcast(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendDonorCallUpError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Donor call-up broadcast successfully", toDonorCallUpResponse(callUp, true))
+}
+
+// GetByRequest godoc
+// @Summary      Get donor call-ups of a blood request
+// @Description  Mengambil daftar panggilan donor untuk permintaan darah beserta jumlah pendonor yang dipanggil, menjawab, bersedia, menolak, dan sudah datang, terbaru lebih dulu
+// @Tags         Donor Call-ups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string              true  "ID Permintaan Darah"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil daftar panggilan donor"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Permintaan darah tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /blood-requests/{id}/call-ups [get]
+func (h *DonorCallUpHandler) GetByRequest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	callUps, err := h.usecase.FindByRequestID(c.Request.Context(), id, actor)
+	if err != nil {
+		sendDonorCallUpError(c, err)
+		return
+	}
+
+	res := make([]dto.DonorCallUpResponse, 0, len(callUps))
+	for _, callUp := range callUps {
+		res = append(res, toDonorCallUpResponse(callUp, false))
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved donor call-ups", res)
+}
+
+// GetByID godoc
+// @Summary      Get donor call-up by ID
+// @Description  Mengambil satu panggilan donor beserta daftar penerima, jawaban, dan kedatangannya, diurutkan dari yang terdekat
+// @Tags         Donor Call-ups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string              true  "ID Panggilan Donor"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil panggilan donor"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /donor-call-ups/{id} [get]
+func (h *DonorCallUpHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	callUp, err := h.usecase.FindByID(c.Request.Context(), id, actor)
+	if err != nil {
+		sendDonorCallUpError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved donor call-up", toDonorCallUpResponse(callUp, true))
+}
+
+// Respond godoc
+// @Summary      Respond to a donor call-up
+// @Description  Pendonor yang dipanggil menjawab bersedia (accept) atau menolak (decline). Jawaban dapat diubah selama permintaan darah masih berjalan dan kedatangan belum dicatat.
+// @Tags         Donor Call-ups
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                         true  "ID Panggilan Donor"  format(uuid)
+// @Param        body  body      dto.RespondDonorCallUpRequest  true  "Jawaban Pendonor"
+// @Success      200   {object}  dto.SuccessWrapper             "Jawaban berhasil dicatat"
+// @Failure      400   {object}  dto.ErrorWrapper               "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper               "Panggilan tidak ditemukan untuk pendonor ini"
+// @Failure      409   {object}  dto.ErrorWrapper               "Permintaan sudah ditutup atau kedatangan sudah dicatat"
+// @Failure      500   {object}  dto.ErrorWrapper               "Terjadi kesalahan internal"
+// @Router       /donor-call-ups/{id}/response [put]
+func (h *DonorCallUpHandler) Respond(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.RespondDonorCallUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipient, err := h.usecase.Respond(c.Request.Context(), id, *userID, req)
+	if err != nil {
+		sendDonorCallUpError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Donor call-up response recorded successfully", toDonorCallUpRecipientResponse(recipient))
+}
+
+// MarkArrived godoc
+// @Summary      Record donor arrival
+// @Description  Mencatat kedatangan pendonor yang dipanggil di lokasi permintaan. Pendonor yang datang dihitung sebagai bersedia.
+// @Tags         Donor Call-ups
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string              true  "ID Panggilan Donor"  format(uuid)
+// @Param        userId  path      string              true  "ID Pendonor"  format(uuid)
+// @Success      200     {object}  dto.SuccessWrapper  "Kedatangan pendonor berhasil dicatat"
+// @Failure      400     {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404     {object}  dto.ErrorWrapper    "Pendonor tidak termasuk penerima panggilan"
+// @Failure      409     {object}  dto.ErrorWrapper    "Kedatangan sudah dicatat"
+// @Failure      500     {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /donor-call-ups/{id}/recipients/{userId}/arrival [put]
+func (h *DonorCallUpHandler) MarkArrived(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recipient, err := h.usecase.MarkArrived(c.Request.Context(), id, userID, actor)
+	if err != nil {
+		sendDonorCallUpError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Donor arrival recorded successfully", toDonorCallUpRecipientResponse(recipient))
+}
+
+// toDonorCallUpResponse merangkum jawaban penerima; daftar penerima hanya disertakan bila withRecipients.
+func toDonorCallUpResponse(callUp entity.DonorCallUp, withRecipients bool) dto.DonorCallUpResponse {
+	res := dto.DonorCallUpResponse{
+		ID:             callUp.ID.String(),
+		BloodRequestID: callUp.BloodRequestID.String(),
+		LocationID:     callUp.LocationID.String(),
+		RadiusKm:       callUp.RadiusKm,
+		Shortfall:      callUp.Shortfall,
+		Message:        callUp.Message,
+		CreatedBy:      callUp.CreatedBy.String(),
+		CreatedAt:      callUp.CreatedAt,
+		InvitedCount:   len(callUp.Recipients),
+	}
+	for _, recipient := range callUp.Recipients {
+		if recipient.RespondedAt != nil {
+			res.RespondedCount++
+		}
+		switch recipient.Status {
+		case entity.DonorCallUpAccepted:
+			res.AcceptedCount++
+		case entity.DonorCallUpDeclined:
+			res.DeclinedCount++
+		}
+		if recipient.ArrivedAt != nil {
+			res.ArrivedCount++
+		}
+		if withRecipients {
+			res.Recipients = append(res.Recipients, toDonorCallUpRecipientResponse(recipient))
+		}
+	}
+	return res
+}
+
+func toDonorCallUpRecipientResponse(recipient entity.DonorCallUpRecipient) dto.DonorCallUpRecipientResponse {
+	var res dto.DonorCallUpRecipientResponse
+	copier.Copy(&res, &recipient)
+	res.UserID = recipient.UserID.String()
+	return res
+}
+
+func sendDonorCallUpError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrStockCoversRequest),
+		errors.Is(err, entity.ErrInvalidRequestStatus),
+		errors.Is(err, entity.ErrLocationNotGeocoded),
+		errors.Is(err, entity.ErrNoDonorsInRange),
+		errors.Is(err, entity.ErrDonorAlreadyArrived):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
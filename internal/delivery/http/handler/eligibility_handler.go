@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EligibilityHandler struct {
+	usecase usecase.EligibilityUsecase
+}
+
+func NewEligibilityHandler(usecase usecase.EligibilityUsecase) *EligibilityHandler {
+	return &EligibilityHandler{usecase: usecase}
+}
+
+// GetMine godoc
+// @Summary      Check my donor eligibility
+// @Description  Menilai apakah pendonor yang login boleh mendonor pada tanggal tertentu (bawaan hari ini): usia, berat badan, jarak sejak donasi terakhir menurut jenis donasi yang direncanakan, dan penundaan yang berlaku. Bila location_id diisi, kebijakan tenant pemilik lokasi yang dipakai.
+// @Tags         Eligibility
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id    query     string              false  "ID Lokasi donasi"  format(uuid)
+// @Param        date           query     string              false  "Tanggal donasi (YYYY-MM-DD)"
+// @Param        donation_type  query     string              false  "Jenis donasi yang direncanakan (bawaan whole_blood)"  Enums(whole_blood, apheresis)
+// @Success      200            {object}  dto.SuccessWrapper  "Berhasil menilai kelayakan donor"
+// @Failure      400            {object}  dto.ErrorWrapper    "Parameter tidak valid"
+// @Failure      404            {object}  dto.ErrorWrapper    "Lokasi atau profil pendonor tidak ditemukan"
+// @Failure      500            {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /profile/eligibility [get]
+func (h *EligibilityHandler) GetMine(c *gin.Context) {
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.evaluate(c, *userID)
+}
+
+// GetByUser godoc
+// @Summary      Check a donor's eligibility
+// @Description  Menilai apakah pendonor boleh mendonor pada tanggal tertentu (bawaan hari ini). Bila location_id diisi, kebijakan tenant pemilik lokasi yang dipakai; bila tidak, kebijakan tenant pemanggil.
+// @Tags         Eligibility
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path      string              true   "ID Pengguna"  format(uuid)
+// @Param        location_id    query     string              false  "ID Lokasi donasi"  format(uuid)
+// @Param        date           query     string              false  "Tanggal donasi (YYYY-MM-DD)"
+// @Param        donation_type  query     string              false  "Jenis donasi yang direncanakan (bawaan whole_blood)"  Enums(whole_blood, apheresis)
+// @Success      200            {object}  dto.SuccessWrapper  "Berhasil menilai kelayakan donor"
+// @Failure      400            {object}  dto.ErrorWrapper    "Parameter tidak valid"
+// @Failure      404            {object}  dto.ErrorWrapper    "Lokasi atau profil pendonor tidak ditemukan"
+// @Failure      500            {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /users/{id}/eligibility [get]
+func (h *EligibilityHandler) GetByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	h.evaluate(c, userID)
+}
+
+func (h *EligibilityHandler) evaluate(c *gin.Context, userID uuid.UUID) {
+	day := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid date format")
+			return
+		}
+		day = parsed
+	}
+	donationType := c.DefaultQuery("donation_type", entity.DonationTypeWholeBlood)
+	if donationType != entity.DonationTypeWholeBlood && donationType != entity.DonationTypeApheresis {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid donation type")
+		return
+	}
+
+	var result dto.EligibilityResponse
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		result, err = h.usecase.EvaluateAtLocation(c.Request.Context(), userID, id, donationType, day)
+		if err != nil {
+			sendEligibilityError(c, err)
+			return
+		}
+	} else {
+		tenantID, err := helper.GetContextValue(c, "tenantID")
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		result, err = h.usecase.Evaluate(c.Request.Context(), userID, *tenantID, donationType, day)
+		if err != nil {
+			sendEligibilityError(c, err)
+			return
+		}
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully evaluated donor eligibility", result)
+}
+
+// GetPolicy godoc
+// @Summary      Get eligibility policy
+// @Description  Mengambil ambang kelayakan donor milik tenant: rentang usia, berat minimal, dan jarak donasi per jenis kelamin dan jenis donasi. Tenant yang belum mengatur kebijakan memakai kebijakan bawaan (is_default). Superadmin wajib mengisi tenant_id.
+// @Tags         Eligibility
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_id  query     string  false  "ID Tenant (khusus superadmin)"  format(uuid)
+// @Success      200        {object}  dto.SuccessWrapper  "Berhasil mengambil kebijakan kelayakan"
+// @Failure      400        {object}  dto.ErrorWrapper    "Parameter tidak valid"
+// @Failure      500        {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /eligibility-policy [get]
+func (h *EligibilityHandler) GetPolicy(c *gin.Context) {
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if *tenantID == uuid.Nil && c.Query("tenant_id") != "" {
+		id, err := uuid.Parse(c.Query("tenant_id"))
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid tenant ID format")
+			return
+		}
+		tenantID = &id
+	}
+
+	result, err := h.usecase.FindPolicy(c.Request.Context(), *tenantID)
+	if err != nil {
+		sendEligibilityError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved eligibility policy", result)
+}
+
+// SavePolicy godoc
+// @Summary      Set eligibility policy
+// @Description  Membuat atau memperbarui ambang kelayakan donor milik tenant. Penilaian kelayakan saat pendaftaran dan pencatatan donasi langsung memakai kebijakan ini. Superadmin wajib mengisi tenant_id.
+// @Tags         Eligibility
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.EligibilityPolicyRequest  true  "Kebijakan Kelayakan"
+// @Success      200   {object}  dto.SuccessWrapper            "Kebijakan kelayakan berhasil disimpan"
+// @Failure      400   {object}  dto.ErrorWrapper              "Request tidak valid"
+// @Failure      500   {object}  dto.ErrorWrapper              "Terjadi kesalahan internal"
+// @Router       /eligibility-policy [put]
+func (h *EligibilityHandler) SavePolicy(c *gin.Context) {
+	var req dto.EligibilityPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.SavePolicy(c.Request.Context(), req, *tenantID)
+	if err != nil {
+		sendEligibilityError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Eligibility policy saved successfully", result)
+}
+
+// sendEligibilityError memetakan error penilaian dan kebijakan kelayakan ke status HTTP.
+func sendEligibilityError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTenantRequired), errors.Is(err, entity.ErrInvalidEligibilityPolicy):
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Location or donor profile not found")
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// sendUpdateError memetakan error dari usecase Update ke status HTTP,
+// termasuk 403 bila pemanggil tidak berhak mengubah data dan 412 bila If-Match tidak cocok dengan versi terbaru.
+func sendUpdateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrForbidden):
+		helper.SendErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrVersionConflict):
+		helper.SendErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
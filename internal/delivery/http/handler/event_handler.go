@@ -4,14 +4,19 @@ import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/delivery/http/helper"
 	"donor-api/internal/usecase"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
+const maxPublicEventLimit = 50
+
 type EventHandler struct {
 	usecase usecase.EventUsecase
 }
@@ -22,7 +27,7 @@ func NewEventHandler(usecase usecase.EventUsecase) *EventHandler {
 
 // Create godoc
 // @Summary      Create a new event
-// @Description  Menambahkan acara (event) baru ke sistem
+// @Description  Menambahkan acara (event) baru ke sistem. Pembuat dan tenant dicatat dari token; pengguna tenant hanya dapat membuat acara di lokasi tenantnya.
 // @Tags         Events
 // @Accept       json
 // @Produce      json
@@ -30,6 +35,7 @@ func NewEventHandler(usecase usecase.EventUsecase) *EventHandler {
 // @Param        body  body      dto.EventRequest    true  "Data Acara Baru"
 // @Success      201   {object}  dto.SuccessWrapper  "Acara berhasil dibuat"
 // @Failure      400   {object}  dto.ErrorWrapper    "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper    "Lokasi tidak ditemukan"
 // @Failure      500   {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /events [post]
 func (h *EventHandler) Create(c *gin.Context) {
@@ -39,8 +45,18 @@ func (h *EventHandler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.Create(c.Request.Context(), req)
+	actor, err := helper.GetActor(c)
 	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Create(c.Request.Context(), req, actor)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.SendErrorResponse(c, http.StatusNotFound, "Location not found")
+			return
+		}
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -48,13 +64,15 @@ func (h *EventHandler) Create(c *gin.Context) {
 	var res dto.EventResponse
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
+	res.TenantID = result.TenantID.String()
+	res.CreatedBy = result.CreatedBy.String()
 
 	helper.SendSuccessResponse(c, http.StatusCreated, "Event created successfully", res)
 }
 
 // GetAll godoc
 // @Summary      Get all events
-// @Description  Mengambil daftar semua acara dengan paginasi
+// @Description  Mengambil daftar acara dengan paginasi. Superadmin melihat semua acara, pengguna tenant melihat acara tenantnya, dan pengguna tanpa tenant hanya melihat acara yang dibuatnya. Daftar acara publik tersedia di /public/events.
 // @Tags         Events
 // @Produce      json
 // @Security     BearerAuth
@@ -67,7 +85,13 @@ func (h *EventHandler) GetAll(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	items, total, err := h.usecase.FindAll(c.Request.Context(), page, limit)
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), actor, page, limit)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -79,6 +103,8 @@ func (h *EventHandler) GetAll(c *gin.Context) {
 	// ID perlu di-mapping manual karena tipe berbeda (uuid.UUID -> string)
 	for i := range items {
 		itemResponses[i].ID = items[i].ID.String()
+		itemResponses[i].TenantID = items[i].TenantID.String()
+		itemResponses[i].CreatedBy = items[i].CreatedBy.String()
 	}
 
 	paginatedResponse := dto.PaginatedResponse[dto.EventResponse]{
@@ -90,14 +116,51 @@ func (h *EventHandler) GetAll(c *gin.Context) {
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved events", paginatedResponse)
 }
 
+// GetPublic godoc
+// @Summary      Get public events
+// @Description  Menampilkan acara donor yang belum berakhir untuk situs publik tanpa autentikasi, diurutkan dari yang paling dekat waktunya. Data pembuat dan tenant tidak disertakan. Jumlah akses per IP dibatasi.
+// @Tags         Public
+// @Produce      json
+// @Param        city   query     string  false  "Kota lokasi acara"
+// @Param        page   query     int     false  "Nomor halaman"  default(1)
+// @Param        limit  query     int     false  "Jumlah item per halaman (maks. 50)"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil daftar acara publik"
+// @Failure      429    {object}  dto.ErrorWrapper    "Terlalu banyak permintaan"
+// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /public/events [get]
+func (h *EventHandler) GetPublic(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page = max(page, 1)
+	limit = min(max(limit, 1), maxPublicEventLimit)
+
+	items, total, err := h.usecase.FindPublic(c.Request.Context(), strings.TrimSpace(c.Query("city")), page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := []dto.PublicEventResponse{}
+	copier.Copy(&itemResponses, &items)
+
+	paginatedResponse := dto.PaginatedResponse[dto.PublicEventResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved public events", paginatedResponse)
+}
+
 // GetByID godoc
 // @Summary      Get event by ID
-// @Description  Mengambil satu data acara berdasarkan ID
+// @Description  Mengambil satu data acara berdasarkan ID. Acara di luar cakupan pemanggil dianggap tidak ditemukan.
 // @Tags         Events
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "ID Acara"  format(uuid)
+// @Param        id   path      string              true  "ID Acara"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data acara"
+// @Header       200  {string}  ETag                "Versi data untuk If-Match"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
 // @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
 // @Router       /events/{id} [get]
@@ -107,7 +170,14 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
-	result, err := h.usecase.FindByID(c.Request.Context(), id)
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.FindByID(c.Request.Context(), id, actor)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
 		return
@@ -116,22 +186,29 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 	var res dto.EventResponse
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
+	res.TenantID = result.TenantID.String()
+	res.CreatedBy = result.CreatedBy.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved event", res)
 }
 
 // Update godoc
 // @Summary      Update an event
-// @Description  Memperbarui acara yang sudah ada berdasarkan ID
+// @Description  Memperbarui acara yang sudah ada berdasarkan ID. Hanya pembuat, admin tenant yang sama, atau superadmin yang boleh mengubah.
 // @Tags         Events
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id    path      string            true  "ID Acara"  format(uuid)
-// @Param        body  body      dto.EventRequest  true  "Data Acara yang Diperbarui"
-// @Success      200   {object}  dto.SuccessWrapper  "Acara berhasil diperbarui"
-// @Failure      400   {object}  dto.ErrorWrapper    "Format ID atau request tidak valid"
-// @Failure      500   {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Param        id        path      string              true  "ID Acara"  format(uuid)
+// @Param        body      body      dto.EventRequest    true  "Data Acara yang Diperbarui"
+// @Param        If-Match  header    string              false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper  "Acara berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper    "Format ID atau request tidak valid"
+// @Failure      403       {object}  dto.ErrorWrapper    "Tidak berhak mengubah acara ini"
+// @Failure      404       {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      412       {object}  dto.ErrorWrapper    "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /events/{id} [put]
 func (h *EventHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -145,28 +222,39 @@ func (h *EventHandler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.Update(c.Request.Context(), id, req)
+	actor, err := helper.GetActor(c)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Update(c.Request.Context(), id, req, actor, helper.ParseIfMatch(c))
+	if err != nil {
+		sendUpdateError(c, err)
 		return
 	}
 
 	var res dto.EventResponse
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
+	res.TenantID = result.TenantID.String()
+	res.CreatedBy = result.CreatedBy.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Event updated successfully", res)
 }
 
 // Delete godoc
 // @Summary      Delete an event
-// @Description  Menghapus acara berdasarkan ID
+// @Description  Menghapus acara berdasarkan ID. Hanya pembuat, admin tenant yang sama, atau superadmin yang boleh menghapus.
 // @Tags         Events
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID Acara"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Acara berhasil dihapus"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Tidak berhak menghapus acara ini"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
 // @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /events/{id} [delete]
 func (h *EventHandler) Delete(c *gin.Context) {
@@ -176,9 +264,15 @@ func (h *EventHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.Delete(c.Request.Context(), id)
+	actor, err := helper.GetActor(c)
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.usecase.Delete(c.Request.Context(), id, actor)
+	if err != nil {
+		sendUpdateError(c, err)
 		return
 	}
 	helper.SendSuccessResponse(c, http.StatusOK, "Event deleted successfully", "")
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+)
+
+type HospitalHandler struct {
+	usecase usecase.HospitalUsecase
+}
+
+func NewHospitalHandler(usecase usecase.HospitalUsecase) *HospitalHandler {
+	return &HospitalHandler{usecase: usecase}
+}
+
+// Create godoc
+// @Summary      Register a hospital
+// @Description  Mendaftarkan rumah sakit pemohon darah untuk tenant. Superadmin wajib mengisi tenant_id.
+// @Tags         Hospitals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.HospitalRequest  true  "Data Rumah Sakit"
+// @Success      201   {object}  dto.SuccessWrapper   "Rumah sakit berhasil didaftarkan"
+// @Failure      400   {object}  dto.ErrorWrapper     "Request tidak valid"
+// @Failure      500   {object}  dto.ErrorWrapper     "Terjadi kesalahan internal"
+// @Router       /hospitals [post]
+func (h *HospitalHandler) Create(c *gin.Context) {
+	var req dto.HospitalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Create(c.Request.Context(), req, *tenantID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTenantRequired) {
+			helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SetETag(c, result.Version)
+	helper.SendSuccessResponse(c, http.StatusCreated, "Hospital created successfully", toHospitalResponse(result))
+}
+
+// GetAll godoc
+// @Summary      Get all hospitals
+// @Description  Mengambil daftar rumah sakit milik tenant dengan filter dan paginasi
+// @Tags         Hospitals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        city   query     string  false  "Kota"
+// @Param        page   query     int     false  "Nomor halaman"  default(1)
+// @Param        limit  query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil daftar rumah sakit"
+// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /hospitals [get]
+func (h *HospitalHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.HospitalFilter{TenantID: *tenantID, City: c.Query("city")}
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.HospitalResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toHospitalResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.HospitalResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved hospitals", paginatedResponse)
+}
+
+// GetByID godoc
+// @Summary      Get hospital by ID
+// @Description  Mengambil satu data rumah sakit berdasarkan ID
+// @Tags         Hospitals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Rumah Sakit"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data rumah sakit"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /hospitals/{id} [get]
+func (h *HospitalHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.FindByID(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	helper.SetETag(c, result.Version)
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved hospital", toHospitalResponse(result))
+}
+
+// Update godoc
+// @Summary      Update a hospital
+// @Description  Memperbarui data rumah sakit berdasarkan ID
+// @Tags         Hospitals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string               true  "ID Rumah Sakit"  format(uuid)
+// @Param        body      body      dto.HospitalRequest  true  "Data Rumah Sakit yang Diperbarui"
+// @Param        If-Match  header    string               false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper   "Rumah sakit berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper     "Format ID atau request tidak valid"
+// @Failure      404       {object}  dto.ErrorWrapper     "Data tidak ditemukan"
+// @Failure      412       {object}  dto.ErrorWrapper     "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper     "Terjadi kesalahan internal"
+// @Router       /hospitals/{id} [put]
+func (h *HospitalHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.HospitalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Update(c.Request.Context(), id, req, *tenantID, helper.ParseIfMatch(c))
+	if err != nil {
+		sendUpdateError(c, err)
+		return
+	}
+
+	helper.SetETag(c, result.Version)
+	helper.SendSuccessResponse(c, http.StatusOK, "Hospital updated successfully", toHospitalResponse(result))
+}
+
+// Delete godoc
+// @Summary      Delete a hospital
+// @Description  Menghapus data rumah sakit berdasarkan ID
+// @Tags         Hospitals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Rumah Sakit"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Rumah sakit berhasil dihapus"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /hospitals/{id} [delete]
+func (h *HospitalHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), id, *tenantID); err != nil {
+		sendUpdateError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Hospital deleted successfully", "")
+}
+
+func toHospitalResponse(hospital entity.Hospital) dto.HospitalResponse {
+	var res dto.HospitalResponse
+	copier.Copy(&res, &hospital)
+	res.ID = hospital.ID.String()
+	res.TenantID = hospital.TenantID.String()
+	return res
+}
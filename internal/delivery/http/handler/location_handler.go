@@ -107,8 +107,9 @@ func (h *LocationHandler) GetAll(c *gin.Context) {
 // @Tags         Locations
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "ID Lokasi"  format(uuid)
+// @Param        id   path      string              true  "ID Lokasi"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data lokasi"
+// @Header       200  {string}  ETag                "Versi data untuk If-Match"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
 // @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
 // @Router       /locations/{id} [get]
@@ -128,6 +129,7 @@ func (h *LocationHandler) GetByID(c *gin.Context) {
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved location", res)
 }
 
@@ -138,11 +140,14 @@ func (h *LocationHandler) GetByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id    path      string               true  "ID Lokasi"  format(uuid)
-// @Param        body  body      dto.LocationRequest  true  "Data Lokasi yang Diperbarui"
-// @Success      200   {object}  dto.SuccessWrapper   "Lokasi berhasil diperbarui"
-// @Failure      400   {object}  dto.ErrorWrapper     "Format ID atau request tidak valid"
-// @Failure      500   {object}  dto.ErrorWrapper     "Terjadi kesalahan internal"
+// @Param        id        path      string               true  "ID Lokasi"  format(uuid)
+// @Param        body      body      dto.LocationRequest  true  "Data Lokasi yang Diperbarui"
+// @Param        If-Match  header    string               false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper   "Lokasi berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper     "Format ID atau request tidak valid"
+// @Failure      404       {object}  dto.ErrorWrapper     "Data tidak ditemukan"
+// @Failure      412       {object}  dto.ErrorWrapper     "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper     "Terjadi kesalahan internal"
 // @Router       /locations/{id} [put]
 func (h *LocationHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -156,9 +161,9 @@ func (h *LocationHandler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.Update(c.Request.Context(), id, req)
+	result, err := h.usecase.Update(c.Request.Context(), id, req, helper.ParseIfMatch(c))
 	if err != nil {
-		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		sendUpdateError(c, err)
 		return
 	}
 
@@ -166,6 +171,7 @@ func (h *LocationHandler) Update(c *gin.Context) {
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Location updated successfully", res)
 }
 
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler struct {
+	usecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(usecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{usecase: usecase}
+}
+
+// GetAll godoc
+// @Summary      Get my notifications
+// @Description  Mengambil daftar notifikasi milik pengguna yang sedang login, terbaru lebih dulu
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread  query     bool  false  "Filter notifikasi yang belum/sudah dibaca"
+// @Param        page    query     int   false  "Nomor halaman"  default(1)
+// @Param        limit   query     int   false  "Jumlah item per halaman"  default(10)
+// @Success      200     {object}  dto.SuccessWrapper  "Berhasil mengambil daftar notifikasi"
+// @Failure      400     {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500     {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /notifications [get]
+func (h *NotificationHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := repository.NotificationFilter{UserID: *userID}
+	if unread := c.Query("unread"); unread != "" {
+		value, err := strconv.ParseBool(unread)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid unread value")
+			return
+		}
+		filter.Unread = &value
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.NotificationResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toNotificationResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.NotificationResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved notifications", paginatedResponse)
+}
+
+// MarkRead godoc
+// @Summary      Mark a notification as read
+// @Description  Menandai notifikasi milik pengguna yang sedang login sudah dibaca
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Notifikasi"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Notifikasi ditandai sudah dibaca"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /notifications/{id}/read [put]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.MarkRead(c.Request.Context(), id, *userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+			return
+		}
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Notification marked as read", toNotificationResponse(result))
+}
+
+func toNotificationResponse(notification entity.Notification) dto.NotificationResponse {
+	var res dto.NotificationResponse
+	copier.Copy(&res, &notification)
+	res.ID = notification.ID.String()
+	return res
+}
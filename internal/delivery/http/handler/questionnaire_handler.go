@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionnaireHandler struct {
+	usecase usecase.QuestionnaireUsecase
+}
+
+func NewQuestionnaireHandler(usecase usecase.QuestionnaireUsecase) *QuestionnaireHandler {
+	return &QuestionnaireHandler{usecase: usecase}
+}
+
+// Create godoc
+// @Summary      Publish a questionnaire version
+// @Description  Menerbitkan versi baru kuesioner kesehatan pra-donor untuk tenant. Versi lama tetap tersimpan untuk jawaban yang sudah dikirim, dan versi terbaru langsung berlaku untuk pengisian berikutnya. Tipe pertanyaan: yes_no, single_choice, number, text, date. Jawaban di defer_answers menunda pendonor selama defer_days hari (kosong berarti permanen). Superadmin wajib mengisi tenant_id.
+// @Tags         Questionnaires
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.QuestionnaireRequest  true  "Kuesioner Pra-Donor"
+// @Success      201   {object}  dto.SuccessWrapper        "Kuesioner berhasil diterbitkan"
+// @Failure      400   {object}  dto.ErrorWrapper          "Request tidak valid"
+// @Failure      500   {object}  dto.ErrorWrapper          "Terjadi kesalahan internal"
+// @Router       /questionnaires [post]
+func (h *QuestionnaireHandler) Create(c *gin.Context) {
+	var req dto.QuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Create(c.Request.Context(), req, actor)
+	if err != nil {
+		sendQuestionnaireError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Questionnaire published successfully", toQuestionnaireResponse(result))
+}
+
+// GetAll godoc
+// @Summary      Get questionnaire versions
+// @Description  Mengambil daftar versi kuesioner pra-donor milik tenant, terbaru lebih dulu, tanpa pertanyaannya
+// @Tags         Questionnaires
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Nomor halaman"  default(1)
+// @Param        limit  query     int  false  "Jumlah item per halaman"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil daftar kuesioner"
+// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /questionnaires [get]
+func (h *QuestionnaireHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), *tenantID, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.QuestionnaireResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toQuestionnaireResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.QuestionnaireResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved questionnaires", paginatedResponse)
+}
+
+// GetByID godoc
+// @Summary      Get questionnaire by ID
+// @Description  Mengambil satu versi kuesioner pra-donor beserta pertanyaannya
+// @Tags         Questionnaires
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Kuesioner"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil kuesioner"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /questionnaires/{id} [get]
+func (h *QuestionnaireHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.FindByID(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved questionnaire", toQuestionnaireResponse(result))
+}
+
+// GetForDonation godoc
+// @Summary      Get a donation's questionnaire
+// @Description  Mengambil kuesioner pra-donor yang harus diisi untuk donasi beserta jawabannya bila sudah dikirim. Sebelum dikirim, yang dikembalikan adalah versi terbaru milik tenant lokasi donasi. Hanya pendonor, admin tenant lokasi, atau superadmin yang boleh mengakses.
+// @Tags         Questionnaires
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Donasi"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil kuesioner donasi"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Bukan donasi milik pemanggil"
+// @Failure      404  {object}  dto.ErrorWrapper    "Donasi atau kuesioner tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /donations/{id}/questionnaire [get]
+func (h *QuestionnaireHandler) GetForDonation(c *gin.Context) {
+	donationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	questionnaire, submission, err := h.usecase.FindForDonation(c.Request.Context(), donationID, actor)
+	if err != nil {
+		sendQuestionnaireError(c, err)
+		return
+	}
+
+	res := dto.DonationQuestionnaireResponse{
+		DonationID:    donationID.String(),
+		Questionnaire: toQuestionnaireResponse(questionnaire),
+	}
+	if submission != nil {
+		submissionRes := toQuestionnaireSubmissionResponse(*submission)
+		res.Submission = &submissionRes
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved donation questionnaire", res)
+}
+
+// Submit godoc
+// @Summary      Submit a donation's questionnaire
+// @Description  Mengirim jawaban kuesioner pra-donor versi terbaru untuk donasi yang masih pending, misalnya dari aplikasi sebelum pendonor datang. Jawaban hanya dapat dikirim sekali. Jawaban yang memicu penundaan mencatat penundaan pendonor dan membatalkan donasi.
+// @Tags         Questionnaires
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                          true  "ID Donasi"  format(uuid)
+// @Param        body  body      dto.SubmitQuestionnaireRequest  true  "Jawaban Kuesioner"
+// @Success      201   {object}  dto.SuccessWrapper              "Jawaban kuesioner berhasil dikirim"
+// @Failure      400   {object}  dto.ErrorWrapper                "Format ID atau jawaban tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper                "Bukan donasi milik pemanggil"
+// @Failure      404   {object}  dto.ErrorWrapper                "Donasi atau kuesioner tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper                "Donasi tidak lagi pending atau kuesioner sudah dikirim"
+// @Failure      500   {object}  dto.ErrorWrapper                "Terjadi kesalahan internal"
+// @Router       /donations/{id}/questionnaire [post]
+func (h *QuestionnaireHandler) Submit(c *gin.Context) {
+	donationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.SubmitQuestionnaireRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Submit(c.Request.Context(), donationID, req, actor)
+	if err != nil {
+		sendQuestionnaireError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Questionnaire submitted successfully", toQuestionnaireSubmissionResponse(result))
+}
+
+// sendQuestionnaireError memetakan error kuesioner ke status HTTP.
+func sendQuestionnaireError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrTenantRequired),
+		errors.Is(err, entity.ErrInvalidQuestionnaire),
+		errors.Is(err, entity.ErrInvalidQuestionAnswer):
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrForbidden):
+		helper.SendErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Donation or questionnaire not found")
+	case errors.Is(err, entity.ErrDonationNotPending), errors.Is(err, entity.ErrQuestionnaireSubmitted):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func toQuestionnaireResponse(questionnaire entity.Questionnaire) dto.QuestionnaireResponse {
+	res := dto.QuestionnaireResponse{
+		ID:        questionnaire.ID.String(),
+		TenantID:  questionnaire.TenantID.String(),
+		Version:   questionnaire.Version,
+		Title:     questionnaire.Title,
+		CreatedBy: questionnaire.CreatedBy.String(),
+		CreatedAt: questionnaire.CreatedAt,
+	}
+	for _, question := range questionnaire.Questions {
+		res.Questions = append(res.Questions, dto.QuestionResponse{
+			ID:           question.ID.String(),
+			Position:     question.Position,
+			Code:         question.Code,
+			Text:         question.Text,
+			Type:         question.Type,
+			Required:     question.Required,
+			Options:      question.Options,
+			DeferAnswers: question.DeferAnswers,
+			DeferDays:    question.DeferDays,
+			DeferReason:  question.DeferReason,
+		})
+	}
+	return res
+}
+
+func toQuestionnaireSubmissionResponse(submission entity.QuestionnaireSubmission) dto.QuestionnaireSubmissionResponse {
+	res := dto.QuestionnaireSubmissionResponse{
+		ID:              submission.ID.String(),
+		DonationID:      submission.DonationID.String(),
+		QuestionnaireID: submission.QuestionnaireID.String(),
+		Deferred:        submission.Deferred,
+		Answers:         make([]dto.AnswerResponse, 0, len(submission.Answers)),
+		SubmittedBy:     submission.SubmittedBy.String(),
+		SubmittedAt:     submission.CreatedAt,
+	}
+	for _, answer := range submission.Answers {
+		res.Answers = append(res.Answers, dto.AnswerResponse{
+			QuestionID: answer.QuestionID.String(),
+			Code:       answer.Code,
+			Value:      answer.Value,
+			Defers:     answer.Defers,
+		})
+	}
+	return res
+}
@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type ScreeningHandler struct {
+	usecase usecase.ScreeningUsecase
+}
+
+func NewScreeningHandler(usecase usecase.ScreeningUsecase) *ScreeningHandler {
+	return &ScreeningHandler{usecase: usecase}
+}
+
+// RecordResults godoc
+// @Summary      Record screening results
+// @Description  Mencatat hasil uji saring IMLTD (HIV, HBV, HCV, sifilis) untuk donasi. Bila semua negatif, unit keluar dari karantina; bila ada yang reaktif, unit dimusnahkan dan pendonor ditunda.
+// @Tags         Screening
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                      true  "ID Donasi"  format(uuid)
+// @Param        body  body      dto.RecordScreeningRequest  true  "Hasil Skrining"
+// @Success      201   {object}  dto.SuccessWrapper          "Hasil skrining berhasil dicatat"
+// @Failure      400   {object}  dto.ErrorWrapper            "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper            "Lokasi donasi milik tenant lain"
+// @Failure      404   {object}  dto.ErrorWrapper            "Donasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper            "Hasil untuk penanda tersebut sudah dicatat"
+// @Failure      500   {object}  dto.ErrorWrapper            "Terjadi kesalahan internal"
+// @Router       /donations/{id}/screening [post]
+func (h *ScreeningHandler) RecordResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.RecordScreeningRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	donation, results, err := h.usecase.Record(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Screening results recorded successfully", toScreeningSummaryResponse(donation, results))
+}
+
+// GetResults godoc
+// @Summary      Get screening results
+// @Description  Mengambil status skrining donasi, hasil yang sudah dicatat, dan penanda yang belum diuji
+// @Tags         Screening
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Donasi"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil hasil skrining"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Lokasi donasi milik tenant lain"
+// @Failure      404  {object}  dto.ErrorWrapper    "Donasi tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /donations/{id}/screening [get]
+func (h *ScreeningHandler) GetResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	donation, results, err := h.usecase.FindByDonationID(c.Request.Context(), id, actor)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved screening results", toScreeningSummaryResponse(donation, results))
+}
+
+// RecordVitalSigns godoc
+// @Summary      Record pre-donation vital signs
+// @Description  Mencatat pemeriksaan fisik pra-donor (Hb, tekanan darah, nadi, suhu, berat badan) untuk donasi yang masih pending dan memperbarui berat badan pendonor. Rentang Hb (batas bawah sesuai jenis kelamin pendonor), tekanan darah, nadi, suhu, dan berat badan minimal diambil dari kebijakan kelayakan tenant lokasi donasi. Hasil di luar rentang membatalkan donasi (batal) dengan alasannya; Hb atau suhu di luar rentang juga menunda pendonor sementara (deferred).
+// @Tags         Screening
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                       true  "ID Donasi"  format(uuid)
+// @Param        body  body      dto.RecordVitalSignsRequest  true  "Hasil Pemeriksaan"
+// @Success      201   {object}  dto.SuccessWrapper           "Pemeriksaan berhasil dicatat"
+// @Failure      400   {object}  dto.ErrorWrapper             "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper             "Lokasi donasi milik tenant lain"
+// @Failure      404   {object}  dto.ErrorWrapper             "Donasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper             "Donasi tidak lagi pending, pemeriksaan sudah dicatat, atau profil pendonor belum diisi"
+// @Failure      500   {object}  dto.ErrorWrapper             "Terjadi kesalahan internal"
+// @Router       /donations/{id}/vitals [post]
+func (h *ScreeningHandler) RecordVitalSigns(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.RecordVitalSignsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	donation, vitals, err := h.usecase.RecordVitalSigns(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	res := toVitalSignsResponse(vitals)
+	res.DonationStatus = donation.Status
+	helper.SendSuccessResponse(c, http.StatusCreated, "Vital signs recorded successfully", res)
+}
+
+// GetVitalSigns godoc
+// @Summary      Get pre-donation vital signs
+// @Description  Mengambil hasil pemeriksaan fisik pra-donor untuk donasi
+// @Tags         Screening
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Donasi"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil hasil pemeriksaan"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      403  {object}  dto.ErrorWrapper    "Lokasi donasi milik tenant lain"
+// @Failure      404  {object}  dto.ErrorWrapper    "Pemeriksaan belum dicatat"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /donations/{id}/vitals [get]
+func (h *ScreeningHandler) GetVitalSigns(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vitals, err := h.usecase.FindVitalSigns(c.Request.Context(), id, actor)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved vital signs", toVitalSignsResponse(vitals))
+}
+
+// GetDeferrals godoc
+// @Summary      Get donor deferrals
+// @Description  Mengambil daftar penundaan pendonor, misalnya akibat hasil skrining reaktif, terbaru lebih dulu. Selain superadmin, hanya penundaan atas donasi di lokasi tenant pemanggil yang ditampilkan.
+// @Tags         Screening
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id            query     string  false  "ID Pendonor"  format(uuid)
+// @Param        follow_up_pending  query     bool    false  "Hanya yang masih menunggu tindak lanjut"
+// @Param        page               query     int     false  "Nomor halaman"  default(1)
+// @Param        limit              query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200                {object}  dto.SuccessWrapper  "Berhasil mengambil daftar penundaan"
+// @Failure      400                {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      403                {object}  dto.ErrorWrapper    "Pengguna tidak terikat ke tenant"
+// @Failure      500                {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /donor-deferrals [get]
+func (h *ScreeningHandler) GetDeferrals(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	var filter repository.DonorDeferralFilter
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid user ID format")
+			return
+		}
+		filter.UserID = id
+	}
+	if pending := c.Query("follow_up_pending"); pending != "" {
+		value, err := strconv.ParseBool(pending)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid follow_up_pending value")
+			return
+		}
+		filter.FollowUpPending = &value
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindDeferrals(c.Request.Context(), filter, actor, page, limit)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	itemResponses := make([]dto.DonorDeferralResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toDonorDeferralResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.DonorDeferralResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved donor deferrals", paginatedResponse)
+}
+
+// CompleteFollowUp godoc
+// @Summary      Complete deferral follow-up
+// @Description  Mencatat hasil tindak lanjut (konseling, uji konfirmasi) atas penundaan pendonor. deferred_until dapat mengubah penundaan permanen menjadi sementara.
+// @Tags         Screening
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                       true  "ID Penundaan"  format(uuid)
+// @Param        body  body      dto.CompleteFollowUpRequest  true  "Hasil Tindak Lanjut"
+// @Success      200   {object}  dto.SuccessWrapper           "Tindak lanjut berhasil dicatat"
+// @Failure      400   {object}  dto.ErrorWrapper             "Format ID atau request tidak valid"
+// @Failure      403   {object}  dto.ErrorWrapper             "Donasi yang ditunda milik tenant lain"
+// @Failure      404   {object}  dto.ErrorWrapper             "Data tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper             "Penundaan tidak memerlukan tindak lanjut"
+// @Failure      500   {object}  dto.ErrorWrapper             "Terjadi kesalahan internal"
+// @Router       /donor-deferrals/{id}/follow-up [put]
+func (h *ScreeningHandler) CompleteFollowUp(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.CompleteFollowUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := helper.GetActor(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.CompleteFollowUp(c.Request.Context(), id, req, actor)
+	if err != nil {
+		sendScreeningError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Deferral follow-up completed successfully", toDonorDeferralResponse(result))
+}
+
+func toScreeningSummaryResponse(donation entity.Donation, results []entity.ScreeningResult) dto.ScreeningSummaryResponse {
+	res := dto.ScreeningSummaryResponse{
+		DonationID:      donation.ID.String(),
+		ScreeningStatus: donation.ScreeningStatus,
+		PendingMarkers:  []string{},
+		Results:         make([]dto.ScreeningResultResponse, 0, len(results)),
+	}
+
+	tested := map[string]bool{}
+	for _, result := range results {
+		tested[result.Marker] = true
+		item := dto.ScreeningResultResponse{}
+		copier.Copy(&item, &result)
+		item.ID = result.ID.String()
+		res.Results = append(res.Results, item)
+	}
+	for _, marker := range entity.ScreeningMarkers {
+		if !tested[marker] {
+			res.PendingMarkers = append(res.PendingMarkers, marker)
+		}
+	}
+	return res
+}
+
+func toDonorDeferralResponse(deferral entity.DonorDeferral) dto.DonorDeferralResponse {
+	var res dto.DonorDeferralResponse
+	copier.Copy(&res, &deferral)
+	res.ID = deferral.ID.String()
+	res.UserID = deferral.UserID.String()
+	res.Permanent = deferral.DeferredUntil == nil
+	return res
+}
+
+func toVitalSignsResponse(vitals entity.VitalSigns) dto.VitalSignsResponse {
+	var res dto.VitalSignsResponse
+	copier.Copy(&res, &vitals)
+	res.ID = vitals.ID.String()
+	res.DonationID = vitals.DonationID.String()
+	res.MeasuredBy = vitals.MeasuredBy.String()
+	return res
+}
+
+func sendScreeningError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrForbidden):
+		helper.SendErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, entity.ErrDuplicateScreeningMarker),
+		errors.Is(err, entity.ErrInvalidVitalSigns):
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrScreeningResultExists),
+		errors.Is(err, entity.ErrFollowUpNotRequired),
+		errors.Is(err, entity.ErrDonationNotPending),
+		errors.Is(err, entity.ErrVitalSignsRecorded),
+		errors.Is(err, entity.ErrDonorDetailMissing):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
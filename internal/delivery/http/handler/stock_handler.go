@@ -3,13 +3,17 @@ package handler
 import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
 	"donor-api/internal/usecase"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
 type StockHandler struct {
@@ -22,7 +26,7 @@ func NewStockHandler(usecase usecase.StockUsecase) *StockHandler {
 
 // Create godoc
 // @Summary      Create a new stock
-// @Description  Menambahkan data stok darah baru ke sistem
+// @Description  Menyiapkan baris stok kosong untuk golongan, komponen, dan lokasi. Jumlah kantong selalu diturunkan dari unit darah.
 // @Tags         Stocks
 // @Accept       json
 // @Produce      json
@@ -30,6 +34,7 @@ func NewStockHandler(usecase usecase.StockUsecase) *StockHandler {
 // @Param        body  body      dto.StockRequest    true  "Data Stok Baru"
 // @Success      201   {object}  dto.SuccessWrapper  "Stok berhasil dibuat"
 // @Failure      400   {object}  dto.ErrorWrapper    "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper    "Lokasi tidak ditemukan"
 // @Failure      500   {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /stocks [post]
 func (h *StockHandler) Create(c *gin.Context) {
@@ -39,12 +44,18 @@ func (h *StockHandler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.Create(c.Request.Context(), req)
+	tenantID, err := helper.GetContextValue(c, "tenantID")
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	result, err := h.usecase.Create(c.Request.Context(), req, *tenantID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
 	var res dto.StockResponse
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
@@ -58,16 +69,28 @@ func (h *StockHandler) Create(c *gin.Context) {
 // @Tags         Stocks
 // @Produce      json
 // @Security     BearerAuth
-// @Param        page   query     int  false  "Nomor halaman"  default(1)
-// @Param        limit  query     int  false  "Jumlah item per halaman"  default(10)
-// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil daftar stok"
-// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Param        blood_type   query     string  false  "Golongan darah"
+// @Param        rhesus       query     string  false  "Rhesus"
+// @Param        component    query     string  false  "Komponen darah (WB, PRC, FFP, TC, CRYO)"
+// @Param        rhesus       query     string  false  "Rhesus (+ atau -); kosong berarti keduanya"
+// @Param        location_id  query     string  false  "ID Lokasi"  format(uuid)
+// @Param        page         query     int     false  "Nomor halaman"  default(1)
+// @Param        limit        query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil mengambil daftar stok"
+// @Failure      400          {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /stocks [get]
 func (h *StockHandler) GetAll(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	items, total, err := h.usecase.FindAll(c.Request.Context(), page, limit)
+	filter, err := bindStockFilter(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -90,14 +113,116 @@ func (h *StockHandler) GetAll(c *gin.Context) {
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stocks", paginatedResponse)
 }
 
+// Summary godoc
+// @Summary      Get stock summary
+// @Description  Menjumlahkan kantong darah per golongan, rhesus, dan komponen
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        blood_type   query     string  false  "Golongan darah"
+// @Param        rhesus       query     string  false  "Rhesus"
+// @Param        component    query     string  false  "Komponen darah (WB, PRC, FFP, TC, CRYO)"
+// @Param        rhesus       query     string  false  "Rhesus (+ atau -); kosong berarti keduanya"
+// @Param        location_id  query     string  false  "ID Lokasi"  format(uuid)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil mengambil ringkasan stok"
+// @Failure      400          {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/summary [get]
+func (h *StockHandler) Summary(c *gin.Context) {
+	filter, err := bindStockFilter(c)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Summary(c.Request.Context(), filter)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stock summary", res)
+}
+
+// Availability godoc
+// @Summary      Get blood availability matrix
+// @Description  Mengambil matriks ketersediaan kantong darah per golongan, rhesus, dan lokasi beserta total per tenant dan nasional
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        city       query     string  false  "Kota lokasi"
+// @Param        component  query     string  false  "Komponen darah (WB, PRC, FFP, TC, CRYO)"
+// @Success      200        {object}  dto.SuccessWrapper  "Berhasil mengambil matriks ketersediaan"
+// @Failure      500        {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/availability [get]
+func (h *StockHandler) Availability(c *gin.Context) {
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.StockAvailabilityFilter{
+		City:      c.Query("city"),
+		Component: c.Query("component"),
+	}
+
+	res, err := h.usecase.Availability(c.Request.Context(), filter, *tenantID)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved blood availability", res)
+}
+
+// Forecast godoc
+// @Summary      Forecast days of supply
+// @Description  Memperkirakan lama stok tiap golongan darah dan rhesus akan bertahan berdasarkan pemakaian historis di ledger pergerakan stok dan penyerahan kantong yang ditahan untuk permintaan darah, termasuk tanggal perkiraan stok habis dan jumlah pendonor yang perlu direkrut
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id  query     string  false  "ID Lokasi; kosong berarti seluruh lokasi tenant"  format(uuid)
+// @Param        component    query     string  false  "Komponen darah (WB, PRC, FFP, TC, CRYO)"
+// @Param        rhesus       query     string  false  "Rhesus (+ atau -); kosong berarti keduanya"
+// @Param        window_days  query     int     false  "Jumlah hari data historis"  default(28)
+// @Param        target_days  query     int     false  "Target hari ketersediaan stok"  default(14)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil membuat perkiraan stok"
+// @Failure      400          {object}  dto.ErrorWrapper    "Parameter tidak valid"
+// @Failure      404          {object}  dto.ErrorWrapper    "Lokasi tidak ditemukan"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/forecast [get]
+func (h *StockHandler) Forecast(c *gin.Context) {
+	var query dto.StockForecastQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Forecast(c.Request.Context(), query, *tenantID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully forecasted stock", res)
+}
+
 // GetByID godoc
 // @Summary      Get stock by ID
 // @Description  Mengambil satu data stok darah berdasarkan ID
 // @Tags         Stocks
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "ID Stok"  format(uuid)
+// @Param        id   path      string              true  "ID Stok"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data stok"
+// @Header       200  {string}  ETag                "Versi data untuk If-Match"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
 // @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
 // @Router       /stocks/{id} [get]
@@ -107,7 +232,12 @@ func (h *StockHandler) GetByID(c *gin.Context) {
 		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
-	result, err := h.usecase.FindByID(c.Request.Context(), id)
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	result, err := h.usecase.FindByID(c.Request.Context(), id, *tenantID)
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
 		return
@@ -117,21 +247,26 @@ func (h *StockHandler) GetByID(c *gin.Context) {
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stock", res)
 }
 
 // Update godoc
 // @Summary      Update a stock
-// @Description  Memperbarui data stok darah yang sudah ada berdasarkan ID
+// @Description  Mengoreksi golongan, komponen, atau lokasi baris stok yang belum memiliki unit maupun riwayat ledger
 // @Tags         Stocks
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id    path      string            true  "ID Stok"  format(uuid)
-// @Param        body  body      dto.StockRequest  true  "Data Stok yang Diperbarui"
-// @Success      200   {object}  dto.SuccessWrapper  "Stok berhasil diperbarui"
-// @Failure      400   {object}  dto.ErrorWrapper    "Format ID atau request tidak valid"
-// @Failure      500   {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Param        id        path      string              true  "ID Stok"  format(uuid)
+// @Param        body      body      dto.StockRequest    true  "Data Stok yang Diperbarui"
+// @Param        If-Match  header    string              false  "ETag dari respons GET"
+// @Success      200       {object}  dto.SuccessWrapper  "Stok berhasil diperbarui"
+// @Failure      400       {object}  dto.ErrorWrapper    "Format ID atau request tidak valid"
+// @Failure      404       {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409       {object}  dto.ErrorWrapper    "Stok sudah memiliki unit atau riwayat ledger"
+// @Failure      412       {object}  dto.ErrorWrapper    "Data telah diubah oleh permintaan lain"
+// @Failure      500       {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /stocks/{id} [put]
 func (h *StockHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -145,28 +280,37 @@ func (h *StockHandler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.Update(c.Request.Context(), id, req)
+	tenantID, err := helper.GetContextValue(c, "tenantID")
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	result, err := h.usecase.Update(c.Request.Context(), id, req, *tenantID, helper.ParseIfMatch(c))
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
 	var res dto.StockResponse
 	copier.Copy(&res, &result)
 	res.ID = result.ID.String()
 
+	helper.SetETag(c, result.Version)
 	helper.SendSuccessResponse(c, http.StatusOK, "Stock updated successfully", res)
 }
 
 // Delete godoc
 // @Summary      Delete a stock
-// @Description  Menghapus data stok darah berdasarkan ID
+// @Description  Menghapus baris stok yang belum memiliki unit maupun riwayat ledger
 // @Tags         Stocks
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "ID Stok"  format(uuid)
 // @Success      200  {object}  dto.SuccessWrapper  "Stok berhasil dihapus"
 // @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Stok sudah memiliki unit atau riwayat ledger"
 // @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
 // @Router       /stocks/{id} [delete]
 func (h *StockHandler) Delete(c *gin.Context) {
@@ -176,10 +320,553 @@ func (h *StockHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.Delete(c.Request.Context(), id)
+	tenantID, err := helper.GetContextValue(c, "tenantID")
 	if err != nil {
 		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+
+	err = h.usecase.Delete(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
 	helper.SendSuccessResponse(c, http.StatusOK, "Stock deleted successfully", "")
 }
+
+// Increment godoc
+// @Summary      Increment stock quantity
+// @Description  Menambah stok dengan mendaftarkan kantong baru sesuai golongan, komponen, dan lokasi baris stok. Setiap kantong harus berasal dari donasi tenant yang sama dengan golongan pendonor yang cocok, sudah lolos skrining, dan belum pernah menjadi unit; kedaluwarsa dihitung dari masa simpan komponen. Jumlah kantong dan ledger diperbarui secara atomik.
+// @Tags         Stocks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                     true  "ID Stok"  format(uuid)
+// @Param        body  body      dto.IncrementStockRequest  true  "Kantong yang Ditambahkan"
+// @Success      200   {object}  dto.SuccessWrapper         "Stok berhasil ditambah"
+// @Failure      400   {object}  dto.ErrorWrapper           "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Stok atau donasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper           "Donasi belum lolos skrining, reaktif, sudah diproses, atau golongan pendonor berbeda"
+// @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
+// @Router       /stocks/{id}/increment [post]
+func (h *StockHandler) Increment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.IncrementStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Increment(c.Request.Context(), id, req, *tenantID, *userID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	var res dto.StockResponse
+	copier.Copy(&res, &result)
+	res.ID = result.ID.String()
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock incremented successfully", res)
+}
+
+// Decrement godoc
+// @Summary      Decrement stock quantity
+// @Description  Mengurangi stok dengan memusnahkan kantong available berkedaluwarsa terdekat (FEFO) dan mencatatnya di ledger secara atomik; ditolak bila jumlah akan menjadi negatif
+// @Tags         Stocks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                     true  "ID Stok"  format(uuid)
+// @Param        body  body      dto.UpdateQuantityRequest  true  "Jumlah Pengurangan"
+// @Success      200   {object}  dto.SuccessWrapper         "Stok berhasil dikurangi"
+// @Failure      400   {object}  dto.ErrorWrapper           "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Data tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper           "Stok tidak mencukupi"
+// @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
+// @Router       /stocks/{id}/decrement [post]
+func (h *StockHandler) Decrement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.UpdateQuantityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Decrement(c.Request.Context(), id, req, *tenantID, *userID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	var res dto.StockResponse
+	copier.Copy(&res, &result)
+	res.ID = result.ID.String()
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock decremented successfully", res)
+}
+
+// Allocate godoc
+// @Summary      Allocate blood units (FEFO)
+// @Description  Mengeluarkan kantong darah dari stok dengan prinsip FEFO: unit dengan tanggal kedaluwarsa terdekat dikeluarkan lebih dulu
+// @Tags         Stocks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.AllocateStockRequest  true  "Permintaan Alokasi"
+// @Success      200   {object}  dto.SuccessWrapper        "Kantong darah berhasil dialokasikan"
+// @Failure      400   {object}  dto.ErrorWrapper          "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper          "Lokasi tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper          "Stok tidak mencukupi"
+// @Failure      500   {object}  dto.ErrorWrapper          "Terjadi kesalahan internal"
+// @Router       /stocks/allocate [post]
+func (h *StockHandler) Allocate(c *gin.Context) {
+	var req dto.AllocateStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	units, err := h.usecase.Allocate(c.Request.Context(), req, *tenantID, *userID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	res := dto.AllocateStockResponse{Quantity: len(units), Units: []dto.BloodUnitResponse{}}
+	for _, unit := range units {
+		res.Units = append(res.Units, toBloodUnitResponse(unit))
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock allocated successfully", res)
+}
+
+// ExpirySweep godoc
+// @Summary      Run expiry sweep
+// @Description  Menandai kantong darah yang sudah kedaluwarsa, mengeluarkannya dari stok, dan mengembalikan laporan per lokasi
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SuccessWrapper  "Laporan kantong kedaluwarsa"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/expiry-sweep [post]
+func (h *StockHandler) ExpirySweep(c *gin.Context) {
+	report, err := h.usecase.ExpireUnits(c.Request.Context())
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Expiry sweep completed", report)
+}
+
+// GetMovements godoc
+// @Summary      Get stock movement history
+// @Description  Mengambil riwayat pergerakan (ledger) sebuah baris stok beserta alasan dan pelakunya
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "ID Stok"  format(uuid)
+// @Param        page   query     int     false  "Nomor halaman"  default(1)
+// @Param        limit  query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil riwayat stok"
+// @Failure      400    {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404    {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /stocks/{id}/movements [get]
+func (h *StockHandler) GetMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items, total, err := h.usecase.FindMovements(c.Request.Context(), id, *tenantID, page, limit)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	itemResponses := make([]dto.StockMovementResponse, 0, len(items))
+	for _, item := range items {
+		var res dto.StockMovementResponse
+		copier.Copy(&res, &item)
+		res.ID = item.ID.String()
+		res.StockID = item.StockID.String()
+		itemResponses = append(itemResponses, res)
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.StockMovementResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stock movements", paginatedResponse)
+}
+
+// Reconcile godoc
+// @Summary      Reconcile stock against ledger
+// @Description  Membandingkan jumlah kantong pada stok dengan saldo ledger dan jumlah unit available
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Stok"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Hasil rekonsiliasi stok"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /stocks/{id}/reconcile [get]
+func (h *StockHandler) Reconcile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := h.usecase.Reconcile(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		sendStockError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock reconciliation completed", res)
+}
+
+// GetThresholds godoc
+// @Summary      Get stock thresholds
+// @Description  Mengambil batas minimum dan kritis stok untuk lokasi milik tenant
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id  query     string  false  "ID Lokasi"  format(uuid)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil mengambil batas stok"
+// @Failure      400          {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/thresholds [get]
+func (h *StockHandler) GetThresholds(c *gin.Context) {
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.StockThresholdFilter{TenantID: *tenantID}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+
+	items, err := h.usecase.FindThresholds(c.Request.Context(), filter)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res := make([]dto.StockThresholdResponse, 0, len(items))
+	for _, item := range items {
+		res = append(res, toStockThresholdResponse(item))
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stock thresholds", res)
+}
+
+// SaveThreshold godoc
+// @Summary      Set a stock threshold
+// @Description  Membuat atau memperbarui batas minimum dan kritis stok per lokasi, golongan darah, dan rhesus
+// @Tags         Stocks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.StockThresholdRequest  true  "Batas Stok"
+// @Success      200   {object}  dto.SuccessWrapper         "Batas stok berhasil disimpan"
+// @Failure      400   {object}  dto.ErrorWrapper           "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Lokasi tidak ditemukan"
+// @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
+// @Router       /stocks/thresholds [put]
+func (h *StockHandler) SaveThreshold(c *gin.Context) {
+	var req dto.StockThresholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.SaveThreshold(c.Request.Context(), req, *tenantID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidStockThreshold):
+			helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			helper.SendErrorResponse(c, http.StatusNotFound, "Location not found")
+		default:
+			helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock threshold saved successfully", toStockThresholdResponse(result))
+}
+
+// DeleteThreshold godoc
+// @Summary      Delete a stock threshold
+// @Description  Menghapus batas stok berdasarkan ID
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Batas Stok"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Batas stok berhasil dihapus"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/thresholds/{id} [delete]
+func (h *StockHandler) DeleteThreshold(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	err = h.usecase.DeleteThreshold(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+			return
+		}
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock threshold deleted successfully", "")
+}
+
+// GetAlerts godoc
+// @Summary      Get stock shortage alerts
+// @Description  Mengambil daftar alert kekurangan stok milik tenant, terbaru lebih dulu
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id   query     string  false  "ID Lokasi"  format(uuid)
+// @Param        level         query     string  false  "Level alert (low, critical)"
+// @Param        acknowledged  query     bool    false  "Filter alert yang sudah/belum diakui"
+// @Param        page          query     int     false  "Nomor halaman"  default(1)
+// @Param        limit         query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200           {object}  dto.SuccessWrapper  "Berhasil mengambil daftar alert"
+// @Failure      400           {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500           {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/alerts [get]
+func (h *StockHandler) GetAlerts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.StockAlertFilter{TenantID: *tenantID, Level: c.Query("level")}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+	if acknowledged := c.Query("acknowledged"); acknowledged != "" {
+		value, err := strconv.ParseBool(acknowledged)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid acknowledged value")
+			return
+		}
+		filter.Acknowledged = &value
+	}
+
+	items, total, err := h.usecase.FindAlerts(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.StockAlertResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toStockAlertResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.StockAlertResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved stock alerts", paginatedResponse)
+}
+
+// AcknowledgeAlert godoc
+// @Summary      Acknowledge a stock alert
+// @Description  Menandai alert kekurangan stok sudah ditindaklanjuti
+// @Tags         Stocks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Alert"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Alert berhasil diakui"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /stocks/alerts/{id}/acknowledge [put]
+func (h *StockHandler) AcknowledgeAlert(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.AcknowledgeAlert(c.Request.Context(), id, *tenantID, *userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+			return
+		}
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Stock alert acknowledged", toStockAlertResponse(result))
+}
+
+func toStockThresholdResponse(threshold entity.StockThreshold) dto.StockThresholdResponse {
+	var res dto.StockThresholdResponse
+	copier.Copy(&res, &threshold)
+	res.ID = threshold.ID.String()
+	res.LocationID = threshold.LocationID.String()
+	return res
+}
+
+func toStockAlertResponse(alert entity.StockAlert) dto.StockAlertResponse {
+	var res dto.StockAlertResponse
+	copier.Copy(&res, &alert)
+	res.ID = alert.ID.String()
+	res.LocationID = alert.LocationID.String()
+	return res
+}
+
+func sendStockError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrInsufficientStock),
+		errors.Is(err, entity.ErrUnitInQuarantine),
+		errors.Is(err, entity.ErrDonationReactive),
+		errors.Is(err, entity.ErrDonationNotCompleted),
+		errors.Is(err, entity.ErrDonationAlreadyProcessed),
+		errors.Is(err, entity.ErrDonorBloodGroupMismatch),
+		errors.Is(err, entity.ErrStockInUse):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, entity.ErrVersionConflict):
+		helper.SendErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// bindStockFilter membaca filter dari query string dan membatasinya pada tenant pemanggil.
+func bindStockFilter(c *gin.Context) (repository.StockFilter, error) {
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		return repository.StockFilter{}, err
+	}
+
+	filter := repository.StockFilter{
+		TenantID:  *tenantID,
+		BloodType: c.Query("blood_type"),
+		Rhesus:    c.Query("rhesus"),
+		Component: c.Query("component"),
+	}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			return filter, errors.New("invalid location ID format")
+		}
+		filter.LocationID = id
+	}
+	return filter, nil
+}
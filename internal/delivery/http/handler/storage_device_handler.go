@@ -0,0 +1,444 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type StorageDeviceHandler struct {
+	usecase usecase.StorageDeviceUsecase
+}
+
+func NewStorageDeviceHandler(usecase usecase.StorageDeviceUsecase) *StorageDeviceHandler {
+	return &StorageDeviceHandler{usecase: usecase}
+}
+
+// Create godoc
+// @Summary      Register a storage device
+// @Description  Mendaftarkan alat simpan darah (refrigerator, freezer, platelet_agitator) di lokasi milik tenant
+// @Tags         Storage Devices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.StorageDeviceRequest  true  "Data Alat Simpan"
+// @Success      201   {object}  dto.SuccessWrapper        "Alat simpan berhasil didaftarkan"
+// @Failure      400   {object}  dto.ErrorWrapper          "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper          "Lokasi tidak ditemukan"
+// @Failure      500   {object}  dto.ErrorWrapper          "Terjadi kesalahan internal"
+// @Router       /storage-devices [post]
+func (h *StorageDeviceHandler) Create(c *gin.Context) {
+	var req dto.StorageDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Create(c.Request.Context(), req, *tenantID)
+	if err != nil {
+		sendStorageDeviceError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Storage device created successfully", toStorageDeviceResponse(result))
+}
+
+// GetAll godoc
+// @Summary      Get all storage devices
+// @Description  Mengambil daftar alat simpan milik tenant dengan filter dan paginasi
+// @Tags         Storage Devices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id   query     string  false  "ID Lokasi"  format(uuid)
+// @Param        device_class  query     string  false  "Jenis alat (refrigerator, freezer, platelet_agitator)"
+// @Param        page          query     int     false  "Nomor halaman"  default(1)
+// @Param        limit         query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200           {object}  dto.SuccessWrapper  "Berhasil mengambil daftar alat simpan"
+// @Failure      400           {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500           {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /storage-devices [get]
+func (h *StorageDeviceHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.StorageDeviceFilter{TenantID: *tenantID, DeviceClass: c.Query("device_class")}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.StorageDeviceResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toStorageDeviceResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.StorageDeviceResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved storage devices", paginatedResponse)
+}
+
+// GetByID godoc
+// @Summary      Get storage device by ID
+// @Description  Mengambil satu data alat simpan berdasarkan ID
+// @Tags         Storage Devices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Alat Simpan"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data alat simpan"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /storage-devices/{id} [get]
+func (h *StorageDeviceHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.FindByID(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved storage device", toStorageDeviceResponse(result))
+}
+
+// Update godoc
+// @Summary      Update a storage device
+// @Description  Memperbarui data alat simpan berdasarkan ID. Lokasi dan kelas alat tidak bisa diubah selama masih ada unit yang tersimpan.
+// @Tags         Storage Devices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                    true  "ID Alat Simpan"  format(uuid)
+// @Param        body  body      dto.StorageDeviceRequest  true  "Data Alat Simpan yang Diperbarui"
+// @Success      200   {object}  dto.SuccessWrapper        "Alat simpan berhasil diperbarui"
+// @Failure      400   {object}  dto.ErrorWrapper          "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper          "Data tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper          "Alat masih menyimpan unit"
+// @Failure      500   {object}  dto.ErrorWrapper          "Terjadi kesalahan internal"
+// @Router       /storage-devices/{id} [put]
+func (h *StorageDeviceHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.StorageDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Update(c.Request.Context(), id, req, *tenantID)
+	if err != nil {
+		sendStorageDeviceError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Storage device updated successfully", toStorageDeviceResponse(result))
+}
+
+// Delete godoc
+// @Summary      Delete a storage device
+// @Description  Menghapus alat simpan yang sudah tidak menyimpan unit berdasarkan ID
+// @Tags         Storage Devices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Alat Simpan"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Alat simpan berhasil dihapus"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Alat masih menyimpan unit darah"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /storage-devices/{id} [delete]
+func (h *StorageDeviceHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), id, *tenantID); err != nil {
+		sendStorageDeviceError(c, err)
+		return
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Storage device deleted successfully", "")
+}
+
+// RecordReadings godoc
+// @Summary      Record temperature readings
+// @Description  Menerima bacaan suhu periodik dari alat simpan. Bacaan di luar rentang alat membuka excursion dan menandai unit di alat tersebut untuk direview.
+// @Tags         Storage Devices
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                        true  "ID Alat Simpan"  format(uuid)
+// @Param        body  body      dto.RecordTemperatureRequest  true  "Bacaan Suhu"
+// @Success      201   {object}  dto.SuccessWrapper            "Bacaan suhu berhasil dicatat"
+// @Failure      400   {object}  dto.ErrorWrapper              "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper              "Data tidak ditemukan"
+// @Failure      500   {object}  dto.ErrorWrapper              "Terjadi kesalahan internal"
+// @Router       /storage-devices/{id}/readings [post]
+func (h *StorageDeviceHandler) RecordReadings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.RecordTemperatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	readings, excursions, err := h.usecase.RecordReadings(c.Request.Context(), id, req, *tenantID)
+	if err != nil {
+		sendStorageDeviceError(c, err)
+		return
+	}
+
+	res := dto.RecordTemperatureResponse{
+		DeviceID:   id.String(),
+		Accepted:   len(readings),
+		Excursions: make([]dto.TemperatureExcursionResponse, 0, len(excursions)),
+	}
+	for _, reading := range readings {
+		if reading.OutOfRange {
+			res.OutOfRange++
+		}
+	}
+	for _, excursion := range excursions {
+		res.Excursions = append(res.Excursions, toTemperatureExcursionResponse(excursion))
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Temperature readings recorded successfully", res)
+}
+
+// GetReadings godoc
+// @Summary      Get temperature readings
+// @Description  Mengambil log bacaan suhu alat simpan untuk keperluan audit, terbaru lebih dulu
+// @Tags         Storage Devices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "ID Alat Simpan"  format(uuid)
+// @Param        from   query     string  false  "Mulai tanggal (YYYY-MM-DD)"
+// @Param        to     query     string  false  "Sampai tanggal (YYYY-MM-DD), inklusif"
+// @Param        page   query     int     false  "Nomor halaman"  default(1)
+// @Param        limit  query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200    {object}  dto.SuccessWrapper  "Berhasil mengambil log suhu"
+// @Failure      400    {object}  dto.ErrorWrapper    "Format ID atau filter tidak valid"
+// @Failure      404    {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Failure      500    {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /storage-devices/{id}/readings [get]
+func (h *StorageDeviceHandler) GetReadings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var filter repository.TemperatureReadingFilter
+	if from := c.Query("from"); from != "" {
+		filter.From, err = time.Parse("2006-01-02", from)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid from date format")
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid to date format")
+			return
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+
+	items, total, err := h.usecase.FindReadings(c.Request.Context(), id, filter, *tenantID, page, limit)
+	if err != nil {
+		sendStorageDeviceError(c, err)
+		return
+	}
+
+	itemResponses := make([]dto.TemperatureReadingResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, dto.TemperatureReadingResponse{
+			ID:          item.ID.String(),
+			Temperature: item.Temperature,
+			RecordedAt:  item.RecordedAt,
+			OutOfRange:  item.OutOfRange,
+		})
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.TemperatureReadingResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved temperature readings", paginatedResponse)
+}
+
+// GetExcursions godoc
+// @Summary      Get temperature excursions
+// @Description  Mengambil riwayat excursion suhu alat simpan milik tenant, terbaru lebih dulu
+// @Tags         Storage Devices
+// @Produce      json
+// @Security     BearerAuth
+// @Param        location_id  query     string  false  "ID Lokasi"  format(uuid)
+// @Param        device_id    query     string  false  "ID Alat Simpan"  format(uuid)
+// @Param        status       query     string  false  "Status excursion (open, closed)"
+// @Param        page         query     int     false  "Nomor halaman"  default(1)
+// @Param        limit        query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil mengambil daftar excursion"
+// @Failure      400          {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /storage-devices/excursions [get]
+func (h *StorageDeviceHandler) GetExcursions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	filter := repository.TemperatureExcursionFilter{TenantID: *tenantID, Status: c.Query("status")}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+	if deviceID := c.Query("device_id"); deviceID != "" {
+		id, err := uuid.Parse(deviceID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid device ID format")
+			return
+		}
+		filter.DeviceID = id
+	}
+
+	items, total, err := h.usecase.FindExcursions(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.TemperatureExcursionResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toTemperatureExcursionResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.TemperatureExcursionResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved temperature excursions", paginatedResponse)
+}
+
+func toStorageDeviceResponse(device entity.StorageDevice) dto.StorageDeviceResponse {
+	var res dto.StorageDeviceResponse
+	copier.Copy(&res, &device)
+	res.ID = device.ID.String()
+	res.TenantID = device.TenantID.String()
+	res.LocationID = device.LocationID.String()
+	res.MinTemperature, res.MaxTemperature = device.AllowedRange()
+	return res
+}
+
+func toTemperatureExcursionResponse(excursion entity.TemperatureExcursion) dto.TemperatureExcursionResponse {
+	var res dto.TemperatureExcursionResponse
+	copier.Copy(&res, &excursion)
+	res.ID = excursion.ID.String()
+	res.LocationID = excursion.LocationID.String()
+	res.DeviceID = excursion.DeviceID.String()
+	return res
+}
+
+func sendStorageDeviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrStorageDeviceInUse):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -60,6 +60,7 @@ func (h *TenantHandler) GetByID(c *gin.Context) {
     return
   }
 
+  helper.SetETag(c, res.Version)
   helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved tenant", res)
 }
 
@@ -75,12 +76,13 @@ func (h *TenantHandler) Update(c *gin.Context) {
     return
   }
 
-  res, err := h.usecase.Update(c.Request.Context(), id, req)
+  res, err := h.usecase.Update(c.Request.Context(), id, req, helper.ParseIfMatch(c))
   if err != nil {
-    helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+    sendUpdateError(c, err)
     return
   }
 
+  helper.SetETag(c, res.Version)
   helper.SendSuccessResponse(c, http.StatusOK, "Tenant updated successfully", res)
 }
 
@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type TransferHandler struct {
+	usecase usecase.TransferUsecase
+}
+
+func NewTransferHandler(usecase usecase.TransferUsecase) *TransferHandler {
+	return &TransferHandler{usecase: usecase}
+}
+
+// Dispatch godoc
+// @Summary      Dispatch a stock transfer
+// @Description  Membuat pengiriman kantong darah dari lokasi milik tenant ke lokasi lain. Hanya kantong belum kedaluwarsa dengan golongan, rhesus, dan komponen yang sama persis yang dikirim; kantong langsung dikunci (in_transit) dan stok asal berkurang.
+// @Tags         Transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      dto.CreateTransferRequest  true  "Data Transfer"
+// @Success      201   {object}  dto.SuccessWrapper         "Transfer berhasil dibuat"
+// @Failure      400   {object}  dto.ErrorWrapper           "Request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Lokasi asal atau tujuan tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper           "Stok lokasi asal tidak mencukupi"
+// @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
+// @Router       /transfers [post]
+func (h *TransferHandler) Dispatch(c *gin.Context) {
+	var req dto.CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Dispatch(c.Request.Context(), req, *tenantID, *userID)
+	if err != nil {
+		sendTransferError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusCreated, "Transfer dispatched successfully", toTransferResponse(result))
+}
+
+// GetAll godoc
+// @Summary      Get all transfers
+// @Description  Mengambil daftar transfer antar lokasi dengan paginasi
+// @Tags         Transfers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status       query     string  false  "Status transfer"
+// @Param        location_id  query     string  false  "ID lokasi asal atau tujuan"  format(uuid)
+// @Param        page         query     int     false  "Nomor halaman"  default(1)
+// @Param        limit        query     int     false  "Jumlah item per halaman"  default(10)
+// @Success      200          {object}  dto.SuccessWrapper  "Berhasil mengambil daftar transfer"
+// @Failure      400          {object}  dto.ErrorWrapper    "Filter tidak valid"
+// @Failure      500          {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /transfers [get]
+func (h *TransferHandler) GetAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := repository.TransferFilter{Status: c.Query("status"), TenantID: *tenantID}
+	if locationID := c.Query("location_id"); locationID != "" {
+		id, err := uuid.Parse(locationID)
+		if err != nil {
+			helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid location ID format")
+			return
+		}
+		filter.LocationID = id
+	}
+
+	items, total, err := h.usecase.FindAll(c.Request.Context(), filter, page, limit)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	itemResponses := make([]dto.TransferResponse, 0, len(items))
+	for _, item := range items {
+		itemResponses = append(itemResponses, toTransferResponse(item))
+	}
+
+	paginatedResponse := dto.PaginatedResponse[dto.TransferResponse]{
+		Data:       itemResponses,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved transfers", paginatedResponse)
+}
+
+// GetByID godoc
+// @Summary      Get transfer by ID
+// @Description  Mengambil satu data transfer berdasarkan ID
+// @Tags         Transfers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Transfer"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Berhasil mengambil data transfer"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Data tidak ditemukan"
+// @Router       /transfers/{id} [get]
+func (h *TransferHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.FindByID(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Successfully retrieved transfer", toTransferResponse(result))
+}
+
+// Ship godoc
+// @Summary      Mark transfer as in transit
+// @Description  Menandai kiriman sudah berangkat dari lokasi asal
+// @Tags         Transfers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID Transfer"  format(uuid)
+// @Success      200  {object}  dto.SuccessWrapper  "Transfer dalam perjalanan"
+// @Failure      400  {object}  dto.ErrorWrapper    "Format ID tidak valid"
+// @Failure      404  {object}  dto.ErrorWrapper    "Transfer tidak ditemukan"
+// @Failure      409  {object}  dto.ErrorWrapper    "Status transfer tidak valid"
+// @Failure      500  {object}  dto.ErrorWrapper    "Terjadi kesalahan internal"
+// @Router       /transfers/{id}/ship [put]
+func (h *TransferHandler) Ship(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Ship(c.Request.Context(), id, *tenantID)
+	if err != nil {
+		sendTransferError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Transfer is in transit", toTransferResponse(result))
+}
+
+// Receive godoc
+// @Summary      Receive a transfer
+// @Description  Mencatat penerimaan kiriman oleh tenant lokasi tujuan, termasuk penerimaan sebagian. Kantong yang dikunci saat dispatch pindah ke lokasi tujuan; kantong yang tidak diterima utuh dimusnahkan.
+// @Tags         Transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                      true  "ID Transfer"  format(uuid)
+// @Param        body  body      dto.ReceiveTransferRequest  true  "Data Penerimaan"
+// @Success      200   {object}  dto.SuccessWrapper          "Transfer berhasil diterima"
+// @Failure      400   {object}  dto.ErrorWrapper            "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper            "Transfer tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper            "Status transfer tidak valid atau stok tidak mencukupi"
+// @Failure      500   {object}  dto.ErrorWrapper            "Terjadi kesalahan internal"
+// @Router       /transfers/{id}/receive [put]
+func (h *TransferHandler) Receive(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.ReceiveTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Receive(c.Request.Context(), id, req, *tenantID, *userID)
+	if err != nil {
+		sendTransferError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Transfer received successfully", toTransferResponse(result))
+}
+
+// Reject godoc
+// @Summary      Reject a transfer
+// @Description  Menolak kiriman; kantong dikembalikan ke stok lokasi asal
+// @Tags         Transfers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string                     true  "ID Transfer"  format(uuid)
+// @Param        body  body      dto.RejectTransferRequest  true  "Alasan Penolakan"
+// @Success      200   {object}  dto.SuccessWrapper         "Transfer ditolak"
+// @Failure      400   {object}  dto.ErrorWrapper           "Format ID atau request tidak valid"
+// @Failure      404   {object}  dto.ErrorWrapper           "Transfer tidak ditemukan"
+// @Failure      409   {object}  dto.ErrorWrapper           "Status transfer tidak valid"
+// @Failure      500   {object}  dto.ErrorWrapper           "Terjadi kesalahan internal"
+// @Router       /transfers/{id}/reject [put]
+func (h *TransferHandler) Reject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, "Invalid ID format")
+		return
+	}
+	var req dto.RejectTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantID, err := helper.GetContextValue(c, "tenantID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	userID, err := helper.GetContextValue(c, "userID")
+	if err != nil {
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.usecase.Reject(c.Request.Context(), id, req, *tenantID, *userID)
+	if err != nil {
+		sendTransferError(c, err)
+		return
+	}
+
+	helper.SendSuccessResponse(c, http.StatusOK, "Transfer rejected", toTransferResponse(result))
+}
+
+func toTransferResponse(transfer entity.Transfer) dto.TransferResponse {
+	var res dto.TransferResponse
+	copier.Copy(&res, &transfer)
+	res.ID = transfer.ID.String()
+	res.SourceLocationID = transfer.SourceLocationID.String()
+	res.DestinationLocationID = transfer.DestinationLocationID.String()
+	return res
+}
+
+func sendTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		helper.SendErrorResponse(c, http.StatusNotFound, "Record not found")
+	case errors.Is(err, entity.ErrSameTransferLocation),
+		errors.Is(err, entity.ErrInvalidReceivedQuantity):
+		helper.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, entity.ErrInvalidTransferStatus),
+		errors.Is(err, entity.ErrInsufficientStock):
+		helper.SendErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		helper.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
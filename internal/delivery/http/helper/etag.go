@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetETag menulis versi resource sebagai header ETag.
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ParseIfMatch membaca versi yang diharapkan dari header If-Match.
+// Mengembalikan 0 bila header kosong atau "*", dan -1 bila nilainya tidak dikenal
+// sehingga tidak akan pernah cocok dengan versi mana pun.
+func ParseIfMatch(c *gin.Context) int {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(value, "W/"), `"`))
+	if err != nil || version <= 0 {
+		return -1
+	}
+	return version
+}
@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"donor-api/internal/delivery/http/dto"
 	"errors"
 
 	"github.com/gin-gonic/gin"
@@ -22,6 +23,19 @@ func GetContextValue(c *gin.Context, key string) (*uuid.UUID, error) {
 	return &value, nil
 }
 
+// GetActor menyusun identitas pemanggil dari nilai yang diisi AuthMiddleware.
+func GetActor(c *gin.Context) (dto.Actor, error) {
+	userID, err := GetContextValue(c, "userID")
+	if err != nil {
+		return dto.Actor{}, err
+	}
+	tenantID, err := GetContextValue(c, "tenantID")
+	if err != nil {
+		return dto.Actor{}, err
+	}
+	return dto.Actor{UserID: *userID, TenantID: *tenantID, Role: c.GetString("role")}, nil
+}
+
 func GetRoleFromContext(c *gin.Context) (*string, error) {
 	valueContext, exists := c.Get("role")
 	if !exists {
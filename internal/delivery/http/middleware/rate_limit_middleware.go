@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware membatasi jumlah permintaan per alamat IP dalam satu jendela waktu tetap.
+// Penghitung disimpan di memori proses dan dikosongkan setiap kali jendela berganti.
+func RateLimitMiddleware(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	windowEnd := time.Now().Add(window)
+
+	return func(c *gin.Context) {
+		mu.Lock()
+		now := time.Now()
+		if !now.Before(windowEnd) {
+			counts = map[string]int{}
+			windowEnd = now.Add(window)
+		}
+		counts[c.ClientIP()]++
+		count := counts[c.ClientIP()]
+		retryAfter := windowEnd.Sub(now)
+		mu.Unlock()
+
+		if count > limit {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests, please try again later"})
+			return
+		}
+		c.Next()
+	}
+}
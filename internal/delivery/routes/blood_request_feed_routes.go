@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitBloodRequestFeedRoutes(
+	router *gin.RouterGroup,
+	handler *handler.BloodRequestFeedHandler,
+	rateLimitMiddleware gin.HandlerFunc,
+) {
+	publicRoutes := router.Group("/public", rateLimitMiddleware)
+	{
+		publicRoutes.GET("/blood-requests", handler.GetPublic)
+	}
+}
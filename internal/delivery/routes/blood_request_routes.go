@@ -2,6 +2,7 @@ package routes
 
 import (
 	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -9,13 +10,20 @@ import (
 func InitBloodRequestRoutes(
 	router *gin.RouterGroup,
 	handler *handler.BloodRequestHandler,
+	authMiddleware gin.HandlerFunc,
 ) {
-	blood_requestsRoutes := router.Group("/blood-requests")
+	// Akses publik hanya melalui umpan anonim di /public/blood-requests.
+	blood_requestsRoutes := router.Group("/blood-requests", authMiddleware)
 	{
 		blood_requestsRoutes.POST("", handler.Create)
 		blood_requestsRoutes.GET("", handler.GetAll)
+		blood_requestsRoutes.GET("/queue", middleware.RequireRoles("superadmin", "admin"), handler.GetQueue)
 		blood_requestsRoutes.GET("/:id", handler.GetByID)
 		blood_requestsRoutes.PUT("/:id", handler.Update)
+		blood_requestsRoutes.GET("/:id/match-plan", handler.MatchPlan)
+		blood_requestsRoutes.POST("/:id/match-plan/approve", middleware.RequireRoles("superadmin", "admin"), handler.ApproveMatchPlan)
+		blood_requestsRoutes.POST("/:id/fulfillments", middleware.RequireRoles("superadmin", "admin"), handler.Issue)
+		blood_requestsRoutes.PATCH("/:id/status", handler.UpdateStatus)
 		blood_requestsRoutes.DELETE("/:id", handler.Delete)
 	}
 }
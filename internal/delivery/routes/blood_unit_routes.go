@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitBloodUnitRoutes(
+	router *gin.RouterGroup,
+	handler *handler.BloodUnitHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	staffOnly := middleware.RequireRoles("superadmin", "admin")
+
+	bloodUnitsRoutes := router.Group("/blood-units")
+	{
+		bloodUnitsRoutes.Use(authMiddleware)
+		bloodUnitsRoutes.POST("", staffOnly, handler.Create)
+		bloodUnitsRoutes.GET("", handler.GetAll)
+		bloodUnitsRoutes.GET("/:id", handler.GetByID)
+		bloodUnitsRoutes.PUT("/:id", staffOnly, handler.Update)
+		bloodUnitsRoutes.PUT("/:id/status", staffOnly, handler.UpdateStatus)
+		bloodUnitsRoutes.PUT("/:id/review", staffOnly, handler.Review)
+		bloodUnitsRoutes.DELETE("/:id", staffOnly, handler.Delete)
+	}
+	router.POST("/donations/:id/components", authMiddleware, staffOnly, handler.ProcessDonation)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitDonorCallUpRoutes(
+	router *gin.RouterGroup,
+	handler *handler.DonorCallUpHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	staffOnly := middleware.RequireRoles("superadmin", "admin")
+
+	router.POST("/blood-requests/:id/call-ups", authMiddleware, staffOnly, handler.Broadcast)
+	router.GET("/blood-requests/:id/call-ups", authMiddleware, staffOnly, handler.GetByRequest)
+
+	callUpsRoutes := router.Group("/donor-call-ups", authMiddleware)
+	{
+		callUpsRoutes.GET("/:id", staffOnly, handler.GetByID)
+		callUpsRoutes.PUT("/:id/response", handler.Respond)
+		callUpsRoutes.PUT("/:id/recipients/:userId/arrival", staffOnly, handler.MarkArrived)
+	}
+}
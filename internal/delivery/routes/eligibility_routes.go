@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitEligibilityRoutes(
+	router *gin.RouterGroup,
+	handler *handler.EligibilityHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	staffOnly := middleware.RequireRoles("superadmin", "admin")
+
+	router.GET("/profile/eligibility", authMiddleware, handler.GetMine)
+	router.GET("/users/:id/eligibility", authMiddleware, staffOnly, handler.GetByUser)
+
+	policyRoutes := router.Group("/eligibility-policy", authMiddleware, staffOnly)
+	{
+		policyRoutes.GET("", handler.GetPolicy)
+		policyRoutes.PUT("", handler.SavePolicy)
+	}
+}
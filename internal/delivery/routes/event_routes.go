@@ -8,8 +8,12 @@ import (
 func InitEventRoutes(
 	router *gin.RouterGroup,
 	handler *handler.EventHandler,
+	authMiddleware gin.HandlerFunc,
+	rateLimitMiddleware gin.HandlerFunc,
 ) {
-	eventsRoutes := router.Group("/events")
+	router.GET("/public/events", rateLimitMiddleware, handler.GetPublic)
+
+	eventsRoutes := router.Group("/events", authMiddleware)
 	{
 		eventsRoutes.POST("", handler.Create)
 		eventsRoutes.GET("", handler.GetAll)
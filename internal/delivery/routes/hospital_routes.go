@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitHospitalRoutes(
+	router *gin.RouterGroup,
+	handler *handler.HospitalHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	hospitalsRoutes := router.Group("/hospitals", authMiddleware,
+		middleware.RequireRoles("superadmin", "admin"))
+	{
+		hospitalsRoutes.POST("", handler.Create)
+		hospitalsRoutes.GET("", handler.GetAll)
+		hospitalsRoutes.GET("/:id", handler.GetByID)
+		hospitalsRoutes.PUT("/:id", handler.Update)
+		hospitalsRoutes.DELETE("/:id", handler.Delete)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitNotificationRoutes(
+	router *gin.RouterGroup,
+	handler *handler.NotificationHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	notificationsRoutes := router.Group("/notifications", authMiddleware)
+	{
+		notificationsRoutes.GET("", handler.GetAll)
+		notificationsRoutes.PUT("/:id/read", handler.MarkRead)
+	}
+}
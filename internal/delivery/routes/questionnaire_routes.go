@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitQuestionnaireRoutes(
+	router *gin.RouterGroup,
+	handler *handler.QuestionnaireHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	router.GET("/donations/:id/questionnaire", authMiddleware, handler.GetForDonation)
+	router.POST("/donations/:id/questionnaire", authMiddleware, handler.Submit)
+
+	questionnairesRoutes := router.Group("/questionnaires", authMiddleware,
+		middleware.RequireRoles("superadmin", "admin"))
+	{
+		questionnairesRoutes.POST("", handler.Create)
+		questionnairesRoutes.GET("", handler.GetAll)
+		questionnairesRoutes.GET("/:id", handler.GetByID)
+	}
+}
@@ -3,22 +3,38 @@ package routes
 import (
 	"donor-api/internal/delivery/http/handler"
 	"donor-api/internal/delivery/http/middleware"
+	"donor-api/internal/delivery/scheduler"
 	"donor-api/internal/infrastructure/persistence"
 	"donor-api/internal/infrastructure/security"
 	"donor-api/internal/usecase"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func NewAPIRoutes(db *gorm.DB) *gin.Engine {
+// NewAPIRoutes menyusun seluruh dependensi dan route API. Usecase yang perlu dijalankan berkala
+// dikembalikan lewat scheduler.Jobs agar pemanggil yang mengatur siklus hidup goroutine-nya.
+func NewAPIRoutes(db *gorm.DB) (*gin.Engine, scheduler.Jobs) {
 	jwtSecret := os.Getenv("JWT_SECRET_KEY")
 	jwtExpHoursStr := os.Getenv("JWT_EXPIRATION_IN_HOURS")
 	webClientID := os.Getenv("WEB_CLIENT_ID")
 	jwtExpHours, _ := strconv.ParseInt(jwtExpHoursStr, 10, 64)
+	reservationTTLMinutes, err := strconv.Atoi(os.Getenv("STOCK_RESERVATION_TTL_MINUTES"))
+	if err != nil || reservationTTLMinutes <= 0 {
+		reservationTTLMinutes = 120
+	}
+	publicFeedCacheSeconds, err := strconv.Atoi(os.Getenv("PUBLIC_FEED_CACHE_TTL_SECONDS"))
+	if err != nil || publicFeedCacheSeconds <= 0 {
+		publicFeedCacheSeconds = 30
+	}
+	publicFeedRateLimit, err := strconv.Atoi(os.Getenv("PUBLIC_FEED_RATE_LIMIT_PER_MINUTE"))
+	if err != nil || publicFeedRateLimit <= 0 {
+		publicFeedRateLimit = 60
+	}
 
 	jwtService := security.NewJWTService(jwtSecret, jwtExpHours)
 
@@ -30,33 +46,82 @@ func NewAPIRoutes(db *gorm.DB) *gin.Engine {
 	authUsecase := usecase.NewAuthUsecase(userRepo, tenantRepo, jwtService, webClientID)
 	authHandler := handler.NewAuthHandler(authUsecase)
 	authMiddleware := middleware.AuthMiddleware(jwtService)
+	// Setiap endpoint publik memiliki limiter sendiri agar kuota per IP-nya tidak saling berbagi.
+	publicEventsRateLimitMiddleware := middleware.RateLimitMiddleware(publicFeedRateLimit, time.Minute)
+	publicFeedRateLimitMiddleware := middleware.RateLimitMiddleware(publicFeedRateLimit, time.Minute)
 
 	userUsecase := usecase.NewUserUsecase(userRepo)
 	profileHanlder := handler.NewProfileHandler(userUsecase)
 
+	locationRepo := persistence.NewLocationRepository(db)
+	locationUsecase := usecase.NewLocationUsecase(locationRepo)
+	locationHandler := handler.NewLocationHandler(locationUsecase)
+
+	eligibilityRepo := persistence.NewEligibilityRepository(db)
+	eligibilityUsecase := usecase.NewEligibilityUsecase(eligibilityRepo, locationRepo)
+	eligibilityHandler := handler.NewEligibilityHandler(eligibilityUsecase)
+
 	donationRepo := persistence.NewDonationRepository(db)
-	donationUsecase := usecase.NewDonationUsecase(donationRepo)
+	questionnaireRepo := persistence.NewQuestionnaireRepository(db)
+	screeningRepo := persistence.NewScreeningRepository(db)
+	donationUsecase := usecase.NewDonationUsecase(donationRepo, questionnaireRepo, screeningRepo, eligibilityUsecase)
 	donationHandler := handler.NewDonationHandler(donationUsecase)
 
+	screeningUsecase := usecase.NewScreeningUsecase(screeningRepo, donationRepo, locationRepo, userRepo, eligibilityUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	questionnaireUsecase := usecase.NewQuestionnaireUsecase(questionnaireRepo, donationRepo, locationRepo)
+	questionnaireHandler := handler.NewQuestionnaireHandler(questionnaireUsecase)
+
 	eventRepo := persistence.NewEventRepository(db)
-	eventUsecase := usecase.NewEventUsecase(eventRepo)
+	eventUsecase := usecase.NewEventUsecase(eventRepo, locationRepo)
 	eventHandler := handler.NewEventHandler(eventUsecase)
 
-	locationRepo := persistence.NewLocationRepository(db)
-	locationUsecase := usecase.NewLocationUsecase(locationRepo)
-	locationHandler := handler.NewLocationHandler(locationUsecase)
+	hospitalRepo := persistence.NewHospitalRepository(db)
+	hospitalUsecase := usecase.NewHospitalUsecase(hospitalRepo)
+	hospitalHandler := handler.NewHospitalHandler(hospitalUsecase)
 
 	bloodRequestRepo := persistence.NewBloodRequestRepository(db)
-	bloodRequestUsecase := usecase.NewBloodRequestUsecase(bloodRequestRepo)
+	stockReservationRepo := persistence.NewStockReservationRepository(db)
+	fulfillmentRepo := persistence.NewBloodRequestFulfillmentRepository(db)
+	stockRepo := persistence.NewStockRepository(db)
+	bloodRequestUsecase := usecase.NewBloodRequestUsecase(bloodRequestRepo, stockReservationRepo, fulfillmentRepo, stockRepo, locationRepo, hospitalRepo, userRepo, time.Duration(reservationTTLMinutes)*time.Minute)
 	bloodRequestHandler := handler.NewBloodRequestHandler(bloodRequestUsecase)
 
+	bloodRequestFeedUsecase := usecase.NewBloodRequestFeedUsecase(bloodRequestRepo, time.Duration(publicFeedCacheSeconds)*time.Second)
+	bloodRequestFeedHandler := handler.NewBloodRequestFeedHandler(bloodRequestFeedUsecase)
+
+	donorCallUpRepo := persistence.NewDonorCallUpRepository(db)
+	donorCallUpUsecase := usecase.NewDonorCallUpUsecase(donorCallUpRepo, bloodRequestRepo, locationRepo, bloodRequestUsecase, eligibilityUsecase)
+	donorCallUpHandler := handler.NewDonorCallUpHandler(donorCallUpUsecase)
+
+	notificationRepo := persistence.NewNotificationRepository(db)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase)
+
+	storageDeviceRepo := persistence.NewStorageDeviceRepository(db)
+	storageDeviceUsecase := usecase.NewStorageDeviceUsecase(storageDeviceRepo, locationRepo)
+	storageDeviceHandler := handler.NewStorageDeviceHandler(storageDeviceUsecase)
+
+	bloodUnitRepo := persistence.NewBloodUnitRepository(db)
+	bloodUnitUsecase := usecase.NewBloodUnitUsecase(bloodUnitRepo, donationRepo, userRepo, storageDeviceRepo, locationRepo)
+	bloodUnitHandler := handler.NewBloodUnitHandler(bloodUnitUsecase)
+
+	stockUsecase := usecase.NewStockUsecase(stockRepo, locationRepo, donationRepo, userRepo)
+	stockHandler := handler.NewStockHandler(stockUsecase)
+
+	transferRepo := persistence.NewTransferRepository(db)
+	transferUsecase := usecase.NewTransferUsecase(transferRepo, locationRepo)
+	transferHandler := handler.NewTransferHandler(transferUsecase)
+
 	// Inisialisasi router
 	router := gin.Default()
 
 	router.Use(cors.New(cors.Config{
 		AllowAllOrigins:  true,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "If-Match"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: false,
 	}))
 
@@ -78,11 +143,27 @@ func NewAPIRoutes(db *gorm.DB) *gin.Engine {
 		InitAuthRoutes(apiV1, authHandler, authMiddleware)
 		InitProfileRoutes(apiV1, profileHanlder, authMiddleware)
 		InitDonationRoutes(apiV1, donationHandler, authMiddleware)
-		InitEventRoutes(apiV1, eventHandler)
+		InitEligibilityRoutes(apiV1, eligibilityHandler, authMiddleware)
+		InitQuestionnaireRoutes(apiV1, questionnaireHandler, authMiddleware)
+		InitEventRoutes(apiV1, eventHandler, authMiddleware, publicEventsRateLimitMiddleware)
 		InitLocationRoutes(apiV1, locationHandler, authMiddleware)
-		InitBloodRequestRoutes(apiV1, bloodRequestHandler)
+		InitBloodRequestRoutes(apiV1, bloodRequestHandler, authMiddleware)
+		InitDonorCallUpRoutes(apiV1, donorCallUpHandler, authMiddleware)
+		InitBloodRequestFeedRoutes(apiV1, bloodRequestFeedHandler, publicFeedRateLimitMiddleware)
 		InitTenantRoutes(apiV1, tenantHandler)
+		InitStockRoutes(apiV1, stockHandler, authMiddleware)
+		InitBloodUnitRoutes(apiV1, bloodUnitHandler, authMiddleware)
+		InitTransferRoutes(apiV1, transferHandler, authMiddleware)
+		InitStorageDeviceRoutes(apiV1, storageDeviceHandler, authMiddleware)
+		InitScreeningRoutes(apiV1, screeningHandler, authMiddleware)
+		InitNotificationRoutes(apiV1, notificationHandler, authMiddleware)
+		InitHospitalRoutes(apiV1, hospitalHandler, authMiddleware)
+	}
+
+	jobs := scheduler.Jobs{
+		StockUsecase:        stockUsecase,
+		BloodRequestUsecase: bloodRequestUsecase,
 	}
 
-	return router
+	return router, jobs
 }
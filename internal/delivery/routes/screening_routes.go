@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitScreeningRoutes(
+	router *gin.RouterGroup,
+	handler *handler.ScreeningHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	staffOnly := middleware.RequireRoles("superadmin", "admin")
+
+	router.POST("/donations/:id/screening", authMiddleware, staffOnly, handler.RecordResults)
+	router.GET("/donations/:id/screening", authMiddleware, staffOnly, handler.GetResults)
+	router.POST("/donations/:id/vitals", authMiddleware, staffOnly, handler.RecordVitalSigns)
+	router.GET("/donations/:id/vitals", authMiddleware, staffOnly, handler.GetVitalSigns)
+
+	deferralsRoutes := router.Group("/donor-deferrals", authMiddleware, staffOnly)
+	{
+		deferralsRoutes.GET("", handler.GetDeferrals)
+		deferralsRoutes.PUT("/:id/follow-up", handler.CompleteFollowUp)
+	}
+}
@@ -2,18 +2,36 @@ package routes
 
 import (
 	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
 func InitStockRoutes(
 	router *gin.RouterGroup,
 	handler *handler.StockHandler,
+	authMiddleware gin.HandlerFunc,
 ) {
-	stocksRoutes := router.Group("/stocks")
+	stocksRoutes := router.Group("/stocks", authMiddleware,
+		middleware.RequireRoles("superadmin", "admin"))
 	{
 		stocksRoutes.POST("", handler.Create)
 		stocksRoutes.GET("", handler.GetAll)
+		stocksRoutes.GET("/summary", handler.Summary)
+		stocksRoutes.GET("/availability", handler.Availability)
+		stocksRoutes.GET("/forecast", handler.Forecast)
+		stocksRoutes.POST("/allocate", handler.Allocate)
+		stocksRoutes.POST("/expiry-sweep", middleware.RequireRoles("superadmin"), handler.ExpirySweep)
+		stocksRoutes.GET("/thresholds", handler.GetThresholds)
+		stocksRoutes.PUT("/thresholds", handler.SaveThreshold)
+		stocksRoutes.DELETE("/thresholds/:id", handler.DeleteThreshold)
+		stocksRoutes.GET("/alerts", handler.GetAlerts)
+		stocksRoutes.PUT("/alerts/:id/acknowledge", handler.AcknowledgeAlert)
 		stocksRoutes.GET("/:id", handler.GetByID)
+		stocksRoutes.GET("/:id/movements", handler.GetMovements)
+		stocksRoutes.GET("/:id/reconcile", handler.Reconcile)
+		stocksRoutes.POST("/:id/increment", handler.Increment)
+		stocksRoutes.POST("/:id/decrement", handler.Decrement)
 		stocksRoutes.PUT("/:id", handler.Update)
 		stocksRoutes.DELETE("/:id", handler.Delete)
 	}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitStorageDeviceRoutes(
+	router *gin.RouterGroup,
+	handler *handler.StorageDeviceHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	storageDevicesRoutes := router.Group("/storage-devices", authMiddleware,
+		middleware.RequireRoles("superadmin", "admin"))
+	{
+		storageDevicesRoutes.POST("", handler.Create)
+		storageDevicesRoutes.GET("", handler.GetAll)
+		storageDevicesRoutes.GET("/excursions", handler.GetExcursions)
+		storageDevicesRoutes.GET("/:id", handler.GetByID)
+		storageDevicesRoutes.PUT("/:id", handler.Update)
+		storageDevicesRoutes.DELETE("/:id", handler.Delete)
+		storageDevicesRoutes.POST("/:id/readings", handler.RecordReadings)
+		storageDevicesRoutes.GET("/:id/readings", handler.GetReadings)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"donor-api/internal/delivery/http/handler"
+	"donor-api/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InitTransferRoutes(
+	router *gin.RouterGroup,
+	handler *handler.TransferHandler,
+	authMiddleware gin.HandlerFunc,
+) {
+	transfersRoutes := router.Group("/transfers", authMiddleware,
+		middleware.RequireRoles("superadmin", "admin"))
+	{
+		transfersRoutes.POST("", handler.Dispatch)
+		transfersRoutes.GET("", handler.GetAll)
+		transfersRoutes.GET("/:id", handler.GetByID)
+		transfersRoutes.PUT("/:id/ship", handler.Ship)
+		transfersRoutes.PUT("/:id/receive", handler.Receive)
+		transfersRoutes.PUT("/:id/reject", handler.Reject)
+	}
+}
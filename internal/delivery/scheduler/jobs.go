@@ -0,0 +1,9 @@
+package scheduler
+
+import "donor-api/internal/usecase"
+
+// Jobs berisi usecase yang dijalankan berkala di background oleh scheduler.
+type Jobs struct {
+	StockUsecase        usecase.StockUsecase
+	BloodRequestUsecase usecase.BloodRequestUsecase
+}
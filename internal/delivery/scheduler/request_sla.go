@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"context"
+	"donor-api/internal/usecase"
+	"log"
+	"time"
+)
+
+// StartRequestSLAEscalation memeriksa permintaan darah yang melewati SLA secara berkala dan memberi tahu admin tenant.
+// Goroutine berhenti begitu ctx dibatalkan.
+func StartRequestSLAEscalation(ctx context.Context, bloodRequestUsecase usecase.BloodRequestUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runRequestSLAEscalation(ctx, bloodRequestUsecase)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func runRequestSLAEscalation(ctx context.Context, bloodRequestUsecase usecase.BloodRequestUsecase) {
+	escalated, err := bloodRequestUsecase.EscalateOverdue(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal memeriksa SLA permintaan darah: %v", err)
+		return
+	}
+	if escalated == 0 {
+		return
+	}
+
+	log.Printf("🚨 Eskalasi SLA: %d permintaan darah dilaporkan ke admin tenant", escalated)
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"context"
+	"donor-api/internal/usecase"
+	"log"
+	"time"
+)
+
+// StartReservationExpirySweep melepas tahanan stok permintaan darah yang melewati TTL secara berkala.
+// Goroutine berhenti begitu ctx dibatalkan.
+func StartReservationExpirySweep(ctx context.Context, bloodRequestUsecase usecase.BloodRequestUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runReservationExpirySweep(ctx, bloodRequestUsecase)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func runReservationExpirySweep(ctx context.Context, bloodRequestUsecase usecase.BloodRequestUsecase) {
+	released, err := bloodRequestUsecase.ReleaseExpiredReservations(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal melepas tahanan stok yang kedaluwarsa: %v", err)
+		return
+	}
+	if released == 0 {
+		return
+	}
+
+	log.Printf("🩸 Tahanan kedaluwarsa: %d kantong dikembalikan ke stok", released)
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"donor-api/internal/usecase"
+	"log"
+	"time"
+)
+
+// StartStockExpirySweep menjalankan sweep kedaluwarsa stok secara berkala di background.
+// Goroutine berhenti begitu ctx dibatalkan.
+func StartStockExpirySweep(ctx context.Context, stockUsecase usecase.StockUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runStockExpirySweep(ctx, stockUsecase)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func runStockExpirySweep(ctx context.Context, stockUsecase usecase.StockUsecase) {
+	report, err := stockUsecase.ExpireUnits(ctx)
+	if err != nil {
+		log.Printf("❌ Gagal menjalankan sweep kedaluwarsa stok: %v", err)
+		return
+	}
+	if report.TotalUnits == 0 {
+		return
+	}
+
+	log.Printf("🩸 Sweep kedaluwarsa: %d kantong ditandai expired", report.TotalUnits)
+	for _, location := range report.Locations {
+		for _, item := range location.Items {
+			log.Printf("   lokasi %s: %d kantong %s%s %s", location.LocationID, item.Quantity, item.BloodType, item.Rhesus, item.Component)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"donor-api/pkg/compatibility"
+	"time"
+)
+
+// Kode komponen darah mengikuti paket compatibility agar aturan kecocokan dan entitas memakai kode yang sama.
+const (
+	ComponentWholeBlood = compatibility.ComponentWholeBlood // Whole Blood
+	ComponentPRC        = compatibility.ComponentPRC        // Packed Red Cells
+	ComponentFFP        = compatibility.ComponentFFP        // Fresh Frozen Plasma
+	ComponentTC         = compatibility.ComponentTC         // Thrombocyte Concentrate
+	ComponentCryo       = compatibility.ComponentCryo       // Cryoprecipitate
+)
+
+// BloodTypes dan RhesusTypes adalah golongan darah ABO dan rhesus yang dikenal sistem.
+var (
+	BloodTypes  = []string{"A", "B", "AB", "O"}
+	RhesusTypes = []string{"+", "-"}
+)
+
+// ComponentSpec menyimpan masa simpan dan aturan penyimpanan tiap komponen darah.
+type ComponentSpec struct {
+	Name        string
+	ShelfLife   time.Duration
+	StorageRule string
+}
+
+var BloodComponents = map[string]ComponentSpec{
+	ComponentWholeBlood: {Name: "Whole Blood", ShelfLife: 35 * 24 * time.Hour, StorageRule: "2-6°C"},
+	ComponentPRC:        {Name: "Packed Red Cells", ShelfLife: 42 * 24 * time.Hour, StorageRule: "2-6°C"},
+	ComponentFFP:        {Name: "Fresh Frozen Plasma", ShelfLife: 365 * 24 * time.Hour, StorageRule: "≤ -25°C"},
+	ComponentTC:         {Name: "Thrombocyte Concentrate", ShelfLife: 5 * 24 * time.Hour, StorageRule: "20-24°C, agitasi terus-menerus"},
+	ComponentCryo:       {Name: "Cryoprecipitate", ShelfLife: 365 * 24 * time.Hour, StorageRule: "≤ -25°C"},
+}
+
+// ComponentExpiryDate menghitung tanggal kedaluwarsa komponen dari tanggal pengambilan darah.
+func ComponentExpiryDate(component string, collectionDate time.Time) (time.Time, bool) {
+	spec, ok := BloodComponents[component]
+	if !ok {
+		return time.Time{}, false
+	}
+	return collectionDate.Add(spec.ShelfLife), true
+}
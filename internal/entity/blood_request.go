@@ -7,19 +7,117 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	BloodRequestStatusPending            = "pending"             // menunggu verifikasi
+	BloodRequestStatusApproved           = "approved"            // disetujui, kantong sedang disiapkan
+	BloodRequestStatusPartiallyFulfilled = "partially_fulfilled" // sebagian kantong sudah diserahkan
+	BloodRequestStatusFulfilled          = "fulfilled"           // semua kantong sudah diserahkan
+	BloodRequestStatusCancelled          = "cancelled"           // dibatalkan pemohon
+	BloodRequestStatusRejected           = "rejected"            // ditolak petugas
+)
+
+const (
+	BloodRequestUrgencyRoutine   = "routine"   // terjadwal
+	BloodRequestUrgencyUrgent    = "urgent"    // mendesak
+	BloodRequestUrgencyEmergency = "emergency" // cito
+)
+
+// BloodRequestUrgencySLA adalah target waktu pemenuhan sejak permintaan dibuat untuk tiap tingkat urgensi.
+var BloodRequestUrgencySLA = map[string]time.Duration{
+	BloodRequestUrgencyRoutine:   24 * time.Hour,
+	BloodRequestUrgencyUrgent:    6 * time.Hour,
+	BloodRequestUrgencyEmergency: 1 * time.Hour,
+}
+
+// BloodRequestOpenStatuses adalah status permintaan yang masih berjalan.
+var BloodRequestOpenStatuses = []string{
+	BloodRequestStatusPending,
+	BloodRequestStatusApproved,
+	BloodRequestStatusPartiallyFulfilled,
+}
+
+// bloodRequestTransitions adalah perpindahan status yang diizinkan. Status tanpa entri bersifat final.
+var bloodRequestTransitions = map[string][]string{
+	BloodRequestStatusPending: {
+		BloodRequestStatusApproved,
+		BloodRequestStatusRejected,
+		BloodRequestStatusCancelled,
+	},
+	BloodRequestStatusApproved: {
+		BloodRequestStatusPartiallyFulfilled,
+		BloodRequestStatusFulfilled,
+		BloodRequestStatusCancelled,
+	},
+	BloodRequestStatusPartiallyFulfilled: {
+		BloodRequestStatusPartiallyFulfilled,
+		BloodRequestStatusFulfilled,
+		BloodRequestStatusCancelled,
+	},
+}
+
 type BloodRequest struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
-	LocationID  uuid.UUID `gorm:"type:uuid;index" json:"location_id"`
-	BloodType   string    `gorm:"type:varchar(2);not null" json:"blood_type"`       // A, B, AB, O
-	Quantity    int       `gorm:"not null" json:"quantity"`                         // in bags
-	Status      string    `gorm:"type:varchar(50);default:'pending'" json:"status"` // pending, fulfilled, cancelled
-	Description string    `gorm:"type:text" json:"description"`
-	CreatedBy   uuid.UUID `gorm:"type:uuid;not null" json:"created_by"` // UserID of the requester
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	LocationID        uuid.UUID  `gorm:"type:uuid;index" json:"location_id"`
+	TenantID          uuid.UUID  `gorm:"type:uuid;index" json:"tenant_id"`                                 // tenant pemohon, diisi dari token saat dibuat
+	BloodType         string     `gorm:"type:varchar(2);not null" json:"blood_type"`                       // A, B, AB, O
+	Rhesus            string     `gorm:"type:varchar(1)" json:"rhesus"`                                    // +, -
+	Component         string     `gorm:"type:varchar(10);default:'WB'" json:"component"`                   // WB, PRC, FFP, TC, CRYO
+	Quantity          int        `gorm:"not null" json:"quantity"`                                         // in bags
+	FulfilledQuantity int        `gorm:"not null;default:0" json:"fulfilled_quantity"`                     // total kantong yang sudah diserahkan
+	Status            string     `gorm:"type:varchar(50);default:'pending'" json:"status"`                 // pending, approved, partially_fulfilled, fulfilled, cancelled, rejected
+	Urgency           string     `gorm:"type:varchar(10);not null;default:'routine';index" json:"urgency"` // routine, urgent, emergency
+	Description       string     `gorm:"type:text" json:"description"`
+	HospitalID        *uuid.UUID `gorm:"type:uuid;index" json:"hospital_id"`
+	Ward              string     `gorm:"type:varchar(100)" json:"ward"`
+	DoctorName        string     `gorm:"type:varchar(255)" json:"doctor_name"`
+	PatientMRN        string     `gorm:"type:varchar(50)" json:"patient_mrn"` // nomor rekam medis
+	PatientName       string     `gorm:"type:varchar(255)" json:"patient_name"`
+	PatientAge        *int       `json:"patient_age"`
+	Diagnosis         string     `gorm:"type:text" json:"diagnosis"`
+	PatientHb         *float64   `gorm:"type:decimal(4,1)" json:"patient_hb"`  // g/dL
+	CreatedBy         uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"` // UserID of the requester
+	EscalatedAt       *time.Time `json:"escalated_at"`                         // diisi saat pelanggaran SLA dilaporkan ke admin tenant
+	Version           int        `gorm:"not null;default:1" json:"version"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
 func (p *BloodRequest) BeforeCreate(tx *gorm.DB) (err error) {
 	p.ID = uuid.New()
 	return
 }
+
+// CanTransitionTo menandakan status permintaan boleh berpindah ke status tujuan.
+func (p BloodRequest) CanTransitionTo(status string) bool {
+	for _, next := range bloodRequestTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen menandakan permintaan masih berjalan sehingga kantongnya tetap ditahan.
+func (p BloodRequest) IsOpen() bool {
+	return len(bloodRequestTransitions[p.Status]) > 0
+}
+
+// IsDeletable menandakan permintaan boleh dihapus: hanya yang masih pending atau sudah dibatalkan
+// dan belum pernah menyerahkan kantong, agar penyerahan dan riwayatnya tidak kehilangan induk.
+func (p BloodRequest) IsDeletable() bool {
+	return (p.Status == BloodRequestStatusPending || p.Status == BloodRequestStatusCancelled) && p.FulfilledQuantity == 0
+}
+
+// DueAt adalah batas waktu pemenuhan menurut SLA tingkat urgensinya.
+func (p BloodRequest) DueAt() time.Time {
+	sla, ok := BloodRequestUrgencySLA[p.Urgency]
+	if !ok {
+		sla = BloodRequestUrgencySLA[BloodRequestUrgencyRoutine]
+	}
+	return p.CreatedAt.Add(sla)
+}
+
+// IsOverdue menandakan permintaan masih berjalan namun sudah melewati batas SLA.
+func (p BloodRequest) IsOverdue(now time.Time) bool {
+	return p.IsOpen() && now.After(p.DueAt())
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BloodRequestFulfillment mencatat satu kali penyerahan kantong untuk sebuah BloodRequest.
+// Satu permintaan dapat dipenuhi bertahap melalui beberapa penyerahan.
+type BloodRequestFulfillment struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	BloodRequestID uuid.UUID `gorm:"type:uuid;not null;index" json:"blood_request_id"`
+	LocationID     uuid.UUID `gorm:"type:uuid;not null;index" json:"location_id"` // lokasi yang menyerahkan kantong
+	Quantity       int       `gorm:"not null" json:"quantity"`
+	Note           string    `gorm:"type:text" json:"note"`
+	IssuedBy       uuid.UUID `gorm:"type:uuid;not null" json:"issued_by"`
+	IssuedAt       time.Time `gorm:"not null;index" json:"issued_at"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Units []BloodRequestFulfillmentUnit `gorm:"foreignKey:FulfillmentID" json:"units,omitempty"`
+}
+
+func (p *BloodRequestFulfillment) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// BloodRequestFulfillmentUnit adalah kantong yang diserahkan pada sebuah penyerahan.
+type BloodRequestFulfillmentUnit struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	FulfillmentID uuid.UUID `gorm:"type:uuid;not null;index" json:"fulfillment_id"`
+	BloodUnitID   uuid.UUID `gorm:"type:uuid;not null;index" json:"blood_unit_id"`
+	BagNumber     string    `gorm:"type:varchar(50);not null" json:"bag_number"`
+	BloodType     string    `gorm:"type:varchar(2);not null" json:"blood_type"`
+	Rhesus        string    `gorm:"type:varchar(1);not null" json:"rhesus"`
+	Component     string    `gorm:"type:varchar(10);not null" json:"component"`
+	FromReserve   bool      `gorm:"not null;default:false" json:"from_reserve"` // kantong berasal dari tahanan permintaan ini
+}
+
+func (p *BloodRequestFulfillmentUnit) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BloodRequestStatusHistory mencatat setiap perpindahan status BloodRequest beserta pelaku dan catatannya.
+type BloodRequestStatusHistory struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	BloodRequestID uuid.UUID  `gorm:"type:uuid;not null;index" json:"blood_request_id"`
+	FromStatus     string     `gorm:"type:varchar(50);not null" json:"from_status"`
+	ToStatus       string     `gorm:"type:varchar(50);not null" json:"to_status"`
+	Note           string     `gorm:"type:text" json:"note"`
+	ChangedBy      *uuid.UUID `gorm:"type:uuid" json:"changed_by"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+func (p *BloodRequestStatusHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
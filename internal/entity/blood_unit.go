@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	BloodUnitStatusQuarantine = "quarantine" // menunggu hasil skrining IMLTD
+	BloodUnitStatusAvailable  = "available"
+	BloodUnitStatusReserved   = "reserved"
+	BloodUnitStatusInTransit  = "in_transit" // sedang dikirim ke lokasi lain
+	BloodUnitStatusIssued     = "issued"
+	BloodUnitStatusExpired    = "expired"
+	BloodUnitStatusDiscarded  = "discarded"
+)
+
+// BloodUnit adalah satu kantong darah fisik. Stock.BagQuantity dihitung dari unit berstatus available
+// yang tidak sedang menunggu review excursion suhu.
+type BloodUnit struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	BagNumber      string     `gorm:"type:varchar(50);uniqueIndex;not null" json:"bag_number"`
+	BloodType      string     `gorm:"type:varchar(2);not null" json:"blood_type"`              // A, B, AB, O
+	Rhesus         string     `gorm:"type:varchar(1);not null" json:"rhesus"`                  // +, -
+	Component      string     `gorm:"type:varchar(10);not null;default:'WB'" json:"component"` // WB, PRC, FFP, TC, CRYO
+	CollectionDate time.Time  `gorm:"type:date;not null" json:"collection_date"`
+	ExpiryDate     time.Time  `gorm:"type:date;not null;index" json:"expiry_date"`
+	LocationID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"location_id"`
+	DonationID     *uuid.UUID `gorm:"type:uuid;index" json:"donation_id"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'available';index" json:"status"` // available, reserved, in_transit, issued, expired, discarded
+	// StorageDeviceID adalah alat simpan tempat unit disimpan.
+	StorageDeviceID *uuid.UUID `gorm:"type:uuid;index" json:"storage_device_id"`
+	// NeedsReview ditandai saat alat simpan mengalami ekskursi suhu; unit tidak dialokasikan sampai direview.
+	NeedsReview bool      `gorm:"not null;default:false;index" json:"needs_review"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *BloodUnit) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// CanTransitionTo menandakan status unit boleh diubah manual ke status tujuan. Issued, expired, dan discarded
+// adalah status akhir; unit reserved dan in_transit hanya berubah lewat permintaan darah dan transfer.
+func (p *BloodUnit) CanTransitionTo(status string) bool {
+	switch p.Status {
+	case BloodUnitStatusAvailable:
+		return status == BloodUnitStatusIssued || status == BloodUnitStatusExpired || status == BloodUnitStatusDiscarded
+	case BloodUnitStatusQuarantine:
+		return status == BloodUnitStatusDiscarded
+	}
+	return false
+}
+
+// IsDeletable menandakan unit boleh dihapus: hanya unit karantina atau available, yang belum menjadi
+// bagian penyerahan maupun transfer yang sedang berjalan.
+func (p *BloodUnit) IsDeletable() bool {
+	return p.Status == BloodUnitStatusQuarantine || p.Status == BloodUnitStatusAvailable
+}
+
+// IsAvailable menandakan unit ikut dihitung dalam Stock.BagQuantity.
+func (p *BloodUnit) IsAvailable() bool {
+	return p.Status == BloodUnitStatusAvailable && !p.NeedsReview
+}
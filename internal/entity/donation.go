@@ -7,16 +7,25 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	DonationStatusPending   = "pending" // pendaftaran pendonor, darah belum diambil
+	DonationStatusCompleted = "selesai"
+	DonationStatusCancelled = "batal"
+)
+
 type Donation struct {
-	ID           uuid.UUID  `gorm:"type:uuid;primary_key;" `
-	Name         string     `gorm:"type:varchar(100)" `
-	UserID       *uuid.UUID `gorm:"type:uuid;not null" `
-	LocationID   uuid.UUID  `gorm:"type:uuid;index" `
-	EventID      *uuid.UUID `gorm:"type:uuid" `
-	DonationDate time.Time  `gorm:"type:date" `
-	Status       string     `gorm:"type:varchar(50);default:'pending'" `
-	CreatedAt    time.Time  ``
-	UpdatedAt    time.Time  ``
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;" `
+	Name            string     `gorm:"type:varchar(100)" `
+	UserID          *uuid.UUID `gorm:"type:uuid;not null" `
+	LocationID      uuid.UUID  `gorm:"type:uuid;index" `
+	EventID         *uuid.UUID `gorm:"type:uuid" `
+	DonationDate    time.Time  `gorm:"type:date" `
+	Status          string     `gorm:"type:varchar(50);default:'pending'" `
+	StatusReason    string     `gorm:"type:text" `                                       // alasan pembatalan, mis. hasil pemeriksaan pra-donor
+	DonationType    string     `gorm:"type:varchar(20);not null;default:'whole_blood'" ` // whole_blood, apheresis
+	ScreeningStatus string     `gorm:"type:varchar(20);not null;default:'pending'" `     // pending, cleared, reactive
+	CreatedAt       time.Time  ``
+	UpdatedAt       time.Time  ``
 
 	// Definisi relasi (opsional, untuk preloading)
 	// User         User       `gorm:"foreignKey:UserID"`
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DonorCallUpInvited  = "invited"  // menunggu jawaban pendonor
+	DonorCallUpAccepted = "accepted" // pendonor bersedia datang
+	DonorCallUpDeclined = "declined" // pendonor menolak
+)
+
+// DonorCallUp adalah panggilan donor untuk BloodRequest yang tidak dapat dipenuhi dari stok.
+// Panggilan dikirim ke pendonor aktif yang golongannya cocok dan berada dalam radius lokasi permintaan.
+type DonorCallUp struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	BloodRequestID uuid.UUID `gorm:"type:uuid;not null;index" json:"blood_request_id"`
+	LocationID     uuid.UUID `gorm:"type:uuid;not null;index" json:"location_id"` // lokasi tujuan pendonor datang
+	RadiusKm       float64   `gorm:"type:decimal(6,2);not null" json:"radius_km"`
+	Shortfall      int       `gorm:"not null" json:"shortfall"` // kekurangan kantong saat panggilan dikirim
+	Message        string    `gorm:"type:text" json:"message"`
+	CreatedBy      uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Recipients []DonorCallUpRecipient `gorm:"foreignKey:CallUpID" json:"recipients,omitempty"`
+}
+
+func (p *DonorCallUp) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// DonorCallUpRecipient adalah pendonor yang menerima panggilan beserta jawaban dan kedatangannya.
+type DonorCallUpRecipient struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	CallUpID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_call_up_recipient" json:"call_up_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_call_up_recipient;index" json:"user_id"`
+	FullName    string     `gorm:"type:varchar(255)" json:"full_name"`
+	PhoneNumber string     `gorm:"type:varchar(20)" json:"phone_number"`
+	BloodType   string     `gorm:"type:varchar(2);not null" json:"blood_type"`
+	Rhesus      string     `gorm:"type:varchar(1);not null" json:"rhesus"`
+	DistanceKm  float64    `gorm:"type:decimal(6,2)" json:"distance_km"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'invited'" json:"status"` // invited, accepted, declined
+	RespondedAt *time.Time `json:"responded_at"`
+	ArrivedAt   *time.Time `json:"arrived_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (p *DonorCallUpRecipient) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
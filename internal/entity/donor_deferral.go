@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DonorDeferral menunda pendonor dari donasi berikutnya. DeferredUntil kosong berarti penundaan permanen.
+// Penundaan dari hasil skrining reaktif selalu memerlukan tindak lanjut (konseling dan uji konfirmasi).
+type DonorDeferral struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID              uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	DonationID          *uuid.UUID `gorm:"type:uuid;index" json:"donation_id"`
+	Reason              string     `gorm:"type:text;not null" json:"reason"`
+	DeferredUntil       *time.Time `gorm:"type:date" json:"deferred_until"`
+	FollowUpRequired    bool       `gorm:"not null;default:false" json:"follow_up_required"`
+	FollowUpCompletedAt *time.Time `json:"follow_up_completed_at"`
+	FollowUpNote        string     `gorm:"type:text" json:"follow_up_note"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (p *DonorDeferral) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// ActiveOn menandakan penundaan masih berlaku pada tanggal tertentu.
+func (p DonorDeferral) ActiveOn(day time.Time) bool {
+	return p.DeferredUntil == nil || day.Before(*p.DeferredUntil)
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DonationTypeWholeBlood = "whole_blood"
+	DonationTypeApheresis  = "apheresis"
+)
+
+// EligibilityPolicy adalah ambang kelayakan donor milik satu tenant, termasuk rentang pemeriksaan
+// pra-donor. Tenant yang belum mengatur kebijakan memakai DefaultEligibilityPolicy.
+type EligibilityPolicy struct {
+	ID                           uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID                     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"tenant_id"`
+	MinAge                       int       `gorm:"not null" json:"min_age"`
+	MaxAge                       int       `gorm:"not null" json:"max_age"`
+	MinWeightKg                  float64   `gorm:"type:decimal(5,2);not null" json:"min_weight_kg"`
+	WholeBloodIntervalMaleDays   int       `gorm:"not null" json:"whole_blood_interval_male_days"`
+	WholeBloodIntervalFemaleDays int       `gorm:"not null" json:"whole_blood_interval_female_days"`
+	ApheresisIntervalMaleDays    int       `gorm:"not null" json:"apheresis_interval_male_days"`
+	ApheresisIntervalFemaleDays  int       `gorm:"not null" json:"apheresis_interval_female_days"`
+	MinHaemoglobinMaleGdl        float64   `gorm:"type:decimal(4,1);not null;default:13.0" json:"min_haemoglobin_male_gdl"`
+	MinHaemoglobinFemaleGdl      float64   `gorm:"type:decimal(4,1);not null;default:12.5" json:"min_haemoglobin_female_gdl"`
+	MaxHaemoglobinGdl            float64   `gorm:"type:decimal(4,1);not null;default:17.0" json:"max_haemoglobin_gdl"`
+	MinSystolicMmHg              int       `gorm:"not null;default:110" json:"min_systolic_mmhg"`
+	MaxSystolicMmHg              int       `gorm:"not null;default:160" json:"max_systolic_mmhg"`
+	MinDiastolicMmHg             int       `gorm:"not null;default:70" json:"min_diastolic_mmhg"`
+	MaxDiastolicMmHg             int       `gorm:"not null;default:100" json:"max_diastolic_mmhg"`
+	MinPulseBpm                  int       `gorm:"not null;default:50" json:"min_pulse_bpm"`
+	MaxPulseBpm                  int       `gorm:"not null;default:100" json:"max_pulse_bpm"`
+	MinTemperatureC              float64   `gorm:"type:decimal(4,1);not null;default:36.0" json:"min_temperature_c"`
+	MaxTemperatureC              float64   `gorm:"type:decimal(4,1);not null;default:37.5" json:"max_temperature_c"`
+	CreatedAt                    time.Time `json:"created_at"`
+	UpdatedAt                    time.Time `json:"updated_at"`
+}
+
+func (p *EligibilityPolicy) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// DefaultEligibilityPolicy mengikuti ketentuan umum donor darah: usia 17–60 tahun, berat minimal 45 kg,
+// jarak donor darah lengkap 60 hari untuk laki-laki dan 90 hari untuk perempuan, serta 14 hari untuk aferesis.
+// Pemeriksaan pra-donor menerima Hb 13,0 (laki-laki) atau 12,5 (perempuan) sampai 17,0 g/dL, tekanan darah
+// 110–160/70–100 mmHg, nadi 50–100/menit, dan suhu 36–37,5 °C.
+func DefaultEligibilityPolicy(tenantID uuid.UUID) EligibilityPolicy {
+	return EligibilityPolicy{
+		TenantID:                     tenantID,
+		MinAge:                       17,
+		MaxAge:                       60,
+		MinWeightKg:                  45,
+		WholeBloodIntervalMaleDays:   60,
+		WholeBloodIntervalFemaleDays: 90,
+		ApheresisIntervalMaleDays:    14,
+		ApheresisIntervalFemaleDays:  14,
+		MinHaemoglobinMaleGdl:        13.0,
+		MinHaemoglobinFemaleGdl:      12.5,
+		MaxHaemoglobinGdl:            17.0,
+		MinSystolicMmHg:              110,
+		MaxSystolicMmHg:              160,
+		MinDiastolicMmHg:             70,
+		MaxDiastolicMmHg:             100,
+		MinPulseBpm:                  50,
+		MaxPulseBpm:                  100,
+		MinTemperatureC:              36.0,
+		MaxTemperatureC:              37.5,
+	}
+}
+
+// IntervalDays mengembalikan jarak minimal setelah donasi berjenis donationType untuk jenis kelamin
+// gender (L atau P). Jenis kelamin yang tidak diketahui memakai jarak terpanjang.
+func (p EligibilityPolicy) IntervalDays(gender, donationType string) int {
+	male, female := p.WholeBloodIntervalMaleDays, p.WholeBloodIntervalFemaleDays
+	if donationType == DonationTypeApheresis {
+		male, female = p.ApheresisIntervalMaleDays, p.ApheresisIntervalFemaleDays
+	}
+	switch gender {
+	case "L":
+		return male
+	case "P":
+		return female
+	}
+	return max(male, female)
+}
+
+// MinHaemoglobinGdl mengembalikan Hb minimal untuk jenis kelamin gender (L atau P).
+// Jenis kelamin yang tidak diketahui memakai batas tertinggi.
+func (p EligibilityPolicy) MinHaemoglobinGdl(gender string) float64 {
+	switch gender {
+	case "L":
+		return p.MinHaemoglobinMaleGdl
+	case "P":
+		return p.MinHaemoglobinFemaleGdl
+	}
+	return max(p.MinHaemoglobinMaleGdl, p.MinHaemoglobinFemaleGdl)
+}
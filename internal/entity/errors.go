@@ -0,0 +1,52 @@
+package entity
+
+import "errors"
+
+var (
+	ErrInsufficientStock        = errors.New("insufficient stock")
+	ErrStockInUse               = errors.New("stock already has blood units or ledger history")
+	ErrDonationNotCompleted     = errors.New("donation is not completed yet")
+	ErrDonationAlreadyProcessed = errors.New("donation has already been processed into components")
+	ErrUnknownBloodGroup        = errors.New("blood type and rhesus of the donor are unknown")
+	ErrDonorBloodGroupMismatch  = errors.New("blood group of the donor does not match the blood unit")
+	ErrInvalidTransferStatus    = errors.New("transfer cannot change to the requested status")
+	ErrSameTransferLocation     = errors.New("source and destination location must be different")
+	ErrInvalidReceivedQuantity  = errors.New("received quantity must not exceed dispatched quantity")
+	ErrInvalidStockThreshold    = errors.New("critical level must not exceed minimum level")
+	ErrVersionConflict          = errors.New("resource has been modified by another request")
+	ErrUnitNotUnderReview       = errors.New("blood unit is not flagged for review")
+	ErrStorageDeviceMismatch    = errors.New("storage device is not at the unit's location")
+	ErrStorageDeviceInUse       = errors.New("storage device still holds blood units")
+	ErrInvalidRequestStatus     = errors.New("blood request cannot change to the requested status")
+	ErrScreeningResultExists    = errors.New("screening result for this marker has already been entered")
+	ErrDuplicateScreeningMarker = errors.New("each screening marker may only appear once per submission")
+	ErrDonationReactive         = errors.New("donation has a reactive screening result")
+	ErrUnitInQuarantine         = errors.New("blood unit is in quarantine until screening is cleared")
+	ErrInvalidUnitStatus        = errors.New("blood unit cannot change to the requested status")
+	ErrUnitNotEditable          = errors.New("only available blood units can be edited")
+	ErrUnitNotDeletable         = errors.New("only quarantined or available blood units can be deleted")
+	ErrUnitLocationFixed        = errors.New("blood unit location can only change through a transfer")
+	ErrUnitDonationFixed        = errors.New("blood unit source donation cannot be changed")
+	ErrFollowUpNotRequired      = errors.New("deferral does not require follow-up or is already completed")
+	ErrInvalidBloodGroup        = errors.New("blood type must be one of A, B, AB, O and rhesus one of +, -")
+	ErrExceedsRequestQuantity   = errors.New("issued quantity exceeds the remaining requested quantity")
+	ErrRequestFullyReserved     = errors.New("remaining requested quantity is already reserved")
+	ErrRequestNotDeletable      = errors.New("only pending or cancelled blood requests without fulfillments can be deleted")
+	ErrTenantRequired           = errors.New("tenant_id is required for users without a tenant")
+	ErrStockCoversRequest       = errors.New("available stock already covers the blood request")
+	ErrLocationNotGeocoded      = errors.New("location has no coordinates")
+	ErrNoDonorsInRange          = errors.New("no eligible donors found within the call-up radius")
+	ErrDonorAlreadyArrived      = errors.New("donor arrival has already been recorded")
+	ErrForbidden                = errors.New("you are not allowed to modify this resource")
+	ErrDonorNotEligible         = errors.New("donor is not eligible to donate")
+	ErrInvalidEligibilityPolicy = errors.New("eligibility policy minimum must not exceed its maximum")
+	ErrInvalidQuestionnaire     = errors.New("questionnaire is invalid")
+	ErrInvalidQuestionAnswer    = errors.New("questionnaire answers are invalid")
+	ErrQuestionnaireSubmitted   = errors.New("questionnaire has already been submitted for this donation")
+	ErrDonationNotPending       = errors.New("donation is no longer pending")
+	ErrQuestionnaireMissing     = errors.New("pre-donation questionnaire has not been submitted")
+	ErrVitalSignsRecorded       = errors.New("vital signs have already been recorded for this donation")
+	ErrInvalidVitalSigns        = errors.New("systolic pressure must be higher than diastolic pressure")
+	ErrVitalSignsMissing        = errors.New("accepted vital signs have not been recorded for this donation")
+	ErrDonorDetailMissing       = errors.New("donor profile has not been filled in")
+)
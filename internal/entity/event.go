@@ -15,6 +15,9 @@ type Event struct {
 	StartDate   time.Time `gorm:"type:date" json:"start_date"`
 	EndDate     time.Time `gorm:"type:date" json:"end_date"`
 	LocationID  uuid.UUID `gorm:"type:uuid;index" json:"location_id"`
+	TenantID    uuid.UUID `gorm:"type:uuid;index" json:"tenant_id"`
+	CreatedBy   uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	Version     int       `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Hospital adalah rumah sakit pemohon darah yang dikelola oleh tenant.
+type Hospital struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
+	Address     string    `gorm:"type:text" json:"address"`
+	City        string    `gorm:"type:varchar(100)" json:"city"`
+	PhoneNumber string    `gorm:"type:varchar(20)" json:"phone_number"`
+	Version     int       `gorm:"not null;default:1" json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *Hospital) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
@@ -16,6 +16,7 @@ type Location struct {
 	City         string    `gorm:"type:varchar(100);not null" json:"city"`
 	Latitude     *float64  `gorm:"type:decimal(10,8)" json:"latitude"`
 	Longitude    *float64  `gorm:"type:decimal(11,8)" json:"longitude"`
+	Version      int       `gorm:"not null;default:1" json:"version"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
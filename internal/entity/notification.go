@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationBloodRequestOverdue = "blood_request_overdue" // permintaan darah melewati SLA
+	NotificationDonorCallUp         = "donor_call_up"         // panggilan donor untuk permintaan darah
+)
+
+// Notification adalah pemberitahuan untuk satu pengguna, misalnya eskalasi permintaan darah ke admin tenant.
+type Notification struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TenantID    *uuid.UUID `gorm:"type:uuid;index" json:"tenant_id"`
+	Type        string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
+	Message     string     `gorm:"type:text" json:"message"`
+	ReferenceID *uuid.UUID `gorm:"type:uuid" json:"reference_id"` // ID data yang dirujuk, mis. permintaan darah
+	ReadAt      *time.Time `json:"read_at"`
+	CreatedAt   time.Time  `gorm:"index" json:"created_at"`
+}
+
+func (p *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
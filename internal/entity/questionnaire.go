@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	QuestionTypeYesNo        = "yes_no"        // jawaban yes atau no
+	QuestionTypeSingleChoice = "single_choice" // satu jawaban dari Options
+	QuestionTypeNumber       = "number"
+	QuestionTypeText         = "text"
+	QuestionTypeDate         = "date" // format YYYY-MM-DD
+)
+
+// Questionnaire adalah satu versi kuesioner kesehatan pra-donor milik tenant. Versi tidak pernah
+// diubah setelah dibuat; perubahan pertanyaan diterbitkan sebagai versi baru dan versi tertinggi yang berlaku.
+type Questionnaire struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_questionnaire_version" json:"tenant_id"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_questionnaire_version" json:"version"`
+	Title     string    `gorm:"type:varchar(255);not null" json:"title"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Questions []QuestionnaireQuestion `gorm:"foreignKey:QuestionnaireID" json:"questions,omitempty"`
+}
+
+func (p *Questionnaire) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// QuestionnaireQuestion adalah satu pertanyaan kuesioner. Jawaban yang tercantum di DeferAnswers
+// menunda pendonor selama DeferDays hari sejak tanggal donasi; DeferDays kosong berarti penundaan permanen.
+type QuestionnaireQuestion struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	QuestionnaireID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_questionnaire_question" json:"questionnaire_id"`
+	Position        int       `gorm:"not null" json:"position"`
+	Code            string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_questionnaire_question" json:"code"` // mis. recent_tattoo
+	Text            string    `gorm:"type:text;not null" json:"text"`
+	Type            string    `gorm:"type:varchar(20);not null" json:"type"` // yes_no, single_choice, number, text, date
+	Required        bool      `gorm:"not null;default:true" json:"required"`
+	Options         []string  `gorm:"type:text;serializer:json" json:"options,omitempty"`
+	DeferAnswers    []string  `gorm:"type:text;serializer:json" json:"defer_answers,omitempty"`
+	DeferDays       *int      `json:"defer_days"`
+	DeferReason     string    `gorm:"type:text" json:"defer_reason"`
+}
+
+func (p *QuestionnaireQuestion) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// Defers menandakan jawaban value memicu penundaan otomatis.
+func (p QuestionnaireQuestion) Defers(value string) bool {
+	for _, answer := range p.DeferAnswers {
+		if answer == value {
+			return true
+		}
+	}
+	return false
+}
+
+// QuestionnaireSubmission adalah jawaban kuesioner pendonor untuk satu donasi.
+type QuestionnaireSubmission struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	DonationID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"donation_id"`
+	QuestionnaireID uuid.UUID `gorm:"type:uuid;not null;index" json:"questionnaire_id"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	SubmittedBy     uuid.UUID `gorm:"type:uuid;not null" json:"submitted_by"`
+	Deferred        bool      `gorm:"not null;default:false" json:"deferred"`
+	CreatedAt       time.Time `json:"created_at"`
+
+	Answers []QuestionnaireAnswer `gorm:"foreignKey:SubmissionID" json:"answers,omitempty"`
+}
+
+func (p *QuestionnaireSubmission) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// QuestionnaireAnswer adalah jawaban satu pertanyaan; Code disalin agar jawaban tetap terbaca tanpa memuat pertanyaannya.
+type QuestionnaireAnswer struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	SubmissionID uuid.UUID `gorm:"type:uuid;not null;index" json:"submission_id"`
+	QuestionID   uuid.UUID `gorm:"type:uuid;not null" json:"question_id"`
+	Code         string    `gorm:"type:varchar(50);not null" json:"code"`
+	Value        string    `gorm:"type:text" json:"value"`
+	Defers       bool      `gorm:"not null;default:false" json:"defers"`
+}
+
+func (p *QuestionnaireAnswer) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
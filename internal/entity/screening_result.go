@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ScreeningMarkerHIV      = "HIV"
+	ScreeningMarkerHBV      = "HBV"
+	ScreeningMarkerHCV      = "HCV"
+	ScreeningMarkerSyphilis = "SYPHILIS"
+)
+
+const (
+	ScreeningResultNegative = "negative" // non-reaktif
+	ScreeningResultReactive = "reactive" // reaktif, darah tidak boleh dipakai
+)
+
+const (
+	DonationScreeningPending  = "pending"  // hasil skrining belum lengkap, unit dikarantina
+	DonationScreeningCleared  = "cleared"  // semua hasil negatif
+	DonationScreeningReactive = "reactive" // minimal satu hasil reaktif
+)
+
+// ScreeningMarkers adalah uji IMLTD wajib sebelum darah boleh keluar dari karantina.
+var ScreeningMarkers = []string{ScreeningMarkerHIV, ScreeningMarkerHBV, ScreeningMarkerHCV, ScreeningMarkerSyphilis}
+
+// ScreeningResult adalah hasil uji saring infeksi menular lewat transfusi untuk satu donasi.
+// Setiap penanda hanya dicatat sekali per donasi.
+type ScreeningResult struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	DonationID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_screening_marker" json:"donation_id"`
+	Marker     string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_screening_marker" json:"marker"` // HIV, HBV, HCV, SYPHILIS
+	Result     string     `gorm:"type:varchar(20);not null" json:"result"`                                  // negative, reactive
+	Method     string     `gorm:"type:varchar(50)" json:"method"`
+	TestedAt   time.Time  `gorm:"not null" json:"tested_at"`
+	Note       string     `gorm:"type:text" json:"note"`
+	EnteredBy  *uuid.UUID `gorm:"type:uuid" json:"entered_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (p *ScreeningResult) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// ScreeningOutcome menentukan status skrining donasi: reactive bila ada satu hasil reaktif,
+// cleared bila semua penanda wajib negatif, selain itu pending.
+func ScreeningOutcome(results []ScreeningResult) string {
+	negative := map[string]bool{}
+	for _, result := range results {
+		if result.Result == ScreeningResultReactive {
+			return DonationScreeningReactive
+		}
+		negative[result.Marker] = true
+	}
+	for _, marker := range ScreeningMarkers {
+		if !negative[marker] {
+			return DonationScreeningPending
+		}
+	}
+	return DonationScreeningCleared
+}
@@ -11,8 +11,10 @@ type Stock struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
 	BloodType   string    `gorm:"type:varchar(2);not null;uniqueIndex:idx_stock_location" json:"blood_type"`
 	Rhesus      string    `gorm:"type:varchar(1);not null;uniqueIndex:idx_stock_location" json:"rhesus"`
+	Component   string    `gorm:"type:varchar(10);not null;default:'WB';uniqueIndex:idx_stock_location" json:"component"` // WB, PRC, FFP, TC, CRYO
 	BagQuantity int       `gorm:"not null" json:"bag_quantity"`
-	LocationID  uuid.UUID `gorm:"type:uuid;index" json:"location_id"`
+	LocationID  uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_stock_location" json:"location_id"`
+	Version     int       `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	StockAlertLevelLow      = "low"      // di bawah batas minimum
+	StockAlertLevelCritical = "critical" // di bawah batas kritis
+)
+
+// StockAlert dicatat setiap kali total stok turun melewati StockThreshold.
+type StockAlert struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	LocationID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"location_id"`
+	ThresholdID    uuid.UUID  `gorm:"type:uuid;not null" json:"threshold_id"`
+	BloodType      string     `gorm:"type:varchar(2);not null" json:"blood_type"`
+	Rhesus         string     `gorm:"type:varchar(1);not null" json:"rhesus"`
+	Level          string     `gorm:"type:varchar(10);not null;index" json:"level"`
+	Quantity       int        `gorm:"not null" json:"quantity"`  // total kantong setelah perubahan
+	Threshold      int        `gorm:"not null" json:"threshold"` // batas yang terlewati
+	AcknowledgedBy *uuid.UUID `gorm:"type:uuid" json:"acknowledged_by"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+func (p *StockAlert) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	StockMovementInbound      = "inbound"       // unit baru masuk dari donasi
+	StockMovementIssue        = "issue"         // dikeluarkan untuk permintaan darah
+	StockMovementTransferIn   = "transfer_in"   // diterima dari lokasi lain
+	StockMovementTransferOut  = "transfer_out"  // dikirim ke lokasi lain
+	StockMovementTransferLoss = "transfer_loss" // hilang atau rusak dalam perjalanan transfer
+	StockMovementAdjustment   = "adjustment"    // koreksi manual
+	StockMovementExpiry       = "expiry"        // kedaluwarsa
+	StockMovementDiscard      = "discard"       // dimusnahkan
+	StockMovementReserve      = "reserve"       // ditahan untuk permintaan darah
+	StockMovementRelease      = "release"       // tahanan dilepas kembali ke stok
+)
+
+// StockMovement adalah catatan append-only setiap perubahan Stock.BagQuantity.
+// Baris ledger tidak pernah diubah atau dihapus.
+type StockMovement struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	StockID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"stock_id"`
+	Type         string     `gorm:"type:varchar(20);not null;index" json:"type"`
+	Quantity     int        `gorm:"not null" json:"quantity"`      // perubahan, positif atau negatif
+	BalanceAfter int        `gorm:"not null" json:"balance_after"` // BagQuantity setelah perubahan
+	Reason       string     `gorm:"type:text" json:"reason"`
+	ReferenceID  *uuid.UUID `gorm:"type:uuid;index" json:"reference_id"` // unit, permintaan, atau transfer terkait
+	CreatedBy    *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+}
+
+func (p *StockMovement) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// NewStockMovement menyiapkan template ledger; StockID, Quantity, dan BalanceAfter diisi oleh repository.
+func NewStockMovement(movementType, reason string, actorID uuid.UUID, referenceID *uuid.UUID) StockMovement {
+	movement := StockMovement{
+		Type:        movementType,
+		Reason:      reason,
+		ReferenceID: referenceID,
+	}
+	if actorID != uuid.Nil {
+		movement.CreatedBy = &actorID
+	}
+	return movement
+}
+
+// StockMovementTypeForStatus memetakan status unit ke jenis pergerakan stok.
+func StockMovementTypeForStatus(status string) string {
+	switch status {
+	case BloodUnitStatusIssued:
+		return StockMovementIssue
+	case BloodUnitStatusExpired:
+		return StockMovementExpiry
+	case BloodUnitStatusDiscarded:
+		return StockMovementDiscard
+	}
+	return StockMovementAdjustment
+}
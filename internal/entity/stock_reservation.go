@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	StockReservationActive    = "active"    // kantong sedang ditahan untuk permintaan
+	StockReservationReleased  = "released"  // dilepas karena permintaan dibatalkan atau diubah
+	StockReservationExpired   = "expired"   // dilepas otomatis karena melewati batas waktu
+	StockReservationFulfilled = "fulfilled" // kantong sudah dikeluarkan untuk permintaan
+)
+
+// StockReservation menahan satu kantong darah untuk sebuah BloodRequest selama staf menyiapkan kantong.
+// Unit yang ditahan berstatus reserved sehingga tidak dihitung di Stock.BagQuantity.
+type StockReservation struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	BloodRequestID uuid.UUID  `gorm:"type:uuid;not null;index" json:"blood_request_id"`
+	BloodUnitID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"blood_unit_id"`
+	BagNumber      string     `gorm:"type:varchar(50);not null" json:"bag_number"`
+	LocationID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"location_id"`
+	BloodType      string     `gorm:"type:varchar(2);not null" json:"blood_type"`
+	Rhesus         string     `gorm:"type:varchar(1);not null" json:"rhesus"`
+	Component      string     `gorm:"type:varchar(10);not null" json:"component"`
+	Status         string     `gorm:"type:varchar(20);not null;index" json:"status"` // active, released, expired, fulfilled
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	ReleasedAt     *time.Time `json:"released_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (p *StockReservation) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
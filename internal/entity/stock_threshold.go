@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockThreshold adalah batas minimum dan kritis stok per lokasi, golongan, dan rhesus.
+// Jumlah yang dibandingkan adalah total kantong semua komponen.
+type StockThreshold struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID      uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	LocationID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_stock_threshold" json:"location_id"`
+	BloodType     string    `gorm:"type:varchar(2);not null;uniqueIndex:idx_stock_threshold" json:"blood_type"`
+	Rhesus        string    `gorm:"type:varchar(1);not null;uniqueIndex:idx_stock_threshold" json:"rhesus"`
+	MinimumLevel  int       `gorm:"not null" json:"minimum_level"`
+	CriticalLevel int       `gorm:"not null" json:"critical_level"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (p *StockThreshold) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// LevelCrossed mengembalikan level alert bila perubahan dari before ke after
+// turun melewati batas kritis atau minimum, atau string kosong bila tidak.
+func (p StockThreshold) LevelCrossed(before, after int) string {
+	switch {
+	case after < p.CriticalLevel && before >= p.CriticalLevel:
+		return StockAlertLevelCritical
+	case after < p.MinimumLevel && before >= p.MinimumLevel:
+		return StockAlertLevelLow
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	StorageDeviceRefrigerator     = "refrigerator"
+	StorageDeviceFreezer          = "freezer"
+	StorageDevicePlateletAgitator = "platelet_agitator"
+)
+
+// StorageDeviceClass menyimpan rentang suhu yang diizinkan untuk tiap jenis alat simpan.
+type StorageDeviceClass struct {
+	Name           string
+	MinTemperature float64
+	MaxTemperature float64
+}
+
+var StorageDeviceClasses = map[string]StorageDeviceClass{
+	StorageDeviceRefrigerator:     {Name: "Blood Bank Refrigerator", MinTemperature: 2, MaxTemperature: 6},
+	StorageDeviceFreezer:          {Name: "Plasma Freezer", MinTemperature: -80, MaxTemperature: -25},
+	StorageDevicePlateletAgitator: {Name: "Platelet Agitator", MinTemperature: 20, MaxTemperature: 24},
+}
+
+// StorageDevice adalah alat simpan darah (kulkas, freezer, agitator trombosit) di sebuah lokasi.
+type StorageDevice struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID     uuid.UUID `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	LocationID   uuid.UUID `gorm:"type:uuid;not null;index" json:"location_id"`
+	Name         string    `gorm:"type:varchar(255);not null" json:"name"`
+	DeviceClass  string    `gorm:"type:varchar(30);not null" json:"device_class"` // refrigerator, freezer, platelet_agitator
+	SerialNumber string    `gorm:"type:varchar(100)" json:"serial_number"`
+	Active       bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (p *StorageDevice) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// AllowedRange mengembalikan rentang suhu yang diizinkan sesuai jenis alat.
+func (p StorageDevice) AllowedRange() (float64, float64) {
+	class := StorageDeviceClasses[p.DeviceClass]
+	return class.MinTemperature, class.MaxTemperature
+}
+
+// InRange menandakan suhu masih berada dalam rentang yang diizinkan.
+func (p StorageDevice) InRange(temperature float64) bool {
+	min, max := p.AllowedRange()
+	return temperature >= min && temperature <= max
+}
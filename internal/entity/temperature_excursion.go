@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	TemperatureExcursionOpen   = "open"
+	TemperatureExcursionClosed = "closed"
+)
+
+// TemperatureExcursion mencatat periode ketika suhu alat simpan keluar dari rentang yang diizinkan.
+// Excursion dibuka oleh bacaan pertama di luar rentang dan ditutup oleh bacaan normal berikutnya.
+type TemperatureExcursion struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	TenantID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	LocationID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"location_id"`
+	DeviceID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"device_id"`
+	Status             string     `gorm:"type:varchar(10);not null;index" json:"status"` // open, closed
+	StartedAt          time.Time  `gorm:"not null" json:"started_at"`
+	EndedAt            *time.Time `json:"ended_at"`
+	MinAllowed         float64    `gorm:"type:decimal(5,2)" json:"min_allowed"`
+	MaxAllowed         float64    `gorm:"type:decimal(5,2)" json:"max_allowed"`
+	LowestTemperature  float64    `gorm:"type:decimal(5,2)" json:"lowest_temperature"`
+	HighestTemperature float64    `gorm:"type:decimal(5,2)" json:"highest_temperature"`
+	ReadingCount       int        `gorm:"not null;default:0" json:"reading_count"`
+	FlaggedUnits       int        `gorm:"not null;default:0" json:"flagged_units"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func (p *TemperatureExcursion) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// Record memasukkan satu bacaan di luar rentang ke dalam excursion.
+func (p *TemperatureExcursion) Record(temperature float64) {
+	if p.ReadingCount == 0 || temperature < p.LowestTemperature {
+		p.LowestTemperature = temperature
+	}
+	if p.ReadingCount == 0 || temperature > p.HighestTemperature {
+		p.HighestTemperature = temperature
+	}
+	p.ReadingCount++
+}
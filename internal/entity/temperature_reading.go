@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TemperatureReading adalah satu catatan suhu periodik dari alat simpan.
+type TemperatureReading struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	DeviceID    uuid.UUID `gorm:"type:uuid;not null;index:idx_reading_device_time" json:"device_id"`
+	Temperature float64   `gorm:"type:decimal(5,2);not null" json:"temperature"`
+	RecordedAt  time.Time `gorm:"not null;index:idx_reading_device_time" json:"recorded_at"`
+	OutOfRange  bool      `gorm:"not null;default:false" json:"out_of_range"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (p *TemperatureReading) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
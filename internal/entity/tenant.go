@@ -11,6 +11,7 @@ type Tenant struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey" `
 	Name      string    `gorm:"not null" `
 	Slug      string    `gorm:"uniqueIndex;not null" `
+	Version   int       `gorm:"not null;default:1"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
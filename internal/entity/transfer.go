@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	TransferStatusDispatched = "dispatched"
+	TransferStatusInTransit  = "in_transit"
+	TransferStatusReceived   = "received"
+	TransferStatusRejected   = "rejected"
+)
+
+// Transfer adalah pengiriman kantong darah antar lokasi.
+// Alur status: dispatched -> in_transit -> received / rejected. Kantong yang dikirim dicatat di TransferUnit
+// dan berstatus in_transit sejak dispatch sampai diterima atau dikembalikan ke lokasi asal.
+type Transfer struct {
+	ID                    uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	SourceLocationID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"source_location_id"`
+	DestinationLocationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"destination_location_id"`
+	BloodType             string     `gorm:"type:varchar(2);not null" json:"blood_type"`
+	Rhesus                string     `gorm:"type:varchar(1);not null" json:"rhesus"`
+	Component             string     `gorm:"type:varchar(10);not null;default:'WB'" json:"component"`
+	Quantity              int        `gorm:"not null" json:"quantity"`                    // jumlah kantong yang dikirim
+	ReceivedQuantity      int        `gorm:"not null;default:0" json:"received_quantity"` // jumlah kantong yang diterima utuh
+	Status                string     `gorm:"type:varchar(20);not null;default:'dispatched';index" json:"status"`
+	Note                  string     `gorm:"type:text" json:"note"`
+	ReceiptNote           string     `gorm:"type:text" json:"receipt_note"`
+	DispatchedBy          *uuid.UUID `gorm:"type:uuid" json:"dispatched_by"`
+	ReceivedBy            *uuid.UUID `gorm:"type:uuid" json:"received_by"`
+	DispatchedAt          time.Time  `json:"dispatched_at"`
+	ShippedAt             *time.Time `json:"shipped_at"`
+	ReceivedAt            *time.Time `json:"received_at"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+func (p *Transfer) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// TransferUnit adalah kantong yang dikunci untuk sebuah transfer saat dispatch.
+type TransferUnit struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TransferID  uuid.UUID `gorm:"type:uuid;not null;index" json:"transfer_id"`
+	BloodUnitID uuid.UUID `gorm:"type:uuid;not null;index" json:"blood_unit_id"`
+	BagNumber   string    `gorm:"type:varchar(50);not null" json:"bag_number"`
+}
+
+func (p *TransferUnit) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
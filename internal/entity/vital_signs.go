@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	VitalSignsAccepted  = "accepted"  // semua hasil dalam rentang, donasi dapat dilanjutkan
+	VitalSignsCancelled = "cancelled" // donasi dibatalkan, pendonor boleh kembali di hari lain
+	VitalSignsDeferred  = "deferred"  // donasi dibatalkan dan pendonor ditunda sementara
+)
+
+// Lama penundaan pendonor bila Hb atau suhu di luar rentang kebijakan kelayakan; tekanan darah,
+// nadi, dan berat badan di luar rentang hanya membatalkan donasi.
+const (
+	HaemoglobinDeferDays = 14
+	TemperatureDeferDays = 7
+)
+
+// VitalSigns adalah pemeriksaan fisik pendonor di lokasi sebelum darah diambil. Setiap donasi
+// hanya memiliki satu pemeriksaan.
+type VitalSigns struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	DonationID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"donation_id"`
+	HaemoglobinGdl float64   `gorm:"type:decimal(4,1);not null" json:"haemoglobin_gdl"`
+	SystolicMmHg   int       `gorm:"not null" json:"systolic_mmhg"`
+	DiastolicMmHg  int       `gorm:"not null" json:"diastolic_mmhg"`
+	PulseBpm       int       `gorm:"not null" json:"pulse_bpm"`
+	TemperatureC   float64   `gorm:"type:decimal(4,1);not null" json:"temperature_c"`
+	WeightKg       float64   `gorm:"type:decimal(5,2);not null" json:"weight_kg"`
+	Outcome        string    `gorm:"type:varchar(20);not null" json:"outcome"` // accepted, cancelled, deferred
+	Reason         string    `gorm:"type:text" json:"reason"`
+	MeasuredBy     uuid.UUID `gorm:"type:uuid;not null" json:"measured_by"`
+	MeasuredAt     time.Time `gorm:"not null" json:"measured_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (p *VitalSigns) BeforeCreate(tx *gorm.DB) (err error) {
+	p.ID = uuid.New()
+	return
+}
+
+// Findings mengembalikan temuan di luar rentang kebijakan kelayakan tenant dan lama penundaan
+// terpanjang yang dipicunya. Batas bawah Hb mengikuti jenis kelamin pendonor (L atau P).
+func (p VitalSigns) Findings(policy EligibilityPolicy, gender string) (findings []string, deferDays int) {
+	minHaemoglobin := policy.MinHaemoglobinGdl(gender)
+	if p.HaemoglobinGdl < minHaemoglobin || p.HaemoglobinGdl > policy.MaxHaemoglobinGdl {
+		findings = append(findings, fmt.Sprintf("Hb %.1f g/dL di luar rentang %.1f–%.1f", p.HaemoglobinGdl, minHaemoglobin, policy.MaxHaemoglobinGdl))
+		deferDays = max(deferDays, HaemoglobinDeferDays)
+	}
+	if p.SystolicMmHg < policy.MinSystolicMmHg || p.SystolicMmHg > policy.MaxSystolicMmHg ||
+		p.DiastolicMmHg < policy.MinDiastolicMmHg || p.DiastolicMmHg > policy.MaxDiastolicMmHg {
+		findings = append(findings, fmt.Sprintf("tekanan darah %d/%d mmHg di luar rentang %d–%d/%d–%d",
+			p.SystolicMmHg, p.DiastolicMmHg, policy.MinSystolicMmHg, policy.MaxSystolicMmHg, policy.MinDiastolicMmHg, policy.MaxDiastolicMmHg))
+	}
+	if p.PulseBpm < policy.MinPulseBpm || p.PulseBpm > policy.MaxPulseBpm {
+		findings = append(findings, fmt.Sprintf("nadi %d/menit di luar rentang %d–%d", p.PulseBpm, policy.MinPulseBpm, policy.MaxPulseBpm))
+	}
+	if p.TemperatureC < policy.MinTemperatureC || p.TemperatureC > policy.MaxTemperatureC {
+		findings = append(findings, fmt.Sprintf("suhu %.1f °C di luar rentang %.1f–%.1f", p.TemperatureC, policy.MinTemperatureC, policy.MaxTemperatureC))
+		deferDays = max(deferDays, TemperatureDeferDays)
+	}
+	if p.WeightKg < policy.MinWeightKg {
+		findings = append(findings, fmt.Sprintf("berat badan %.1f kg di bawah minimal %.1f kg", p.WeightKg, policy.MinWeightKg))
+	}
+	return findings, deferDays
+}
@@ -0,0 +1,180 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/pkg/compatibility"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type bloodRequestFulfillmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBloodRequestFulfillmentRepository(db *gorm.DB) repository.BloodRequestFulfillmentRepository {
+	return &bloodRequestFulfillmentRepositoryImpl{db: db}
+}
+
+// Issue mendahulukan kantong yang sudah ditahan untuk permintaan di lokasi penyerahan, lalu
+// melengkapi sisanya dari kantong available dengan kedaluwarsa terdekat (FEFO). Tahanan atas
+// kantong yang ditandai review excursion suhu dilepas lebih dulu dan tidak pernah dikeluarkan;
+// tahanan atas kantong yang sudah kedaluwarsa ditutup sebagai expired tanpa menunggu sweep.
+// Status permintaan menjadi fulfilled bila jumlah terpenuhi mencapai Quantity, selain itu partially_fulfilled.
+// Permintaan yang terpenuhi melepas tahanan sisanya di lokasi lain dengan movement release.
+func (r *bloodRequestFulfillmentRepositoryImpl) Issue(ctx context.Context, bloodRequest entity.BloodRequest, fulfillment *entity.BloodRequestFulfillment, today time.Time, movement, release entity.StockMovement) (entity.BloodRequest, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.BloodRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, bloodRequest.ID).Error; err != nil {
+			return err
+		}
+		if current.Version != bloodRequest.Version {
+			return entity.ErrVersionConflict
+		}
+		if fulfillment.Quantity > current.Quantity-current.FulfilledQuantity {
+			return entity.ErrExceedsRequestQuantity
+		}
+
+		var flagged []entity.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("blood_request_id = ? AND location_id = ? AND status = ?",
+				current.ID, fulfillment.LocationID, entity.StockReservationActive).
+			Where("blood_unit_id IN (SELECT id FROM blood_units WHERE status = ? AND needs_review = ?)", entity.BloodUnitStatusReserved, true).
+			Find(&flagged).Error; err != nil {
+			return err
+		}
+		if _, err := releaseReservationRows(tx, current.ID, flagged, entity.StockReservationReleased, release); err != nil {
+			return err
+		}
+
+		var expired []entity.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("blood_request_id = ? AND location_id = ? AND status = ?",
+				current.ID, fulfillment.LocationID, entity.StockReservationActive).
+			Where("blood_unit_id IN (SELECT id FROM blood_units WHERE status = ? AND expiry_date < ?)", entity.BloodUnitStatusReserved, today).
+			Find(&expired).Error; err != nil {
+			return err
+		}
+		if err := expireReservationRows(tx, expired); err != nil {
+			return err
+		}
+
+		var reservations []entity.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("blood_request_id = ? AND location_id = ? AND status = ?",
+				current.ID, fulfillment.LocationID, entity.StockReservationActive).
+			Where("blood_unit_id IN (SELECT id FROM blood_units WHERE status = ? AND expiry_date >= ? AND needs_review = ?)",
+				entity.BloodUnitStatusReserved, today, false).
+			Order("expires_at ASC").
+			Limit(fulfillment.Quantity).
+			Find(&reservations).Error; err != nil {
+			return err
+		}
+		if len(reservations) > 0 {
+			if err := tx.Model(&entity.StockReservation{}).Where("id IN ?", stockReservationIDs(reservations)).
+				Update("status", entity.StockReservationFulfilled).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", reservedUnitIDs(reservations)).
+				Update("status", entity.BloodUnitStatusIssued).Error; err != nil {
+				return err
+			}
+			for _, reservation := range reservations {
+				fulfillment.Units = append(fulfillment.Units, entity.BloodRequestFulfillmentUnit{
+					BloodUnitID: reservation.BloodUnitID,
+					BagNumber:   reservation.BagNumber,
+					BloodType:   reservation.BloodType,
+					Rhesus:      reservation.Rhesus,
+					Component:   reservation.Component,
+					FromReserve: true,
+				})
+			}
+		}
+
+		// Kantong yang ditahan sudah dikurangi dari stok; hanya sisanya yang mengurangi BagQuantity.
+		remaining := fulfillment.Quantity - len(reservations)
+		if remaining > 0 {
+			var units []entity.BloodUnit
+			recipient := compatibility.BloodGroup{BloodType: current.BloodType, Rhesus: current.Rhesus}
+			err := whereCompatible(tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}), current.Component, recipient).
+				Where("location_id = ?", fulfillment.LocationID).
+				Where("status = ? AND expiry_date >= ? AND needs_review = ?", entity.BloodUnitStatusAvailable, today, false).
+				Order("expiry_date ASC, collection_date ASC").
+				Limit(remaining).
+				Find(&units).Error
+			if err != nil {
+				return err
+			}
+			if len(units) < remaining {
+				return entity.ErrInsufficientStock
+			}
+
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+				Update("status", entity.BloodUnitStatusIssued).Error; err != nil {
+				return err
+			}
+			for _, unit := range units {
+				fulfillment.Units = append(fulfillment.Units, entity.BloodRequestFulfillmentUnit{
+					BloodUnitID: unit.ID,
+					BagNumber:   unit.BagNumber,
+					BloodType:   unit.BloodType,
+					Rhesus:      unit.Rhesus,
+					Component:   unit.Component,
+				})
+			}
+
+			movement = withMovementDefaults(movement, entity.StockMovementIssue, current.ID)
+			if err := adjustStockByGroup(tx, units, -1, movement); err != nil {
+				return err
+			}
+		}
+
+		fulfillment.BloodRequestID = current.ID
+		if err := tx.Create(fulfillment).Error; err != nil {
+			return err
+		}
+
+		history := entity.BloodRequestStatusHistory{
+			BloodRequestID: current.ID,
+			FromStatus:     current.Status,
+			ToStatus:       entity.BloodRequestStatusPartiallyFulfilled,
+			Note:           fulfillment.Note,
+			ChangedBy:      &fulfillment.IssuedBy,
+		}
+		current.FulfilledQuantity += fulfillment.Quantity
+		if current.FulfilledQuantity >= current.Quantity {
+			history.ToStatus = entity.BloodRequestStatusFulfilled
+		}
+		current.Status = history.ToStatus
+
+		version := current.Version
+		current.Version++
+		if err := updateVersioned(tx, &current, version); err != nil {
+			return err
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		if !current.IsOpen() {
+			if _, err := releaseReservations(tx, current.ID, entity.StockReservationReleased, release); err != nil {
+				return err
+			}
+		}
+
+		bloodRequest = current
+		return nil
+	})
+	return bloodRequest, err
+}
+
+func (r *bloodRequestFulfillmentRepositoryImpl) FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.BloodRequestFulfillment, error) {
+	var fulfillments []entity.BloodRequestFulfillment
+	err := r.db.WithContext(ctx).Preload("Units").
+		Where("blood_request_id = ?", bloodRequestID).
+		Order("issued_at ASC").Find(&fulfillments).Error
+	return fulfillments, err
+}
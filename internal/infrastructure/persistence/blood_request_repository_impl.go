@@ -4,9 +4,11 @@ import (
   "context"
   "donor-api/internal/entity"
   "donor-api/internal/repository"
+  "time"
 
   "github.com/google/uuid"
   "gorm.io/gorm"
+  "gorm.io/gorm/clause"
 )
 
 type bloodRequestRepositoryImpl struct {
@@ -21,15 +23,16 @@ func (r *bloodRequestRepositoryImpl) Save(ctx context.Context, bloodRequest *ent
   return r.db.WithContext(ctx).Create(bloodRequest).Error
 }
 
-func (r *bloodRequestRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]entity.BloodRequest, int64, error) {
+func (r *bloodRequestRepositoryImpl) FindAll(ctx context.Context, filter repository.BloodRequestFilter, limit, offset int) ([]entity.BloodRequest, int64, error) {
   var bloodRequests []entity.BloodRequest
   var total int64
 
-  if err := r.db.WithContext(ctx).Model(&entity.BloodRequest{}).Count(&total).Error; err != nil {
+  query := applyBloodRequestFilter(r.db.WithContext(ctx).Model(&entity.BloodRequest{}), filter)
+  if err := query.Count(&total).Error; err != nil {
     return nil, 0, err
   }
 
-  if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&bloodRequests).Error; err != nil {
+  if err := query.Limit(limit).Offset(offset).Find(&bloodRequests).Error; err != nil {
     return nil, 0, err
   }
 
@@ -43,12 +46,168 @@ func (r *bloodRequestRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID)
   return bloodRequest, err
 }
 
+func (r *bloodRequestRepositoryImpl) FindQueue(ctx context.Context, filter repository.BloodRequestFilter, limit, offset int) ([]entity.BloodRequest, int64, error) {
+  var bloodRequests []entity.BloodRequest
+  var total int64
+
+  query := applyBloodRequestFilter(r.db.WithContext(ctx).Model(&entity.BloodRequest{}), filter).
+    Where("status IN ?", entity.BloodRequestOpenStatuses)
+  if err := query.Count(&total).Error; err != nil {
+    return nil, 0, err
+  }
+
+  urgencyOrder := clause.Expr{
+    SQL:  "CASE urgency WHEN ? THEN 0 WHEN ? THEN 1 ELSE 2 END",
+    Vars: []interface{}{entity.BloodRequestUrgencyEmergency, entity.BloodRequestUrgencyUrgent},
+  }
+  err := query.Clauses(clause.OrderBy{Expression: urgencyOrder}).Order("created_at ASC").
+    Limit(limit).Offset(offset).Find(&bloodRequests).Error
+  if err != nil {
+    return nil, 0, err
+  }
+
+  return bloodRequests, total, nil
+}
+
+func (r *bloodRequestRepositoryImpl) FindPublicFeed(ctx context.Context, filter repository.PublicFeedFilter, limit, offset int) ([]repository.PublicBloodRequest, int64, error) {
+  var rows []repository.PublicBloodRequest
+  var total int64
+
+  query := r.db.WithContext(ctx).Table("blood_requests").
+    Joins("JOIN locations ON locations.id = blood_requests.location_id").
+    Where("blood_requests.status IN ? AND blood_requests.quantity > blood_requests.fulfilled_quantity", entity.BloodRequestOpenStatuses)
+  if filter.City != "" {
+    query = query.Where("LOWER(locations.city) = LOWER(?)", filter.City)
+  }
+  if filter.BloodType != "" {
+    query = query.Where("blood_requests.blood_type = ?", filter.BloodType)
+  }
+  if err := query.Count(&total).Error; err != nil {
+    return nil, 0, err
+  }
+
+  urgencyOrder := clause.Expr{
+    SQL:  "CASE blood_requests.urgency WHEN ? THEN 0 WHEN ? THEN 1 ELSE 2 END",
+    Vars: []interface{}{entity.BloodRequestUrgencyEmergency, entity.BloodRequestUrgencyUrgent},
+  }
+  err := query.
+    Select("blood_requests.blood_type, blood_requests.rhesus, blood_requests.quantity - blood_requests.fulfilled_quantity AS quantity, locations.city, locations.location_name, blood_requests.urgency").
+    Clauses(clause.OrderBy{Expression: urgencyOrder}).Order("blood_requests.created_at ASC").
+    Limit(limit).Offset(offset).Scan(&rows).Error
+  if err != nil {
+    return nil, 0, err
+  }
+
+  return rows, total, nil
+}
+
+func (r *bloodRequestRepositoryImpl) FindUnescalated(ctx context.Context, createdBefore time.Time) ([]entity.BloodRequest, error) {
+  var bloodRequests []entity.BloodRequest
+  err := r.db.WithContext(ctx).
+    Where("status IN ? AND escalated_at IS NULL AND created_at < ?", entity.BloodRequestOpenStatuses, createdBefore).
+    Order("created_at ASC").Find(&bloodRequests).Error
+  return bloodRequests, err
+}
+
+// Escalate hanya berhasil sekali per permintaan; false berarti permintaan sudah dieskalasi proses lain.
+// escalated_at diubah tanpa menaikkan versi agar tidak mengganggu If-Match milik petugas.
+func (r *bloodRequestRepositoryImpl) Escalate(ctx context.Context, bloodRequest entity.BloodRequest, notifications []entity.Notification) (bool, error) {
+  escalated := false
+  err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+    result := tx.Model(&entity.BloodRequest{}).
+      Where("id = ? AND escalated_at IS NULL", bloodRequest.ID).
+      UpdateColumn("escalated_at", time.Now())
+    if result.Error != nil || result.RowsAffected == 0 {
+      return result.Error
+    }
+
+    escalated = true
+    if len(notifications) == 0 {
+      return nil
+    }
+    return tx.Create(&notifications).Error
+  })
+  return escalated, err
+}
+
 func (r *bloodRequestRepositoryImpl) Update(ctx context.Context, bloodRequest entity.BloodRequest) (entity.BloodRequest, error) {
-  err := r.db.WithContext(ctx).Save(&bloodRequest).Error
+  version := bloodRequest.Version
+  bloodRequest.Version++
+  err := updateVersioned(r.db.WithContext(ctx), &bloodRequest, version)
+  return bloodRequest, err
+}
+
+// UpdateStatus menyimpan status baru dan riwayat perpindahannya dalam satu transaksi.
+// Bila status baru menutup permintaan, tahanannya ikut dilepas kembali ke stok di transaksi yang sama.
+func (r *bloodRequestRepositoryImpl) UpdateStatus(ctx context.Context, bloodRequest entity.BloodRequest, history entity.BloodRequestStatusHistory, release entity.StockMovement) (entity.BloodRequest, error) {
+  err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+    version := bloodRequest.Version
+    bloodRequest.Version++
+    if err := updateVersioned(tx, &bloodRequest, version); err != nil {
+      return err
+    }
+
+    history.BloodRequestID = bloodRequest.ID
+    if err := tx.Create(&history).Error; err != nil {
+      return err
+    }
+    if bloodRequest.IsOpen() {
+      return nil
+    }
+    _, err := releaseReservations(tx, bloodRequest.ID, entity.StockReservationReleased, release)
+    return err
+  })
   return bloodRequest, err
 }
 
-func (r *bloodRequestRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-  // GORM dapat menghapus berdasarkan primary key secara langsung.
-  return r.db.WithContext(ctx).Delete(&entity.BloodRequest{}, id).Error
+func (r *bloodRequestRepositoryImpl) FindStatusHistory(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.BloodRequestStatusHistory, error) {
+  var histories []entity.BloodRequestStatusHistory
+  err := r.db.WithContext(ctx).Where("blood_request_id = ?", bloodRequestID).
+    Order("created_at ASC").Find(&histories).Error
+  return histories, err
+}
+
+func applyBloodRequestFilter(query *gorm.DB, filter repository.BloodRequestFilter) *gorm.DB {
+  if filter.TenantID != uuid.Nil {
+    query = query.Where("tenant_id = ?", filter.TenantID)
+  }
+  if filter.CreatedBy != uuid.Nil {
+    query = query.Where("created_by = ?", filter.CreatedBy)
+  }
+  return query
+}
+
+// Delete mengunci permintaan dan hanya menghapusnya bila masih pending atau sudah dibatalkan tanpa
+// penyerahan. Tahanan aktif dikembalikan ke stok, lalu riwayat status dan baris tahanan yang hanya
+// milik permintaan ini ikut dihapus di transaksi yang sama.
+func (r *bloodRequestRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, release entity.StockMovement) error {
+  return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+    var current entity.BloodRequest
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, id).Error; err != nil {
+      return err
+    }
+    if !current.IsDeletable() {
+      return entity.ErrRequestNotDeletable
+    }
+
+    var fulfillments int64
+    if err := tx.Model(&entity.BloodRequestFulfillment{}).Where("blood_request_id = ?", id).
+      Count(&fulfillments).Error; err != nil {
+      return err
+    }
+    if fulfillments > 0 {
+      return entity.ErrRequestNotDeletable
+    }
+
+    if _, err := releaseReservations(tx, id, entity.StockReservationReleased, release); err != nil {
+      return err
+    }
+    if err := tx.Where("blood_request_id = ?", id).Delete(&entity.BloodRequestStatusHistory{}).Error; err != nil {
+      return err
+    }
+    if err := tx.Where("blood_request_id = ?", id).Delete(&entity.StockReservation{}).Error; err != nil {
+      return err
+    }
+    return tx.Delete(&entity.BloodRequest{}, id).Error
+  })
 }
@@ -0,0 +1,235 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type bloodUnitRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBloodUnitRepository(db *gorm.DB) repository.BloodUnitRepository {
+	return &bloodUnitRepositoryImpl{db: db}
+}
+
+// Save menyimpan unit baru dan menambah stok terkait dalam satu transaksi. Donasi asal dikunci dan
+// ditolak bila belum selesai atau sudah pernah menjadi unit, sama seperti SaveDonationComponents.
+func (r *bloodUnitRepositoryImpl) Save(ctx context.Context, unit *entity.BloodUnit, movement entity.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if unit.DonationID == nil {
+			return entity.ErrDonationNotCompleted
+		}
+		if _, err := lockUnprocessedDonation(tx, *unit.DonationID); err != nil {
+			return err
+		}
+		return createBloodUnit(tx, unit, movement)
+	})
+}
+
+// SaveDonationComponents menyimpan unit-unit komponen hasil pemisahan satu donasi. Baris donasi dikunci
+// selama transaksi sehingga dua permintaan pemrosesan yang bersamaan tidak bisa menghasilkan unit ganda.
+func (r *bloodUnitRepositoryImpl) SaveDonationComponents(ctx context.Context, donationID uuid.UUID, units []entity.BloodUnit, movement entity.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockUnprocessedDonation(tx, donationID); err != nil {
+			return err
+		}
+
+		for i := range units {
+			if err := createBloodUnit(tx, &units[i], movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *bloodUnitRepositoryImpl) FindAll(ctx context.Context, filter repository.BloodUnitFilter, limit, offset int) ([]entity.BloodUnit, int64, error) {
+	var units []entity.BloodUnit
+	var total int64
+
+	query := applyBloodUnitFilter(r.db.WithContext(ctx).Model(&entity.BloodUnit{}), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("expiry_date ASC").Limit(limit).Offset(offset).Find(&units).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return units, total, nil
+}
+
+func (r *bloodUnitRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.BloodUnit, error) {
+	var unit entity.BloodUnit
+	err := r.db.WithContext(ctx).First(&unit, id).Error
+	return unit, err
+}
+
+// Update menerapkan perubahan unit pada baris yang dikunci. Hanya data kantong, alat simpan, status,
+// dan tanda review yang disalin dari after; lokasi dan donasi asal tidak pernah berubah di sini.
+// Bila status, tanda review, atau lokasi unit berubah sejak before dibaca, perubahan ditolak dengan
+// ErrVersionConflict. Jika status atau golongan unit berubah, stok lama dikurangi dan stok baru
+// ditambah di transaksi yang sama. Unit yang dimusnahkan atau kedaluwarsa juga menutup tahanan aktifnya.
+func (r *bloodUnitRepositoryImpl) Update(ctx context.Context, before, after entity.BloodUnit, movement entity.StockMovement) (entity.BloodUnit, error) {
+	var updated entity.BloodUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.BloodUnit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, before.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != before.Status || current.NeedsReview != before.NeedsReview || current.LocationID != before.LocationID {
+			return entity.ErrVersionConflict
+		}
+
+		updated = current
+		updated.BagNumber = after.BagNumber
+		updated.BloodType = after.BloodType
+		updated.Rhesus = after.Rhesus
+		updated.Component = after.Component
+		updated.CollectionDate = after.CollectionDate
+		updated.ExpiryDate = after.ExpiryDate
+		updated.StorageDeviceID = after.StorageDeviceID
+		updated.Status = after.Status
+		updated.NeedsReview = after.NeedsReview
+		if err := tx.Save(&updated).Error; err != nil {
+			return err
+		}
+		if current.Status != updated.Status {
+			closed := ""
+			switch updated.Status {
+			case entity.BloodUnitStatusDiscarded:
+				closed = entity.StockReservationReleased
+			case entity.BloodUnitStatusExpired:
+				closed = entity.StockReservationExpired
+			}
+			if closed != "" {
+				if err := closeUnitReservations(tx, []uuid.UUID{updated.ID}, closed); err != nil {
+					return err
+				}
+			}
+		}
+		return syncUnitStock(tx, current, updated, movement)
+	})
+	return updated, err
+}
+
+// Delete menghapus unit karantina atau available pada baris yang dikunci. Tahanan aktif yang masih
+// menunjuk unit ditutup di transaksi yang sama, dan stok dikurangi bila unit ikut dihitung di stok.
+func (r *bloodUnitRepositoryImpl) Delete(ctx context.Context, id uuid.UUID, movement entity.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.BloodUnit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, id).Error; err != nil {
+			return err
+		}
+		if !current.IsDeletable() {
+			return entity.ErrUnitNotDeletable
+		}
+
+		if err := closeUnitReservations(tx, []uuid.UUID{current.ID}, entity.StockReservationReleased); err != nil {
+			return err
+		}
+		if err := tx.Delete(&entity.BloodUnit{}, id).Error; err != nil {
+			return err
+		}
+		if current.IsAvailable() {
+			movement = withMovementDefaults(movement, entity.StockMovementAdjustment, current.ID)
+			if _, err := adjustStockQuantity(tx, stockKeyOf(current), -1, movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// lockUnprocessedDonation mengunci donasi asal dan memastikan donasi sudah selesai dan belum pernah
+// menjadi unit. Karena donasi tetap terkunci sampai transaksi selesai, dua permintaan bersamaan
+// tidak bisa membuat unit ganda dari donasi yang sama.
+func lockUnprocessedDonation(tx *gorm.DB, donationID uuid.UUID) (entity.Donation, error) {
+	var donation entity.Donation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&donation, donationID).Error; err != nil {
+		return entity.Donation{}, err
+	}
+	if donation.Status != entity.DonationStatusCompleted {
+		return entity.Donation{}, entity.ErrDonationNotCompleted
+	}
+
+	var processed int64
+	if err := tx.Model(&entity.BloodUnit{}).Where("donation_id = ?", donationID).Count(&processed).Error; err != nil {
+		return entity.Donation{}, err
+	}
+	if processed > 0 {
+		return entity.Donation{}, entity.ErrDonationAlreadyProcessed
+	}
+	return donation, nil
+}
+
+func createBloodUnit(tx *gorm.DB, unit *entity.BloodUnit, movement entity.StockMovement) error {
+	if err := tx.Create(unit).Error; err != nil {
+		return err
+	}
+	if !unit.IsAvailable() {
+		return nil
+	}
+	movement = withMovementDefaults(movement, entity.StockMovementInbound, unit.ID)
+	_, err := adjustStockQuantity(tx, stockKeyOf(*unit), 1, movement)
+	return err
+}
+
+// syncUnitStock menyesuaikan stok ketika status atau golongan sebuah unit berubah:
+// stok lama dikurangi bila unit sebelumnya available, stok baru ditambah bila unit kini available.
+func syncUnitStock(tx *gorm.DB, before, after entity.BloodUnit, movement entity.StockMovement) error {
+	if before.IsAvailable() && after.IsAvailable() && stockKeyOf(before) == stockKeyOf(after) {
+		return nil
+	}
+	if before.IsAvailable() {
+		movementType := entity.StockMovementAdjustment
+		if !after.IsAvailable() {
+			movementType = entity.StockMovementTypeForStatus(after.Status)
+		}
+		leaving := withMovementDefaults(movement, movementType, before.ID)
+		if _, err := adjustStockQuantity(tx, stockKeyOf(before), -1, leaving); err != nil {
+			return err
+		}
+	}
+	if after.IsAvailable() {
+		entering := withMovementDefaults(movement, entity.StockMovementAdjustment, after.ID)
+		if _, err := adjustStockQuantity(tx, stockKeyOf(after), 1, entering); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyBloodUnitFilter(query *gorm.DB, filter repository.BloodUnitFilter) *gorm.DB {
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.BloodType != "" {
+		query = query.Where("blood_type = ?", filter.BloodType)
+	}
+	if filter.Rhesus != "" {
+		query = query.Where("rhesus = ?", filter.Rhesus)
+	}
+	if filter.Component != "" {
+		query = query.Where("component = ?", filter.Component)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	if filter.DonationID != uuid.Nil {
+		query = query.Where("donation_id = ?", filter.DonationID)
+	}
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("location_id IN (SELECT id FROM locations WHERE tenant_id = ?)", filter.TenantID)
+	}
+	if filter.NeedsReview != nil {
+		query = query.Where("needs_review = ?", *filter.NeedsReview)
+	}
+	return query
+}
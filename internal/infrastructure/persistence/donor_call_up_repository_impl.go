@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"math"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// earthRadiusKm sama dengan jari-jari yang dipakai helper.Haversine.
+const earthRadiusKm = 6371.0
+
+type donorCallUpRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDonorCallUpRepository(db *gorm.DB) repository.DonorCallUpRepository {
+	return &donorCallUpRepositoryImpl{db: db}
+}
+
+// FindCandidates mengambil pengguna yang terdaftar sebagai pendonor aktif dan berkoordinat dengan golongan yang diminta,
+// tanpa penundaan aktif dan belum pernah dipanggil untuk permintaan yang sama. Pendonor dibatasi kotak lintang/bujur
+// yang memuat radius panggilan, lalu diurutkan menurut perkiraan jarak equirectangular dari titik asal; jarak
+// Haversine yang tepat tetap dihitung pemanggil.
+func (r *donorCallUpRepositoryImpl) FindCandidates(ctx context.Context, filter repository.DonorCandidateFilter) ([]entity.UserDetail, error) {
+	var details []entity.UserDetail
+	if len(filter.BloodGroups) == 0 {
+		return details, nil
+	}
+
+	groups := make([][]interface{}, 0, len(filter.BloodGroups))
+	for _, group := range filter.BloodGroups {
+		groups = append(groups, []interface{}{group.BloodType, group.Rhesus})
+	}
+
+	query := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = user_details.user_id AND users.deleted_at IS NULL").
+		Where("user_details.is_active_donor = ?", true).
+		Where("user_details.latitude IS NOT NULL AND user_details.longitude IS NOT NULL").
+		Where("(user_details.blood_type, user_details.rhesus) IN ?", groups).
+		Where("NOT EXISTS (?)", r.db.Model(&entity.DonorDeferral{}).
+			Select("1").
+			Where("donor_deferrals.user_id = user_details.user_id").
+			Where("(donor_deferrals.deferred_until IS NULL OR donor_deferrals.deferred_until > ?)", filter.Day))
+	if filter.ExcludeRequestID != uuid.Nil {
+		query = query.Where("NOT EXISTS (?)", r.db.Model(&entity.DonorCallUpRecipient{}).
+			Select("1").
+			Joins("JOIN donor_call_ups ON donor_call_ups.id = donor_call_up_recipients.call_up_id").
+			Where("donor_call_up_recipients.user_id = user_details.user_id").
+			Where("donor_call_ups.blood_request_id = ?", filter.ExcludeRequestID))
+	}
+
+	if filter.RadiusKm > 0 {
+		query = whereWithinRadius(query, filter.Latitude, filter.Longitude, filter.RadiusKm)
+	}
+	scale := math.Cos(filter.Latitude * math.Pi / 180)
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "POWER(user_details.latitude - ?, 2) + POWER((user_details.longitude - ?) * ?, 2)",
+		Vars: []interface{}{filter.Latitude, filter.Longitude, scale},
+	}})
+
+	err := query.Find(&details).Error
+	return details, err
+}
+
+// whereWithinRadius menyaring koordinat pendonor dengan kotak batas di sekitar titik asal. Kotak ini lebih
+// longgar dari lingkaran radius; batas bujur dilewati dekat kutub atau bila kotak melintasi garis bujur 180.
+func whereWithinRadius(query *gorm.DB, latitude, longitude, radiusKm float64) *gorm.DB {
+	deltaLat := radiusKm / earthRadiusKm * 180 / math.Pi
+	query = query.Where("user_details.latitude BETWEEN ? AND ?", latitude-deltaLat, latitude+deltaLat)
+
+	scale := math.Cos(latitude * math.Pi / 180)
+	if scale < 1e-6 {
+		return query
+	}
+	deltaLon := deltaLat / scale
+	if longitude-deltaLon < -180 || longitude+deltaLon > 180 {
+		return query
+	}
+	return query.Where("user_details.longitude BETWEEN ? AND ?", longitude-deltaLon, longitude+deltaLon)
+}
+
+// Create menyimpan panggilan beserta penerimanya dan notifikasi untuk tiap pendonor dalam satu transaksi.
+func (r *donorCallUpRepositoryImpl) Create(ctx context.Context, callUp *entity.DonorCallUp, notifications []entity.Notification) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(callUp).Error; err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		for i := range notifications {
+			notifications[i].ReferenceID = &callUp.ID
+		}
+		return tx.Create(&notifications).Error
+	})
+}
+
+func (r *donorCallUpRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.DonorCallUp, error) {
+	var callUp entity.DonorCallUp
+	err := r.db.WithContext(ctx).
+		Preload("Recipients", func(db *gorm.DB) *gorm.DB {
+			return db.Order("distance_km ASC")
+		}).
+		First(&callUp, id).Error
+	return callUp, err
+}
+
+func (r *donorCallUpRepositoryImpl) FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.DonorCallUp, error) {
+	var callUps []entity.DonorCallUp
+	err := r.db.WithContext(ctx).
+		Preload("Recipients").
+		Where("blood_request_id = ?", bloodRequestID).
+		Order("created_at DESC").
+		Find(&callUps).Error
+	return callUps, err
+}
+
+func (r *donorCallUpRepositoryImpl) FindRecipient(ctx context.Context, callUpID, userID uuid.UUID) (entity.DonorCallUpRecipient, error) {
+	var recipient entity.DonorCallUpRecipient
+	err := r.db.WithContext(ctx).Where("call_up_id = ? AND user_id = ?", callUpID, userID).First(&recipient).Error
+	return recipient, err
+}
+
+func (r *donorCallUpRepositoryImpl) UpdateRecipient(ctx context.Context, recipient entity.DonorCallUpRecipient) (entity.DonorCallUpRecipient, error) {
+	err := r.db.WithContext(ctx).Save(&recipient).Error
+	return recipient, err
+}
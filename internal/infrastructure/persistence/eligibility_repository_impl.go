@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type eligibilityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEligibilityRepository(db *gorm.DB) repository.EligibilityRepository {
+	return &eligibilityRepositoryImpl{db: db}
+}
+
+func (r *eligibilityRepositoryImpl) FindPolicy(ctx context.Context, tenantID uuid.UUID) (entity.EligibilityPolicy, error) {
+	var policy entity.EligibilityPolicy
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&policy).Error
+	return policy, err
+}
+
+// SavePolicy membuat atau memperbarui kebijakan kelayakan tenant.
+func (r *eligibilityRepositoryImpl) SavePolicy(ctx context.Context, policy *entity.EligibilityPolicy) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"min_age", "max_age", "min_weight_kg",
+			"whole_blood_interval_male_days", "whole_blood_interval_female_days",
+			"apheresis_interval_male_days", "apheresis_interval_female_days",
+			"min_haemoglobin_male_gdl", "min_haemoglobin_female_gdl", "max_haemoglobin_gdl",
+			"min_systolic_mmhg", "max_systolic_mmhg", "min_diastolic_mmhg", "max_diastolic_mmhg",
+			"min_pulse_bpm", "max_pulse_bpm", "min_temperature_c", "max_temperature_c",
+			"updated_at",
+		}),
+	}).Create(policy).Error
+	if err != nil {
+		return err
+	}
+
+	// ID baru dari BeforeCreate tidak dipakai bila baris sudah ada, jadi muat ulang.
+	saved, err := r.FindPolicy(ctx, policy.TenantID)
+	if err != nil {
+		return err
+	}
+	*policy = saved
+	return nil
+}
+
+func (r *eligibilityRepositoryImpl) FindDonorDetail(ctx context.Context, userID uuid.UUID) (entity.UserDetail, error) {
+	var detail entity.UserDetail
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&detail).Error
+	return detail, err
+}
+
+func (r *eligibilityRepositoryImpl) FindLastCompletedDonation(ctx context.Context, userID uuid.UUID, day time.Time) (*entity.Donation, error) {
+	var donations []entity.Donation
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND donation_date <= ?", userID, entity.DonationStatusCompleted, day).
+		Order("donation_date DESC").
+		Limit(1).
+		Find(&donations).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(donations) == 0 {
+		return nil, nil
+	}
+	return &donations[0], nil
+}
+
+func (r *eligibilityRepositoryImpl) FindActiveDeferrals(ctx context.Context, userID uuid.UUID, day time.Time) ([]entity.DonorDeferral, error) {
+	var deferrals []entity.DonorDeferral
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("deferred_until IS NULL OR deferred_until > ?", day).
+		Order("created_at DESC").
+		Find(&deferrals).Error
+	return deferrals, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"donor-api/internal/entity"
 	"donor-api/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -21,21 +22,53 @@ func (r *eventRepositoryImpl) Save(ctx context.Context, event *entity.Event) err
 	return r.db.WithContext(ctx).Create(event).Error
 }
 
-func (r *eventRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]entity.Event, int64, error) {
+func (r *eventRepositoryImpl) FindAll(ctx context.Context, filter repository.EventFilter, limit, offset int) ([]entity.Event, int64, error) {
 	var events []entity.Event
 	var total int64
 
-	if err := r.db.WithContext(ctx).Model(&entity.Event{}).Count(&total).Error; err != nil {
+	query := r.db.WithContext(ctx).Model(&entity.Event{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.CreatedBy != uuid.Nil {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+	if err := query.Limit(limit).Offset(offset).Find(&events).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return events, total, nil
 }
 
+func (r *eventRepositoryImpl) FindPublic(ctx context.Context, city string, from time.Time, limit, offset int) ([]repository.PublicEvent, int64, error) {
+	var rows []repository.PublicEvent
+	var total int64
+
+	query := r.db.WithContext(ctx).Table("events").
+		Joins("JOIN locations ON locations.id = events.location_id").
+		Where("events.end_date >= ?", from)
+	if city != "" {
+		query = query.Where("LOWER(locations.city) = LOWER(?)", city)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Select("events.event_name, events.slug, events.description, events.start_date, events.end_date, locations.location_name, locations.city").
+		Order("events.start_date ASC").
+		Limit(limit).Offset(offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rows, total, nil
+}
+
 func (r *eventRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Event, error) {
 	var event entity.Event
 	err := r.db.WithContext(ctx).First(&event, id).Error
@@ -43,7 +76,9 @@ func (r *eventRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entit
 }
 
 func (r *eventRepositoryImpl) Update(ctx context.Context, event entity.Event) (entity.Event, error) {
-	err := r.db.WithContext(ctx).Save(&event).Error
+	version := event.Version
+	event.Version++
+	err := updateVersioned(r.db.WithContext(ctx), &event, version)
 	return event, err
 }
 
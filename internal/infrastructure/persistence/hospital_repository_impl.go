@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type hospitalRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHospitalRepository(db *gorm.DB) repository.HospitalRepository {
+	return &hospitalRepositoryImpl{db: db}
+}
+
+func (r *hospitalRepositoryImpl) Save(ctx context.Context, hospital *entity.Hospital) error {
+	return r.db.WithContext(ctx).Create(hospital).Error
+}
+
+func (r *hospitalRepositoryImpl) FindAll(ctx context.Context, filter repository.HospitalFilter, limit, offset int) ([]entity.Hospital, int64, error) {
+	var hospitals []entity.Hospital
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Hospital{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(city) = LOWER(?)", filter.City)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("name ASC").Limit(limit).Offset(offset).Find(&hospitals).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return hospitals, total, nil
+}
+
+func (r *hospitalRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Hospital, error) {
+	var hospital entity.Hospital
+	err := r.db.WithContext(ctx).First(&hospital, id).Error
+	return hospital, err
+}
+
+func (r *hospitalRepositoryImpl) Update(ctx context.Context, hospital entity.Hospital) (entity.Hospital, error) {
+	version := hospital.Version
+	hospital.Version++
+	err := updateVersioned(r.db.WithContext(ctx), &hospital, version)
+	return hospital, err
+}
+
+func (r *hospitalRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.Hospital{}, id).Error
+}
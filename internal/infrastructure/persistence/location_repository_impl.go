@@ -53,8 +53,16 @@ func (r *locationRepositoryImpl) FindByTenantID(ctx context.Context, limit, offs
 	return locations, total, err
 }
 
+func (r *locationRepositoryImpl) FindAllByTenantID(ctx context.Context, tenantID uuid.UUID) ([]entity.Location, error) {
+	var locations []entity.Location
+	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).Find(&locations).Error
+	return locations, err
+}
+
 func (r *locationRepositoryImpl) Update(ctx context.Context, location entity.Location) (entity.Location, error) {
-	err := r.db.WithContext(ctx).Save(&location).Error
+	version := location.Version
+	location.Version++
+	err := updateVersioned(r.db.WithContext(ctx), &location, version)
 	return location, err
 }
 
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type notificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepositoryImpl{db: db}
+}
+
+func (r *notificationRepositoryImpl) FindAll(ctx context.Context, filter repository.NotificationFilter, limit, offset int) ([]entity.Notification, int64, error) {
+	var notifications []entity.Notification
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", filter.UserID)
+	if filter.Unread != nil {
+		if *filter.Unread {
+			query = query.Where("read_at IS NULL")
+		} else {
+			query = query.Where("read_at IS NOT NULL")
+		}
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *notificationRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Notification, error) {
+	var notification entity.Notification
+	err := r.db.WithContext(ctx).First(&notification, id).Error
+	return notification, err
+}
+
+func (r *notificationRepositoryImpl) MarkRead(ctx context.Context, notification entity.Notification) (entity.Notification, error) {
+	err := r.db.WithContext(ctx).Model(&notification).
+		Select("read_at").
+		Updates(notification).Error
+	return notification, err
+}
@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type questionnaireRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewQuestionnaireRepository(db *gorm.DB) repository.QuestionnaireRepository {
+	return &questionnaireRepositoryImpl{db: db}
+}
+
+func (r *questionnaireRepositoryImpl) Create(ctx context.Context, questionnaire *entity.Questionnaire) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&entity.Questionnaire{}).
+			Where("tenant_id = ?", questionnaire.TenantID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		questionnaire.Version = last + 1
+		return tx.Create(questionnaire).Error
+	})
+}
+
+func (r *questionnaireRepositoryImpl) FindAll(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]entity.Questionnaire, int64, error) {
+	var questionnaires []entity.Questionnaire
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Questionnaire{})
+	if tenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("tenant_id, version DESC").Limit(limit).Offset(offset).Find(&questionnaires).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return questionnaires, total, nil
+}
+
+func (r *questionnaireRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Questionnaire, error) {
+	var questionnaire entity.Questionnaire
+	err := r.db.WithContext(ctx).Preload("Questions", orderByPosition).First(&questionnaire, id).Error
+	return questionnaire, err
+}
+
+func (r *questionnaireRepositoryImpl) FindCurrent(ctx context.Context, tenantID uuid.UUID) (entity.Questionnaire, error) {
+	var questionnaire entity.Questionnaire
+	err := r.db.WithContext(ctx).
+		Preload("Questions", orderByPosition).
+		Where("tenant_id = ?", tenantID).
+		Order("version DESC").
+		First(&questionnaire).Error
+	return questionnaire, err
+}
+
+func (r *questionnaireRepositoryImpl) FindSubmission(ctx context.Context, donationID uuid.UUID) (entity.QuestionnaireSubmission, error) {
+	var submission entity.QuestionnaireSubmission
+	err := r.db.WithContext(ctx).Preload("Answers").Where("donation_id = ?", donationID).First(&submission).Error
+	return submission, err
+}
+
+// Submit mengunci donasi agar pengiriman ganda atau perubahan status yang bersamaan tidak lolos pemeriksaan.
+func (r *questionnaireRepositoryImpl) Submit(ctx context.Context, submission *entity.QuestionnaireSubmission, deferrals []entity.DonorDeferral) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var donation entity.Donation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&donation, submission.DonationID).Error; err != nil {
+			return err
+		}
+		if donation.Status != entity.DonationStatusPending {
+			return entity.ErrDonationNotPending
+		}
+
+		var existing int64
+		if err := tx.Model(&entity.QuestionnaireSubmission{}).
+			Where("donation_id = ?", submission.DonationID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return entity.ErrQuestionnaireSubmitted
+		}
+
+		if err := tx.Create(submission).Error; err != nil {
+			return err
+		}
+		if len(deferrals) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&deferrals).Error; err != nil {
+			return err
+		}
+		return tx.Model(&donation).Updates(map[string]interface{}{
+			"status":        entity.DonationStatusCancelled,
+			"status_reason": "Ditunda berdasarkan jawaban kuesioner pra-donor",
+		}).Error
+	})
+}
+
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
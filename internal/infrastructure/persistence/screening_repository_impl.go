@@ -0,0 +1,272 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type screeningRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewScreeningRepository(db *gorm.DB) repository.ScreeningRepository {
+	return &screeningRepositoryImpl{db: db}
+}
+
+func (r *screeningRepositoryImpl) FindByDonationID(ctx context.Context, donationID uuid.UUID) ([]entity.ScreeningResult, error) {
+	var results []entity.ScreeningResult
+	err := r.db.WithContext(ctx).Where("donation_id = ?", donationID).Order("tested_at ASC").Find(&results).Error
+	return results, err
+}
+
+// Record menyimpan hasil skrining lalu menerapkan status donasi yang baru dalam satu transaksi:
+// cleared mengeluarkan unit dari karantina ke stok, reactive memusnahkan unit dan menunda pendonor.
+func (r *screeningRepositoryImpl) Record(ctx context.Context, donationID uuid.UUID, results []entity.ScreeningResult, actorID uuid.UUID) (entity.Donation, error) {
+	var donation entity.Donation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&donation, donationID).Error; err != nil {
+			return err
+		}
+
+		var existing int64
+		markers := make([]string, 0, len(results))
+		for _, result := range results {
+			markers = append(markers, result.Marker)
+		}
+		if err := tx.Model(&entity.ScreeningResult{}).
+			Where("donation_id = ? AND marker IN ?", donationID, markers).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return entity.ErrScreeningResultExists
+		}
+
+		for i := range results {
+			results[i].DonationID = donationID
+			if actorID != uuid.Nil {
+				results[i].EnteredBy = &actorID
+			}
+		}
+		if err := tx.Create(&results).Error; err != nil {
+			return err
+		}
+
+		var all []entity.ScreeningResult
+		if err := tx.Where("donation_id = ?", donationID).Find(&all).Error; err != nil {
+			return err
+		}
+		outcome := entity.ScreeningOutcome(all)
+		if outcome == donation.ScreeningStatus {
+			return nil
+		}
+
+		donation.ScreeningStatus = outcome
+		if err := tx.Model(&donation).Update("screening_status", outcome).Error; err != nil {
+			return err
+		}
+
+		switch outcome {
+		case entity.DonationScreeningCleared:
+			return releaseQuarantinedUnits(tx, donation, actorID)
+		case entity.DonationScreeningReactive:
+			if err := discardDonationUnits(tx, donation, actorID); err != nil {
+				return err
+			}
+			return deferReactiveDonor(tx, donation, all)
+		}
+		return nil
+	})
+	return donation, err
+}
+
+func (r *screeningRepositoryImpl) FindDeferrals(ctx context.Context, filter repository.DonorDeferralFilter, limit, offset int) ([]entity.DonorDeferral, int64, error) {
+	var deferrals []entity.DonorDeferral
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.DonorDeferral{})
+	if filter.UserID != uuid.Nil {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("donation_id IN (SELECT donations.id FROM donations JOIN locations ON locations.id = donations.location_id WHERE locations.tenant_id = ?)", filter.TenantID)
+	}
+	if filter.FollowUpPending != nil {
+		if *filter.FollowUpPending {
+			query = query.Where("follow_up_required = ? AND follow_up_completed_at IS NULL", true)
+		} else {
+			query = query.Where("follow_up_required = ? OR follow_up_completed_at IS NOT NULL", false)
+		}
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&deferrals).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deferrals, total, nil
+}
+
+func (r *screeningRepositoryImpl) FindDeferralByID(ctx context.Context, id uuid.UUID) (entity.DonorDeferral, error) {
+	var deferral entity.DonorDeferral
+	err := r.db.WithContext(ctx).First(&deferral, id).Error
+	return deferral, err
+}
+
+func (r *screeningRepositoryImpl) UpdateDeferral(ctx context.Context, deferral entity.DonorDeferral) (entity.DonorDeferral, error) {
+	err := r.db.WithContext(ctx).Save(&deferral).Error
+	return deferral, err
+}
+
+func (r *screeningRepositoryImpl) FindVitalSigns(ctx context.Context, donationID uuid.UUID) (entity.VitalSigns, error) {
+	var vitals entity.VitalSigns
+	err := r.db.WithContext(ctx).Where("donation_id = ?", donationID).First(&vitals).Error
+	return vitals, err
+}
+
+func (r *screeningRepositoryImpl) RecordVitalSigns(ctx context.Context, vitals *entity.VitalSigns, deferral *entity.DonorDeferral) (entity.Donation, error) {
+	var donation entity.Donation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&donation, vitals.DonationID).Error; err != nil {
+			return err
+		}
+		if donation.Status != entity.DonationStatusPending {
+			return entity.ErrDonationNotPending
+		}
+
+		var existing int64
+		if err := tx.Model(&entity.VitalSigns{}).Where("donation_id = ?", vitals.DonationID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return entity.ErrVitalSignsRecorded
+		}
+
+		if err := tx.Create(vitals).Error; err != nil {
+			return err
+		}
+		if donation.UserID != nil {
+			result := tx.Model(&entity.UserDetail{}).
+				Where("user_id = ?", *donation.UserID).
+				Update("weight", vitals.WeightKg)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return entity.ErrDonorDetailMissing
+			}
+		}
+		if vitals.Outcome == entity.VitalSignsAccepted {
+			return nil
+		}
+
+		donation.Status = entity.DonationStatusCancelled
+		donation.StatusReason = vitals.Reason
+		if err := tx.Model(&donation).Updates(map[string]interface{}{
+			"status":        donation.Status,
+			"status_reason": donation.StatusReason,
+		}).Error; err != nil {
+			return err
+		}
+		if deferral == nil {
+			return nil
+		}
+		return tx.Create(deferral).Error
+	})
+	return donation, err
+}
+
+// releaseQuarantinedUnits memindahkan unit karantina donasi ke available dan menambah stoknya.
+func releaseQuarantinedUnits(tx *gorm.DB, donation entity.Donation, actorID uuid.UUID) error {
+	var units []entity.BloodUnit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("donation_id = ? AND status = ?", donation.ID, entity.BloodUnitStatusQuarantine).
+		Find(&units).Error; err != nil || len(units) == 0 {
+		return err
+	}
+
+	if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+		Update("status", entity.BloodUnitStatusAvailable).Error; err != nil {
+		return err
+	}
+	// Unit yang masih menunggu review excursion suhu baru dihitung di stok setelah dilepas saat review.
+	for _, unit := range units {
+		unit.Status = entity.BloodUnitStatusAvailable
+		if !unit.IsAvailable() {
+			continue
+		}
+		movement := entity.NewStockMovement(entity.StockMovementInbound, "Lolos skrining IMLTD", actorID, &unit.ID)
+		if _, err := adjustStockQuantity(tx, stockKeyOf(unit), 1, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardDonationUnits memusnahkan semua unit donasi yang belum dikeluarkan. Unit available
+// dikurangi dari stok; tahanan aktif atas unit reserved ikut dilepas.
+func discardDonationUnits(tx *gorm.DB, donation entity.Donation, actorID uuid.UUID) error {
+	var units []entity.BloodUnit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("donation_id = ? AND status IN ?", donation.ID, []string{
+			entity.BloodUnitStatusQuarantine,
+			entity.BloodUnitStatusAvailable,
+			entity.BloodUnitStatusReserved,
+		}).
+		Find(&units).Error; err != nil || len(units) == 0 {
+		return err
+	}
+
+	ids := bloodUnitIDs(units)
+	if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", ids).
+		Update("status", entity.BloodUnitStatusDiscarded).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&entity.StockReservation{}).
+		Where("blood_unit_id IN ? AND status = ?", ids, entity.StockReservationActive).
+		Updates(map[string]interface{}{"status": entity.StockReservationReleased, "released_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	for _, unit := range units {
+		if !unit.IsAvailable() {
+			continue
+		}
+		movement := entity.NewStockMovement(entity.StockMovementDiscard, "Hasil skrining reaktif", actorID, &unit.ID)
+		if _, err := adjustStockQuantity(tx, stockKeyOf(unit), -1, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deferReactiveDonor mencatat penundaan permanen yang memerlukan tindak lanjut untuk pendonor.
+func deferReactiveDonor(tx *gorm.DB, donation entity.Donation, results []entity.ScreeningResult) error {
+	if donation.UserID == nil {
+		return nil
+	}
+
+	var reactive []string
+	for _, result := range results {
+		if result.Result == entity.ScreeningResultReactive {
+			reactive = append(reactive, result.Marker)
+		}
+	}
+	deferral := entity.DonorDeferral{
+		UserID:           *donation.UserID,
+		DonationID:       &donation.ID,
+		Reason:           fmt.Sprintf("Hasil skrining reaktif: %s", strings.Join(reactive, ", ")),
+		FollowUpRequired: true,
+	}
+	return tx.Create(&deferral).Error
+}
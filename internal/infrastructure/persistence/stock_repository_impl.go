@@ -4,9 +4,14 @@ import (
 	"context"
 	"donor-api/internal/entity"
 	"donor-api/internal/repository"
+	"donor-api/pkg/compatibility"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type stockRepositoryImpl struct {
@@ -21,32 +26,609 @@ func (r *stockRepositoryImpl) Save(ctx context.Context, stock *entity.Stock) err
 	return r.db.WithContext(ctx).Create(stock).Error
 }
 
-func (r *stockRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]entity.Stock, int64, error) {
+func (r *stockRepositoryImpl) FindAll(ctx context.Context, filter repository.StockFilter, limit, offset int) ([]entity.Stock, int64, error) {
 	var stocks []entity.Stock
 	var total int64
 
-	if err := r.db.WithContext(ctx).Model(&entity.Stock{}).Count(&total).Error; err != nil {
+	query := applyStockFilter(r.db.WithContext(ctx).Model(&entity.Stock{}), filter)
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&stocks).Error; err != nil {
+	if err := query.Limit(limit).Offset(offset).Find(&stocks).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return stocks, total, nil
 }
 
+func (r *stockRepositoryImpl) FindByFilter(ctx context.Context, filter repository.StockFilter) ([]entity.Stock, error) {
+	var stocks []entity.Stock
+	err := applyStockFilter(r.db.WithContext(ctx), filter).Find(&stocks).Error
+	return stocks, err
+}
+
+func (r *stockRepositoryImpl) Summarize(ctx context.Context, filter repository.StockFilter) ([]repository.StockAggregate, error) {
+	var aggregates []repository.StockAggregate
+	err := applyStockFilter(r.db.WithContext(ctx).Model(&entity.Stock{}), filter).
+		Select("blood_type, rhesus, component, SUM(bag_quantity) AS bag_quantity").
+		Group("blood_type, rhesus, component").
+		Order("blood_type, rhesus, component").
+		Scan(&aggregates).Error
+	return aggregates, err
+}
+
+// Availability menggabungkan lokasi dengan stoknya sehingga lokasi tanpa stok tetap muncul.
+func (r *stockRepositoryImpl) Availability(ctx context.Context, filter repository.StockAvailabilityFilter) ([]repository.StockAvailabilityRow, error) {
+	var rows []repository.StockAvailabilityRow
+
+	join := "LEFT JOIN stocks ON stocks.location_id = locations.id"
+	args := []interface{}{}
+	if filter.Component != "" {
+		join += " AND stocks.component = ?"
+		args = append(args, filter.Component)
+	}
+
+	query := r.db.WithContext(ctx).Table("locations").
+		Joins(join, args...).
+		Select("locations.tenant_id, locations.id AS location_id, locations.location_name, locations.city, " +
+			"COALESCE(stocks.blood_type, '') AS blood_type, COALESCE(stocks.rhesus, '') AS rhesus, " +
+			"COALESCE(SUM(stocks.bag_quantity), 0) AS bag_quantity")
+	if filter.City != "" {
+		query = query.Where("LOWER(locations.city) = LOWER(?)", filter.City)
+	}
+
+	err := query.
+		Group("locations.tenant_id, locations.id, locations.location_name, locations.city, stocks.blood_type, stocks.rhesus").
+		Order("locations.city, locations.location_name").
+		Scan(&rows).Error
+	return rows, err
+}
+
 func (r *stockRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Stock, error) {
 	var stock entity.Stock
 	err := r.db.WithContext(ctx).First(&stock, id).Error
 	return stock, err
 }
 
+// Update mengubah golongan, komponen, atau lokasi baris stok yang belum pernah dipakai.
 func (r *stockRepositoryImpl) Update(ctx context.Context, stock entity.Stock) (entity.Stock, error) {
-	err := r.db.WithContext(ctx).Save(&stock).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUnusedStock(tx, stock.ID); err != nil {
+			return err
+		}
+		version := stock.Version
+		stock.Version++
+		return updateVersioned(tx, &stock, version)
+	})
 	return stock, err
 }
 
+func (r *stockRepositoryImpl) FindMovements(ctx context.Context, stockID uuid.UUID, limit, offset int) ([]entity.StockMovement, int64, error) {
+	var movements []entity.StockMovement
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.StockMovement{}).Where("stock_id = ?", stockID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+func (r *stockRepositoryImpl) SumMovements(ctx context.Context, stockID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&entity.StockMovement{}).
+		Where("stock_id = ?", stockID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *stockRepositoryImpl) CountAvailableUnits(ctx context.Context, stock entity.Stock) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&entity.BloodUnit{}).
+		Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+			stock.BloodType, stock.Rhesus, stock.Component, stock.LocationID).
+		Where("status = ? AND needs_review = ?", entity.BloodUnitStatusAvailable, false).
+		Count(&total).Error
+	return total, err
+}
+
+// SaveThreshold membuat atau memperbarui batas untuk kombinasi lokasi, golongan, dan rhesus.
+func (r *stockRepositoryImpl) SaveThreshold(ctx context.Context, threshold *entity.StockThreshold) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "location_id"}, {Name: "blood_type"}, {Name: "rhesus"}},
+		DoUpdates: clause.AssignmentColumns([]string{"minimum_level", "critical_level", "updated_at"}),
+	}).Create(threshold).Error
+	if err != nil {
+		return err
+	}
+
+	// ID baru dari BeforeCreate tidak dipakai bila baris sudah ada, jadi muat ulang.
+	var saved entity.StockThreshold
+	if err := r.db.WithContext(ctx).
+		Where("location_id = ? AND blood_type = ? AND rhesus = ?",
+			threshold.LocationID, threshold.BloodType, threshold.Rhesus).
+		First(&saved).Error; err != nil {
+		return err
+	}
+	*threshold = saved
+	return nil
+}
+
+func (r *stockRepositoryImpl) FindThresholds(ctx context.Context, filter repository.StockThresholdFilter) ([]entity.StockThreshold, error) {
+	var thresholds []entity.StockThreshold
+	query := r.db.WithContext(ctx).Model(&entity.StockThreshold{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	err := query.Order("location_id, blood_type, rhesus").Find(&thresholds).Error
+	return thresholds, err
+}
+
+func (r *stockRepositoryImpl) FindThresholdByID(ctx context.Context, id uuid.UUID) (entity.StockThreshold, error) {
+	var threshold entity.StockThreshold
+	err := r.db.WithContext(ctx).First(&threshold, id).Error
+	return threshold, err
+}
+
+func (r *stockRepositoryImpl) DeleteThreshold(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.StockThreshold{}, id).Error
+}
+
+func (r *stockRepositoryImpl) FindAlerts(ctx context.Context, filter repository.StockAlertFilter, limit, offset int) ([]entity.StockAlert, int64, error) {
+	var alerts []entity.StockAlert
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.StockAlert{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	if filter.Level != "" {
+		query = query.Where("level = ?", filter.Level)
+	}
+	if filter.Acknowledged != nil {
+		if *filter.Acknowledged {
+			query = query.Where("acknowledged_at IS NOT NULL")
+		} else {
+			query = query.Where("acknowledged_at IS NULL")
+		}
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+func (r *stockRepositoryImpl) FindAlertByID(ctx context.Context, id uuid.UUID) (entity.StockAlert, error) {
+	var alert entity.StockAlert
+	err := r.db.WithContext(ctx).First(&alert, id).Error
+	return alert, err
+}
+
+func (r *stockRepositoryImpl) AcknowledgeAlert(ctx context.Context, alert entity.StockAlert) (entity.StockAlert, error) {
+	err := r.db.WithContext(ctx).Model(&alert).
+		Select("acknowledged_by", "acknowledged_at").
+		Updates(alert).Error
+	return alert, err
+}
+
+// DailyIssued menjumlahkan kantong yang dikeluarkan (movement issue) per hari, golongan darah, dan rhesus.
+// Ledger ini hanya mencakup kantong yang keluar langsung dari stok; kantong yang sudah ditahan
+// sebelum diserahkan dihitung oleh DailyReservedIssued.
+func (r *stockRepositoryImpl) DailyIssued(ctx context.Context, filter repository.ConsumptionFilter, from, to time.Time) ([]repository.DailyConsumption, error) {
+	var rows []repository.DailyConsumption
+	query := r.db.WithContext(ctx).Table("stock_movements").
+		Joins("JOIN stocks ON stocks.id = stock_movements.stock_id").
+		Select("DATE(stock_movements.created_at) AS day, stocks.blood_type, stocks.rhesus, -SUM(stock_movements.quantity) AS quantity").
+		Where("stock_movements.type = ?", entity.StockMovementIssue).
+		Where("stock_movements.created_at >= ? AND stock_movements.created_at < ?", from, to)
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("stocks.location_id IN (SELECT id FROM locations WHERE tenant_id = ?)", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("stocks.location_id = ?", filter.LocationID)
+	}
+	if filter.Component != "" {
+		query = query.Where("stocks.component = ?", filter.Component)
+	}
+	if filter.Rhesus != "" {
+		query = query.Where("stocks.rhesus = ?", filter.Rhesus)
+	}
+
+	err := query.Group("DATE(stock_movements.created_at), stocks.blood_type, stocks.rhesus").Scan(&rows).Error
+	return rows, err
+}
+
+// DailyReservedIssued menjumlahkan kantong yang diserahkan untuk permintaan darah dari tahanan per hari,
+// golongan darah, dan rhesus. Kantong ini sudah keluar dari stok saat ditahan, jadi penyerahannya
+// tidak memiliki movement issue di ledger.
+func (r *stockRepositoryImpl) DailyReservedIssued(ctx context.Context, filter repository.ConsumptionFilter, from, to time.Time) ([]repository.DailyConsumption, error) {
+	var rows []repository.DailyConsumption
+	query := r.db.WithContext(ctx).Table("blood_request_fulfillment_units AS units").
+		Joins("JOIN blood_request_fulfillments AS fulfillments ON fulfillments.id = units.fulfillment_id").
+		Select("DATE(fulfillments.issued_at) AS day, units.blood_type, units.rhesus, COUNT(*) AS quantity").
+		Where("units.from_reserve = ?", true).
+		Where("fulfillments.issued_at >= ? AND fulfillments.issued_at < ?", from, to)
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("fulfillments.location_id IN (SELECT id FROM locations WHERE tenant_id = ?)", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("fulfillments.location_id = ?", filter.LocationID)
+	}
+	if filter.Component != "" {
+		query = query.Where("units.component = ?", filter.Component)
+	}
+	if filter.Rhesus != "" {
+		query = query.Where("units.rhesus = ?", filter.Rhesus)
+	}
+
+	err := query.Group("DATE(fulfillments.issued_at), units.blood_type, units.rhesus").Scan(&rows).Error
+	return rows, err
+}
+
+// Delete menghapus baris stok yang belum pernah dipakai.
 func (r *stockRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Stock{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockUnusedStock(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Stock{}, id).Error
+	})
+}
+
+// lockUnusedStock mengunci baris stok dan memastikan belum ada unit maupun ledger untuknya. Jumlah stok
+// diturunkan dari unit, jadi baris yang sudah dipakai tidak boleh dipindah golongan/lokasi atau dihapus.
+func lockUnusedStock(tx *gorm.DB, id uuid.UUID) error {
+	var stock entity.Stock
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&stock, id).Error; err != nil {
+		return err
+	}
+
+	var units, movements int64
+	if err := tx.Model(&entity.BloodUnit{}).
+		Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+			stock.BloodType, stock.Rhesus, stock.Component, stock.LocationID).
+		Count(&units).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&entity.StockMovement{}).Where("stock_id = ?", id).Count(&movements).Error; err != nil {
+		return err
+	}
+	if stock.BagQuantity != 0 || units > 0 || movements > 0 {
+		return entity.ErrStockInUse
+	}
+	return nil
+}
+
+func (r *stockRepositoryImpl) AllocateUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, movement entity.StockMovement) ([]entity.BloodUnit, error) {
+	if movement.Type == "" {
+		movement.Type = entity.StockMovementIssue
+	}
+	return r.takeUnits(ctx, key, quantity, today, entity.BloodUnitStatusIssued, movement)
+}
+
+func (r *stockRepositoryImpl) WriteOffUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, movement entity.StockMovement) ([]entity.BloodUnit, error) {
+	if movement.Type == "" {
+		movement.Type = entity.StockMovementDiscard
+	}
+	return r.takeUnits(ctx, key, quantity, today, entity.BloodUnitStatusDiscarded, movement)
+}
+
+// ReceiveUnits membuat kantong baru dalam satu transaksi. Donasi asal dikunci, harus sudah lolos
+// skrining, dan belum pernah menjadi unit, sehingga satu donasi hanya menghasilkan satu kantong yang
+// langsung berstatus available dan tercatat di ledger.
+func (r *stockRepositoryImpl) ReceiveUnits(ctx context.Context, units []entity.BloodUnit, movement entity.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range units {
+			donation, err := lockUnprocessedDonation(tx, *units[i].DonationID)
+			if err != nil {
+				return err
+			}
+			switch donation.ScreeningStatus {
+			case entity.DonationScreeningCleared:
+			case entity.DonationScreeningReactive:
+				return entity.ErrDonationReactive
+			default:
+				return entity.ErrUnitInQuarantine
+			}
+
+			units[i].Status = entity.BloodUnitStatusAvailable
+			if err := createBloodUnit(tx, &units[i], movement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// takeUnits mengunci unit available dengan kedaluwarsa terdekat (FEFO), mengubah statusnya,
+// dan mengurangi stok sejumlah unit tersebut.
+func (r *stockRepositoryImpl) takeUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, status string, movement entity.StockMovement) ([]entity.BloodUnit, error) {
+	var units []entity.BloodUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+				key.BloodType, key.Rhesus, key.Component, key.LocationID).
+			Where("status = ? AND expiry_date >= ?", entity.BloodUnitStatusAvailable, today).
+			Where("needs_review = ?", false).
+			Order("expiry_date ASC, collection_date ASC").
+			Limit(quantity).
+			Find(&units).Error
+		if err != nil {
+			return err
+		}
+		if len(units) < quantity {
+			return entity.ErrInsufficientStock
+		}
+
+		ids := make([]uuid.UUID, 0, len(units))
+		for i := range units {
+			ids = append(ids, units[i].ID)
+			units[i].Status = status
+		}
+		if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", ids).
+			Update("status", status).Error; err != nil {
+			return err
+		}
+
+		_, err = adjustStockQuantity(tx, key, -quantity, movement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+func (r *stockRepositoryImpl) ExpireUnits(ctx context.Context, today time.Time) ([]entity.BloodUnit, error) {
+	var units []entity.BloodUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND expiry_date < ?",
+				[]string{entity.BloodUnitStatusQuarantine, entity.BloodUnitStatusAvailable, entity.BloodUnitStatusReserved}, today).
+			Find(&units).Error
+		if err != nil || len(units) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(units))
+		for _, unit := range units {
+			ids = append(ids, unit.ID)
+			if unit.IsAvailable() {
+				movement := entity.NewStockMovement(entity.StockMovementExpiry, "Kedaluwarsa", uuid.Nil, &unit.ID)
+				if _, err := adjustStockQuantity(tx, stockKeyOf(unit), -1, movement); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Tahanan pada kantong yang kedaluwarsa ikut ditutup agar tidak lagi dihitung menutupi permintaan.
+		if err := closeUnitReservations(tx, ids, entity.StockReservationExpired); err != nil {
+			return err
+		}
+		return tx.Model(&entity.BloodUnit{}).Where("id IN ?", ids).
+			Update("status", entity.BloodUnitStatusExpired).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range units {
+		units[i].Status = entity.BloodUnitStatusExpired
+	}
+	return units, nil
+}
+
+// stockKeyOf mengambil kunci stok (golongan, rhesus, komponen, lokasi) dari sebuah unit.
+func stockKeyOf(unit entity.BloodUnit) entity.Stock {
+	return entity.Stock{
+		BloodType:  unit.BloodType,
+		Rhesus:     unit.Rhesus,
+		Component:  unit.Component,
+		LocationID: unit.LocationID,
+	}
+}
+
+// adjustStockByGroup menyesuaikan stok per golongan kantong, karena kantong yang cocok untuk satu
+// permintaan dapat berasal dari beberapa golongan. Setiap unit menambah sign ke stok golongannya.
+func adjustStockByGroup(tx *gorm.DB, units []entity.BloodUnit, sign int, movement entity.StockMovement) error {
+	var keys []entity.Stock
+	counts := map[entity.Stock]int{}
+	for _, unit := range units {
+		key := stockKeyOf(unit)
+		if _, ok := counts[key]; !ok {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	for _, key := range keys {
+		if _, err := adjustStockQuantity(tx, key, sign*counts[key], movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// whereCompatible membatasi query kantong pada komponen dan golongan donor yang cocok untuk resipien,
+// dengan golongan yang sama persis didahulukan sebelum urutan lain yang ditambahkan pemanggil.
+func whereCompatible(query *gorm.DB, component string, recipient compatibility.BloodGroup) *gorm.DB {
+	var bloodTypes, rhesus []string
+	for _, donor := range compatibility.Donors(component, recipient) {
+		if !slices.Contains(bloodTypes, donor.BloodType) {
+			bloodTypes = append(bloodTypes, donor.BloodType)
+		}
+		if !slices.Contains(rhesus, donor.Rhesus) {
+			rhesus = append(rhesus, donor.Rhesus)
+		}
+	}
+	return query.Where("component = ? AND blood_type IN ? AND rhesus IN ?", component, bloodTypes, rhesus).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "CASE WHEN blood_type = ? AND rhesus = ? THEN 0 ELSE 1 END",
+			Vars: []interface{}{recipient.BloodType, recipient.Rhesus},
+		}})
+}
+
+// adjustStockQuantity menambah/mengurangi BagQuantity secara atomik di SQL dan
+// mencatat perubahannya ke ledger stock_movements dalam transaksi yang sama.
+// Baris stok dibuat otomatis bila belum ada, dan jumlah tidak boleh menjadi negatif.
+// recordStockMovement mencatat pergerakan tanpa mengubah BagQuantity, untuk kantong yang sudah keluar dari
+// stok sebelumnya tetapi perubahan statusnya tetap harus tertelusur di ledger stok asalnya.
+func recordStockMovement(tx *gorm.DB, key entity.Stock, movement entity.StockMovement) error {
+	var stock entity.Stock
+	if err := tx.Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+		key.BloodType, key.Rhesus, key.Component, key.LocationID).
+		First(&stock).Error; err != nil {
+		return err
+	}
+
+	movement.StockID = stock.ID
+	movement.Quantity = 0
+	movement.BalanceAfter = stock.BagQuantity
+	return tx.Create(&movement).Error
+}
+
+func adjustStockQuantity(tx *gorm.DB, key entity.Stock, delta int, movement entity.StockMovement) (entity.Stock, error) {
+	placeholder := entity.Stock{
+		BloodType:  key.BloodType,
+		Rhesus:     key.Rhesus,
+		Component:  key.Component,
+		LocationID: key.LocationID,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&placeholder).Error; err != nil {
+		return entity.Stock{}, err
+	}
+
+	var stock entity.Stock
+	if err := tx.Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+		key.BloodType, key.Rhesus, key.Component, key.LocationID).
+		First(&stock).Error; err != nil {
+		return entity.Stock{}, err
+	}
+
+	result := tx.Model(&entity.Stock{}).
+		Where("id = ? AND bag_quantity + ? >= 0", stock.ID, delta).
+		Updates(map[string]interface{}{
+			"bag_quantity": gorm.Expr("bag_quantity + ?", delta),
+			"version":      gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return entity.Stock{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.Stock{}, entity.ErrInsufficientStock
+	}
+
+	if err := tx.First(&stock, stock.ID).Error; err != nil {
+		return entity.Stock{}, err
+	}
+
+	movement.StockID = stock.ID
+	movement.Quantity = delta
+	movement.BalanceAfter = stock.BagQuantity
+	if movement.Type == "" {
+		movement.Type = entity.StockMovementAdjustment
+	}
+	if err := tx.Create(&movement).Error; err != nil {
+		return entity.Stock{}, err
+	}
+
+	if err := raiseStockAlert(tx, stock, delta); err != nil {
+		return entity.Stock{}, err
+	}
+
+	return stock, nil
+}
+
+// raiseStockAlert mencatat StockAlert bila total kantong (semua komponen) di lokasi
+// untuk golongan dan rhesus tersebut turun melewati batas yang dikonfigurasi.
+func raiseStockAlert(tx *gorm.DB, stock entity.Stock, delta int) error {
+	if delta >= 0 {
+		return nil
+	}
+
+	var threshold entity.StockThreshold
+	err := tx.Where("location_id = ? AND blood_type = ? AND rhesus = ?",
+		stock.LocationID, stock.BloodType, stock.Rhesus).
+		First(&threshold).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var after int
+	if err := tx.Model(&entity.Stock{}).
+		Where("location_id = ? AND blood_type = ? AND rhesus = ?",
+			stock.LocationID, stock.BloodType, stock.Rhesus).
+		Select("COALESCE(SUM(bag_quantity), 0)").
+		Scan(&after).Error; err != nil {
+		return err
+	}
+
+	level := threshold.LevelCrossed(after-delta, after)
+	if level == "" {
+		return nil
+	}
+
+	alert := entity.StockAlert{
+		TenantID:    threshold.TenantID,
+		LocationID:  threshold.LocationID,
+		ThresholdID: threshold.ID,
+		BloodType:   threshold.BloodType,
+		Rhesus:      threshold.Rhesus,
+		Level:       level,
+		Quantity:    after,
+		Threshold:   threshold.MinimumLevel,
+	}
+	if level == entity.StockAlertLevelCritical {
+		alert.Threshold = threshold.CriticalLevel
+	}
+	return tx.Create(&alert).Error
+}
+
+// withMovementDefaults mengisi jenis dan referensi ledger bila belum ditentukan pemanggil.
+func withMovementDefaults(movement entity.StockMovement, movementType string, referenceID uuid.UUID) entity.StockMovement {
+	if movement.Type == "" {
+		movement.Type = movementType
+	}
+	if movement.ReferenceID == nil {
+		movement.ReferenceID = &referenceID
+	}
+	return movement
+}
+
+func applyStockFilter(query *gorm.DB, filter repository.StockFilter) *gorm.DB {
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("location_id IN (SELECT id FROM locations WHERE tenant_id = ?)", filter.TenantID)
+	}
+	if filter.BloodType != "" {
+		query = query.Where("blood_type = ?", filter.BloodType)
+	}
+	if filter.Rhesus != "" {
+		query = query.Where("rhesus = ?", filter.Rhesus)
+	}
+	if filter.Component != "" {
+		query = query.Where("component = ?", filter.Component)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	return query
 }
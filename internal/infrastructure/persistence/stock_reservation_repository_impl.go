@@ -0,0 +1,333 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/pkg/compatibility"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockReservationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStockReservationRepository(db *gorm.DB) repository.StockReservationRepository {
+	return &stockReservationRepositoryImpl{db: db}
+}
+
+func (r *stockReservationRepositoryImpl) SaveAndReserve(ctx context.Context, bloodRequest *entity.BloodRequest, today, expiresAt time.Time, movement entity.StockMovement) ([]entity.StockReservation, error) {
+	var reservations []entity.StockReservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(bloodRequest).Error; err != nil {
+			return err
+		}
+
+		var err error
+		reservations, err = reserveUnits(tx, *bloodRequest, today, expiresAt, movement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// UpdateAndReserve menyimpan perubahan permintaan beserta riwayat statusnya, lalu menyusun ulang tahanannya
+// dalam satu transaksi. Permintaan yang tertutup melepas seluruh tahanannya; bila rereserve bernilai true,
+// tahanan lama dilepas dan stok ditahan ulang untuk sisa kebutuhan permintaan.
+func (r *stockReservationRepositoryImpl) UpdateAndReserve(ctx context.Context, bloodRequest entity.BloodRequest, history *entity.BloodRequestStatusHistory, rereserve bool, today, expiresAt time.Time, release, movement entity.StockMovement) (entity.BloodRequest, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version := bloodRequest.Version
+		bloodRequest.Version++
+		if err := updateVersioned(tx, &bloodRequest, version); err != nil {
+			return err
+		}
+		if history != nil {
+			history.BloodRequestID = bloodRequest.ID
+			if err := tx.Create(history).Error; err != nil {
+				return err
+			}
+		}
+
+		if !bloodRequest.IsOpen() || rereserve {
+			if _, err := releaseReservations(tx, bloodRequest.ID, entity.StockReservationReleased, release); err != nil {
+				return err
+			}
+		}
+		if !bloodRequest.IsOpen() || !rereserve {
+			return nil
+		}
+		_, err := reserveUnits(tx, bloodRequest, today, expiresAt, movement)
+		return err
+	})
+	return bloodRequest, err
+}
+
+// ReservePlan mengunci permintaan lalu menahan kantong tiap item rencana dengan kedaluwarsa terdekat (FEFO).
+// Rencana disusun sebelum permintaan dikunci, jadi tahanan aktif dihitung ulang di sini dan tiap item
+// dipotong sampai sisa kebutuhan (Quantity - FulfilledQuantity - tahanan aktif). Permintaan yang sudah
+// ditutup atau sudah tertahan penuh, maupun item yang kantongnya kurang, membatalkan seluruh transaksi.
+func (r *stockReservationRepositoryImpl) ReservePlan(ctx context.Context, bloodRequest entity.BloodRequest, items []repository.ReservationPlanItem, today, expiresAt time.Time, movement entity.StockMovement) ([]entity.StockReservation, error) {
+	var reservations []entity.StockReservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.BloodRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, bloodRequest.ID).Error; err != nil {
+			return err
+		}
+		if !current.IsOpen() {
+			return fmt.Errorf("%w: %s is closed", entity.ErrInvalidRequestStatus, current.Status)
+		}
+
+		var reserved int64
+		if err := tx.Model(&entity.StockReservation{}).
+			Where("blood_request_id = ? AND status = ?", current.ID, entity.StockReservationActive).
+			Count(&reserved).Error; err != nil {
+			return err
+		}
+		remaining := current.Quantity - current.FulfilledQuantity - int(reserved)
+		if remaining <= 0 {
+			return entity.ErrRequestFullyReserved
+		}
+
+		movement = withMovementDefaults(movement, entity.StockMovementReserve, current.ID)
+		for _, item := range items {
+			if remaining <= 0 {
+				break
+			}
+			item.Quantity = min(item.Quantity, remaining)
+			remaining -= item.Quantity
+
+			var units []entity.BloodUnit
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+					item.BloodType, item.Rhesus, item.Component, item.LocationID).
+				Where("status = ? AND expiry_date >= ? AND needs_review = ?", entity.BloodUnitStatusAvailable, today, false).
+				Order("expiry_date ASC, collection_date ASC").
+				Limit(item.Quantity).
+				Find(&units).Error
+			if err != nil {
+				return err
+			}
+			if len(units) < item.Quantity {
+				return fmt.Errorf("%w: %s%s %s at location %s", entity.ErrInsufficientStock,
+					item.BloodType, item.Rhesus, item.Component, item.LocationID)
+			}
+
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+				Update("status", entity.BloodUnitStatusReserved).Error; err != nil {
+				return err
+			}
+			for _, unit := range units {
+				reservations = append(reservations, entity.StockReservation{
+					BloodRequestID: current.ID,
+					BloodUnitID:    unit.ID,
+					BagNumber:      unit.BagNumber,
+					LocationID:     unit.LocationID,
+					BloodType:      unit.BloodType,
+					Rhesus:         unit.Rhesus,
+					Component:      unit.Component,
+					Status:         entity.StockReservationActive,
+					ExpiresAt:      expiresAt,
+				})
+			}
+			if err := adjustStockByGroup(tx, units, -1, movement); err != nil {
+				return err
+			}
+		}
+		if len(reservations) == 0 {
+			return nil
+		}
+		return tx.Create(&reservations).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *stockReservationRepositoryImpl) FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.StockReservation, error) {
+	var reservations []entity.StockReservation
+	err := r.db.WithContext(ctx).Where("blood_request_id = ?", bloodRequestID).
+		Order("created_at ASC, expires_at ASC").Find(&reservations).Error
+	return reservations, err
+}
+
+// ReleaseExpired hanya melepas tahanan aktif sebuah permintaan yang sudah melewati batas waktu.
+// Tahanan yang masih berlaku, misalnya hasil persetujuan rencana pemenuhan, tetap dipertahankan.
+func (r *stockReservationRepositoryImpl) ReleaseExpired(ctx context.Context, bloodRequestID uuid.UUID, now time.Time, movement entity.StockMovement) (int, error) {
+	var released int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reservations []entity.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("blood_request_id = ? AND status = ? AND expires_at < ?", bloodRequestID, entity.StockReservationActive, now).
+			Find(&reservations).Error; err != nil {
+			return err
+		}
+
+		var err error
+		released, err = releaseReservationRows(tx, bloodRequestID, reservations, entity.StockReservationExpired, movement)
+		return err
+	})
+	return released, err
+}
+
+// FindExpiredRequestIDs mengambil permintaan yang masih memiliki tahanan aktif melewati batas waktu.
+func (r *stockReservationRepositoryImpl) FindExpiredRequestIDs(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&entity.StockReservation{}).
+		Where("status = ? AND expires_at < ?", entity.StockReservationActive, now).
+		Distinct().Pluck("blood_request_id", &ids).Error
+	return ids, err
+}
+
+// reserveUnits menahan kantong available yang cocok dengan sisa kebutuhan permintaan (Quantity - FulfilledQuantity),
+// mulai dari yang paling cepat kedaluwarsa. Bila stok kurang, kantong yang ada tetap ditahan; sisa permintaan
+// tidak memiliki tahanan. Permintaan tanpa rhesus atau sisa kebutuhan belum bisa ditahan stoknya.
+func reserveUnits(tx *gorm.DB, bloodRequest entity.BloodRequest, today, expiresAt time.Time, movement entity.StockMovement) ([]entity.StockReservation, error) {
+	needed := bloodRequest.Quantity - bloodRequest.FulfilledQuantity
+	if bloodRequest.Rhesus == "" || needed <= 0 {
+		return nil, nil
+	}
+
+	var units []entity.BloodUnit
+	recipient := compatibility.BloodGroup{BloodType: bloodRequest.BloodType, Rhesus: bloodRequest.Rhesus}
+	err := whereCompatible(tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}), bloodRequest.Component, recipient).
+		Where("location_id = ?", bloodRequest.LocationID).
+		Where("status = ? AND expiry_date >= ? AND needs_review = ?", entity.BloodUnitStatusAvailable, today, false).
+		Order("expiry_date ASC, collection_date ASC").
+		Limit(needed).
+		Find(&units).Error
+	if err != nil || len(units) == 0 {
+		return nil, err
+	}
+
+	if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+		Update("status", entity.BloodUnitStatusReserved).Error; err != nil {
+		return nil, err
+	}
+
+	var reservations []entity.StockReservation
+	for _, unit := range units {
+		reservations = append(reservations, entity.StockReservation{
+			BloodRequestID: bloodRequest.ID,
+			BloodUnitID:    unit.ID,
+			BagNumber:      unit.BagNumber,
+			LocationID:     unit.LocationID,
+			BloodType:      unit.BloodType,
+			Rhesus:         unit.Rhesus,
+			Component:      unit.Component,
+			Status:         entity.StockReservationActive,
+			ExpiresAt:      expiresAt,
+		})
+	}
+	if err := tx.Create(&reservations).Error; err != nil {
+		return nil, err
+	}
+
+	movement = withMovementDefaults(movement, entity.StockMovementReserve, bloodRequest.ID)
+	if err := adjustStockByGroup(tx, units, -1, movement); err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// releaseReservations melepas seluruh tahanan aktif sebuah permintaan di dalam transaksi tx
+// dan mengembalikan kantongnya ke stok. Nilai kembalian adalah jumlah kantong yang dilepas.
+func releaseReservations(tx *gorm.DB, bloodRequestID uuid.UUID, status string, movement entity.StockMovement) (int, error) {
+	var reservations []entity.StockReservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("blood_request_id = ? AND status = ?", bloodRequestID, entity.StockReservationActive).
+		Find(&reservations).Error; err != nil {
+		return 0, err
+	}
+	return releaseReservationRows(tx, bloodRequestID, reservations, status, movement)
+}
+
+// releaseReservationRows menutup tahanan yang sudah dikunci dengan status tertentu dan
+// mengembalikan kantongnya ke available.
+func releaseReservationRows(tx *gorm.DB, bloodRequestID uuid.UUID, reservations []entity.StockReservation, status string, movement entity.StockMovement) (int, error) {
+	if len(reservations) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	if err := tx.Model(&entity.StockReservation{}).Where("id IN ?", stockReservationIDs(reservations)).
+		Updates(map[string]interface{}{"status": status, "released_at": now}).Error; err != nil {
+		return 0, err
+	}
+
+	var units []entity.BloodUnit
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ? AND status = ?", reservedUnitIDs(reservations), entity.BloodUnitStatusReserved).
+		Find(&units).Error; err != nil || len(units) == 0 {
+		return 0, err
+	}
+	if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+		Update("status", entity.BloodUnitStatusAvailable).Error; err != nil {
+		return 0, err
+	}
+
+	// Kantong yang masih menunggu review excursion suhu kembali berstatus available
+	// tetapi baru dihitung di stok setelah dilepas saat review.
+	var restored []entity.BloodUnit
+	for _, unit := range units {
+		unit.Status = entity.BloodUnitStatusAvailable
+		if unit.IsAvailable() {
+			restored = append(restored, unit)
+		}
+	}
+	movement = withMovementDefaults(movement, entity.StockMovementRelease, bloodRequestID)
+	if err := adjustStockByGroup(tx, restored, 1, movement); err != nil {
+		return 0, err
+	}
+	return len(units), nil
+}
+
+// closeUnitReservations menutup tahanan aktif yang menunjuk unit-unit tertentu dengan status yang diberikan,
+// tanpa mengubah status kantong. Dipakai saat kantong keluar dari stok di luar alur permintaan.
+func closeUnitReservations(tx *gorm.DB, unitIDs []uuid.UUID, status string) error {
+	if len(unitIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&entity.StockReservation{}).
+		Where("blood_unit_id IN ? AND status = ?", unitIDs, entity.StockReservationActive).
+		Updates(map[string]interface{}{"status": status, "released_at": time.Now()}).Error
+}
+
+// expireReservationRows menutup tahanan yang sudah dikunci sebagai expired karena kantongnya melewati
+// tanggal kedaluwarsa. Kantong yang ditahan sudah keluar dari stok, jadi kantong langsung ditandai
+// expired tanpa pergerakan stok.
+func expireReservationRows(tx *gorm.DB, reservations []entity.StockReservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+	if err := tx.Model(&entity.StockReservation{}).Where("id IN ?", stockReservationIDs(reservations)).
+		Updates(map[string]interface{}{"status": entity.StockReservationExpired, "released_at": time.Now()}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&entity.BloodUnit{}).
+		Where("id IN ? AND status = ?", reservedUnitIDs(reservations), entity.BloodUnitStatusReserved).
+		Update("status", entity.BloodUnitStatusExpired).Error
+}
+
+func stockReservationIDs(reservations []entity.StockReservation) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(reservations))
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.ID)
+	}
+	return ids
+}
+
+func reservedUnitIDs(reservations []entity.StockReservation) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(reservations))
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.BloodUnitID)
+	}
+	return ids
+}
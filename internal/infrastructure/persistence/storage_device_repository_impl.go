@@ -0,0 +1,239 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type storageDeviceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStorageDeviceRepository(db *gorm.DB) repository.StorageDeviceRepository {
+	return &storageDeviceRepositoryImpl{db: db}
+}
+
+func (r *storageDeviceRepositoryImpl) Save(ctx context.Context, device *entity.StorageDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+
+func (r *storageDeviceRepositoryImpl) FindAll(ctx context.Context, filter repository.StorageDeviceFilter, limit, offset int) ([]entity.StorageDevice, int64, error) {
+	var devices []entity.StorageDevice
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.StorageDevice{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	if filter.DeviceClass != "" {
+		query = query.Where("device_class = ?", filter.DeviceClass)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("name ASC").Limit(limit).Offset(offset).Find(&devices).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return devices, total, nil
+}
+
+func (r *storageDeviceRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.StorageDevice, error) {
+	var device entity.StorageDevice
+	err := r.db.WithContext(ctx).First(&device, id).Error
+	return device, err
+}
+
+func (r *storageDeviceRepositoryImpl) Update(ctx context.Context, device entity.StorageDevice) (entity.StorageDevice, error) {
+	err := r.db.WithContext(ctx).Save(&device).Error
+	return device, err
+}
+
+func (r *storageDeviceRepositoryImpl) CountAssignedUnits(ctx context.Context, id uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&entity.BloodUnit{}).
+		Where("storage_device_id = ?", id).
+		Where("status IN ?", []string{
+			entity.BloodUnitStatusQuarantine,
+			entity.BloodUnitStatusAvailable,
+			entity.BloodUnitStatusReserved,
+			entity.BloodUnitStatusInTransit,
+		}).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *storageDeviceRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.StorageDevice{}, id).Error
+}
+
+// RecordReadings menyimpan bacaan suhu (urut waktu) sekaligus membuka atau menutup excursion.
+// Selama excursion terbuka, unit available/reserved/karantina di alat tersebut ditandai NeedsReview.
+// Baris alat dikunci agar dua pengiriman bacaan tidak membuka excursion ganda.
+func (r *storageDeviceRepositoryImpl) RecordReadings(ctx context.Context, device entity.StorageDevice, readings []entity.TemperatureReading) ([]entity.TemperatureExcursion, error) {
+	var touched []entity.TemperatureExcursion
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&entity.StorageDevice{}, device.ID).Error; err != nil {
+			return err
+		}
+
+		var open *entity.TemperatureExcursion
+		var current entity.TemperatureExcursion
+		err := tx.Where("device_id = ? AND status = ?", device.ID, entity.TemperatureExcursionOpen).
+			Order("started_at DESC").First(&current).Error
+		if err == nil {
+			open = &current
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		minAllowed, maxAllowed := device.AllowedRange()
+		for i := range readings {
+			reading := &readings[i]
+			reading.DeviceID = device.ID
+			reading.OutOfRange = !device.InRange(reading.Temperature)
+			if err := tx.Create(reading).Error; err != nil {
+				return err
+			}
+
+			if !reading.OutOfRange {
+				if open != nil {
+					endedAt := reading.RecordedAt
+					open.EndedAt = &endedAt
+					open.Status = entity.TemperatureExcursionClosed
+					if err := tx.Save(open).Error; err != nil {
+						return err
+					}
+					touched = append(touched, *open)
+					open = nil
+				}
+				continue
+			}
+
+			if open == nil {
+				open = &entity.TemperatureExcursion{
+					TenantID:   device.TenantID,
+					LocationID: device.LocationID,
+					DeviceID:   device.ID,
+					Status:     entity.TemperatureExcursionOpen,
+					StartedAt:  reading.RecordedAt,
+					MinAllowed: minAllowed,
+					MaxAllowed: maxAllowed,
+				}
+			}
+			open.Record(reading.Temperature)
+
+			flagged, err := flagUnitsForReview(tx, device.ID)
+			if err != nil {
+				return err
+			}
+			open.FlaggedUnits += flagged
+
+			if err := tx.Save(open).Error; err != nil {
+				return err
+			}
+		}
+		if open != nil {
+			touched = append(touched, *open)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return touched, nil
+}
+
+// flagUnitsForReview menandai unit available/reserved/karantina di alat simpan agar direview. Unit
+// available keluar dari Stock.BagQuantity selama ditandai dan baru kembali bila dilepas saat review;
+// unit karantina yang lolos skrining tetap di luar stok sampai direview.
+func flagUnitsForReview(tx *gorm.DB, deviceID uuid.UUID) (int, error) {
+	var units []entity.BloodUnit
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("storage_device_id = ? AND needs_review = ?", deviceID, false).
+		Where("status IN ?", []string{
+			entity.BloodUnitStatusAvailable,
+			entity.BloodUnitStatusReserved,
+			entity.BloodUnitStatusQuarantine,
+		}).
+		Find(&units).Error
+	if err != nil || len(units) == 0 {
+		return 0, err
+	}
+
+	if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+		Update("needs_review", true).Error; err != nil {
+		return 0, err
+	}
+
+	for _, unit := range units {
+		if !unit.IsAvailable() {
+			continue
+		}
+		movement := entity.NewStockMovement(entity.StockMovementAdjustment, "Ditahan untuk review excursion suhu", uuid.Nil, &unit.ID)
+		if _, err := adjustStockQuantity(tx, stockKeyOf(unit), -1, movement); err != nil {
+			return 0, err
+		}
+	}
+	return len(units), nil
+}
+
+func (r *storageDeviceRepositoryImpl) FindReadings(ctx context.Context, filter repository.TemperatureReadingFilter, limit, offset int) ([]entity.TemperatureReading, int64, error) {
+	var readings []entity.TemperatureReading
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.TemperatureReading{}).Where("device_id = ?", filter.DeviceID)
+	if !filter.From.IsZero() {
+		query = query.Where("recorded_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("recorded_at < ?", filter.To)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("recorded_at DESC").Limit(limit).Offset(offset).Find(&readings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return readings, total, nil
+}
+
+func (r *storageDeviceRepositoryImpl) FindExcursions(ctx context.Context, filter repository.TemperatureExcursionFilter, limit, offset int) ([]entity.TemperatureExcursion, int64, error) {
+	var excursions []entity.TemperatureExcursion
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.TemperatureExcursion{})
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("location_id = ?", filter.LocationID)
+	}
+	if filter.DeviceID != uuid.Nil {
+		query = query.Where("device_id = ?", filter.DeviceID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("started_at DESC").Limit(limit).Offset(offset).Find(&excursions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return excursions, total, nil
+}
@@ -44,7 +44,9 @@ func (r *tenantRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (enti
 }
 
 func (r *tenantRepositoryImpl) Update(ctx context.Context, tenant entity.Tenant) (entity.Tenant, error) {
-  err := r.db.WithContext(ctx).Save(&tenant).Error
+  version := tenant.Version
+  tenant.Version++
+  err := updateVersioned(r.db.WithContext(ctx), &tenant, version)
   return tenant, err
 }
 
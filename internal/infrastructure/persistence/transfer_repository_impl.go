@@ -0,0 +1,237 @@
+package persistence
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type transferRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) repository.TransferRepository {
+	return &transferRepositoryImpl{db: db}
+}
+
+// Dispatch mengunci kantong available yang belum kedaluwarsa di lokasi asal dengan golongan, rhesus, dan
+// komponen yang sama persis dengan transfer, mulai dari kedaluwarsa terdekat, lalu mengurangi stoknya.
+// Transfer memindahkan stok golongan tertentu, bukan mencocokkan resipien, jadi golongan pengganti tidak dipakai.
+func (r *transferRepositoryImpl) Dispatch(ctx context.Context, transfer *entity.Transfer, today time.Time, actorID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var units []entity.BloodUnit
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("blood_type = ? AND rhesus = ? AND component = ? AND location_id = ?",
+				transfer.BloodType, transfer.Rhesus, transfer.Component, transfer.SourceLocationID).
+			Where("status = ? AND expiry_date >= ? AND needs_review = ?", entity.BloodUnitStatusAvailable, today, false).
+			Order("expiry_date ASC, collection_date ASC").
+			Limit(transfer.Quantity).
+			Find(&units).Error
+		if err != nil {
+			return err
+		}
+		if len(units) < transfer.Quantity {
+			return entity.ErrInsufficientStock
+		}
+
+		if err := tx.Create(transfer).Error; err != nil {
+			return err
+		}
+
+		transferUnits := make([]entity.TransferUnit, 0, len(units))
+		for _, unit := range units {
+			transferUnits = append(transferUnits, entity.TransferUnit{
+				TransferID:  transfer.ID,
+				BloodUnitID: unit.ID,
+				BagNumber:   unit.BagNumber,
+			})
+		}
+		if err := tx.Create(&transferUnits).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+			Update("status", entity.BloodUnitStatusInTransit).Error; err != nil {
+			return err
+		}
+
+		out := entity.NewStockMovement(entity.StockMovementTransferOut, "Transfer ke lokasi lain", actorID, &transfer.ID)
+		return adjustStockByGroup(tx, units, -1, out)
+	})
+}
+
+func (r *transferRepositoryImpl) FindAll(ctx context.Context, filter repository.TransferFilter, limit, offset int) ([]entity.Transfer, int64, error) {
+	var transfers []entity.Transfer
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Transfer{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.LocationID != uuid.Nil {
+		query = query.Where("source_location_id = ? OR destination_location_id = ?", filter.LocationID, filter.LocationID)
+	}
+	if filter.TenantID != uuid.Nil {
+		query = query.Where("source_location_id IN (SELECT id FROM locations WHERE tenant_id = ?) OR destination_location_id IN (SELECT id FROM locations WHERE tenant_id = ?)",
+			filter.TenantID, filter.TenantID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&transfers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return transfers, total, nil
+}
+
+func (r *transferRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Transfer, error) {
+	var transfer entity.Transfer
+	err := r.db.WithContext(ctx).First(&transfer, id).Error
+	return transfer, err
+}
+
+func (r *transferRepositoryImpl) Ship(ctx context.Context, id uuid.UUID) (entity.Transfer, error) {
+	var transfer entity.Transfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id).Error; err != nil {
+			return err
+		}
+		if transfer.Status != entity.TransferStatusDispatched {
+			return entity.ErrInvalidTransferStatus
+		}
+
+		now := time.Now()
+		transfer.Status = entity.TransferStatusInTransit
+		transfer.ShippedAt = &now
+		return tx.Save(&transfer).Error
+	})
+	return transfer, err
+}
+
+func (r *transferRepositoryImpl) Receive(ctx context.Context, transfer entity.Transfer, actorID uuid.UUID) (entity.Transfer, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.Transfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, transfer.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != entity.TransferStatusInTransit {
+			return entity.ErrInvalidTransferStatus
+		}
+
+		units, err := lockTransferUnits(tx, transfer.ID)
+		if err != nil {
+			return err
+		}
+		if transfer.ReceivedQuantity > len(units) {
+			return entity.ErrInvalidReceivedQuantity
+		}
+
+		received := units[:transfer.ReceivedQuantity]
+		lost := units[transfer.ReceivedQuantity:]
+
+		if len(received) > 0 {
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(received)).
+				Updates(map[string]interface{}{
+					"location_id":       transfer.DestinationLocationID,
+					"storage_device_id": nil,
+					"status":            entity.BloodUnitStatusAvailable,
+				}).Error; err != nil {
+				return err
+			}
+
+			for i := range received {
+				received[i].LocationID = transfer.DestinationLocationID
+			}
+			in := entity.NewStockMovement(entity.StockMovementTransferIn, "Transfer dari lokasi lain", actorID, &transfer.ID)
+			if err := adjustStockByGroup(tx, received, 1, in); err != nil {
+				return err
+			}
+		}
+
+		// Stok asal sudah berkurang saat dispatch, jadi kantong yang tidak diterima utuh dimusnahkan dan
+		// dicatat di ledger stok asal tanpa mengubah jumlahnya.
+		if len(lost) > 0 {
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(lost)).
+				Update("status", entity.BloodUnitStatusDiscarded).Error; err != nil {
+				return err
+			}
+			for _, unit := range lost {
+				loss := entity.NewStockMovement(entity.StockMovementTransferLoss,
+					"Hilang atau rusak dalam transfer: kantong "+unit.BagNumber, actorID, &transfer.ID)
+				if err := recordStockMovement(tx, stockKeyOf(unit), loss); err != nil {
+					return err
+				}
+			}
+		}
+
+		now := time.Now()
+		transfer.Status = entity.TransferStatusReceived
+		transfer.ReceivedBy = &actorID
+		transfer.ReceivedAt = &now
+		return tx.Save(&transfer).Error
+	})
+	return transfer, err
+}
+
+func (r *transferRepositoryImpl) Reject(ctx context.Context, transfer entity.Transfer, actorID uuid.UUID) (entity.Transfer, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.Transfer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, transfer.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != entity.TransferStatusDispatched && current.Status != entity.TransferStatusInTransit {
+			return entity.ErrInvalidTransferStatus
+		}
+
+		units, err := lockTransferUnits(tx, transfer.ID)
+		if err != nil {
+			return err
+		}
+		if len(units) > 0 {
+			if err := tx.Model(&entity.BloodUnit{}).Where("id IN ?", bloodUnitIDs(units)).
+				Update("status", entity.BloodUnitStatusAvailable).Error; err != nil {
+				return err
+			}
+
+			back := entity.NewStockMovement(entity.StockMovementTransferIn, "Transfer ditolak, kembali ke lokasi asal", actorID, &transfer.ID)
+			if err := adjustStockByGroup(tx, units, 1, back); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		transfer.Status = entity.TransferStatusRejected
+		transfer.ReceivedBy = &actorID
+		transfer.ReceivedAt = &now
+		return tx.Save(&transfer).Error
+	})
+	return transfer, err
+}
+
+// lockTransferUnits mengunci kantong yang dikirim dalam transfer dan masih berstatus in_transit,
+// diurutkan dari kedaluwarsa terdekat.
+func lockTransferUnits(tx *gorm.DB, transferID uuid.UUID) ([]entity.BloodUnit, error) {
+	var units []entity.BloodUnit
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN (SELECT blood_unit_id FROM transfer_units WHERE transfer_id = ?)", transferID).
+		Where("status = ?", entity.BloodUnitStatusInTransit).
+		Order("expiry_date ASC, collection_date ASC").
+		Find(&units).Error
+	return units, err
+}
+
+func bloodUnitIDs(units []entity.BloodUnit) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(units))
+	for _, unit := range units {
+		ids = append(ids, unit.ID)
+	}
+	return ids
+}
@@ -33,6 +33,12 @@ func (r *userRepositoryImpl) FindAll(ctx context.Context, limit, offset int) ([]
 	return users, total, nil
 }
 
+func (r *userRepositoryImpl) FindByTenantRole(ctx context.Context, tenantID uuid.UUID, role string) ([]entity.User, error) {
+	var users []entity.User
+	err := r.db.WithContext(ctx).Where("tenant_id = ? AND role = ?", tenantID, role).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
 	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"donor-api/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+// updateVersioned menyimpan seluruh kolom model hanya bila versi di database masih
+// sama dengan version. Pemanggil menaikkan field Version sebelum memanggil fungsi ini.
+func updateVersioned(db *gorm.DB, model interface{}, version int) error {
+	result := db.Model(model).Where("version = ?", version).Select("*").Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BloodRequestFulfillmentRepository interface {
+	// Issue mengeluarkan kantong untuk permintaan, mencatat penyerahannya, dan memperbarui
+	// jumlah terpenuhi serta status permintaan dalam satu transaksi. Tahanan yang tersisa dilepas
+	// dengan release di transaksi yang sama begitu permintaan terpenuhi.
+	Issue(ctx context.Context, bloodRequest entity.BloodRequest, fulfillment *entity.BloodRequestFulfillment, today time.Time, movement, release entity.StockMovement) (entity.BloodRequest, error)
+	FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.BloodRequestFulfillment, error)
+}
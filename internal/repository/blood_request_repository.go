@@ -3,14 +3,49 @@ package repository
 import (
   "context"
   "donor-api/internal/entity"
+  "time"
 
   "github.com/google/uuid"
 )
 
+// BloodRequestFilter membatasi daftar permintaan darah. Nilai kosong berarti tanpa filter.
+type BloodRequestFilter struct {
+  TenantID  uuid.UUID
+  CreatedBy uuid.UUID
+}
+
+// PublicFeedFilter menyaring umpan publik permintaan darah. Nilai kosong berarti tanpa filter.
+type PublicFeedFilter struct {
+  City      string
+  BloodType string
+}
+
+// PublicBloodRequest adalah permintaan darah berjalan tanpa data pasien maupun pemohon.
+type PublicBloodRequest struct {
+  BloodType    string
+  Rhesus       string
+  Quantity     int // sisa kantong yang belum diserahkan
+  City         string
+  LocationName string
+  Urgency      string
+}
+
 type BloodRequestRepository interface {
   Save(ctx context.Context, bloodRequest *entity.BloodRequest) error
-  FindAll(ctx context.Context, limit, offset int) ([]entity.BloodRequest, int64, error)
+  FindAll(ctx context.Context, filter BloodRequestFilter, limit, offset int) ([]entity.BloodRequest, int64, error)
   FindByID(ctx context.Context, id uuid.UUID) (entity.BloodRequest, error)
+  // FindQueue mengambil permintaan yang masih berjalan, diurutkan menurut urgensi lalu umur permintaan.
+  FindQueue(ctx context.Context, filter BloodRequestFilter, limit, offset int) ([]entity.BloodRequest, int64, error)
+  // FindPublicFeed mengambil permintaan berjalan dalam bentuk anonim dengan urutan yang sama seperti FindQueue.
+  FindPublicFeed(ctx context.Context, filter PublicFeedFilter, limit, offset int) ([]PublicBloodRequest, int64, error)
+  // FindUnescalated mengambil permintaan berjalan yang belum dieskalasi dan dibuat sebelum createdBefore.
+  FindUnescalated(ctx context.Context, createdBefore time.Time) ([]entity.BloodRequest, error)
+  // Escalate menandai permintaan sudah dieskalasi dan menyimpan notifikasinya dalam satu transaksi.
+  Escalate(ctx context.Context, bloodRequest entity.BloodRequest, notifications []entity.Notification) (bool, error)
   Update(ctx context.Context, bloodRequest entity.BloodRequest) (entity.BloodRequest, error)
-  Delete(ctx context.Context, id uuid.UUID) error
+  // UpdateStatus menyimpan status baru beserta riwayatnya dan melepas tahanan bila permintaan ditutup.
+  UpdateStatus(ctx context.Context, bloodRequest entity.BloodRequest, history entity.BloodRequestStatusHistory, release entity.StockMovement) (entity.BloodRequest, error)
+  FindStatusHistory(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.BloodRequestStatusHistory, error)
+  // Delete melepas tahanan lalu menghapus permintaan beserta riwayat status dan tahanannya dalam satu transaksi.
+  Delete(ctx context.Context, id uuid.UUID, release entity.StockMovement) error
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type BloodUnitFilter struct {
+	Status      string
+	BloodType   string
+	Rhesus      string
+	Component   string
+	LocationID  uuid.UUID
+	DonationID  uuid.UUID
+	TenantID    uuid.UUID
+	NeedsReview *bool
+}
+
+type BloodUnitRepository interface {
+	Save(ctx context.Context, unit *entity.BloodUnit, movement entity.StockMovement) error
+	SaveDonationComponents(ctx context.Context, donationID uuid.UUID, units []entity.BloodUnit, movement entity.StockMovement) error
+	FindAll(ctx context.Context, filter BloodUnitFilter, limit, offset int) ([]entity.BloodUnit, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.BloodUnit, error)
+	// Update menerapkan perubahan dari after ke baris unit yang dikunci. before adalah unit yang dibaca
+	// pemanggil; bila status, tanda review, atau lokasinya sudah berubah, perubahan ditolak.
+	Update(ctx context.Context, before, after entity.BloodUnit, movement entity.StockMovement) (entity.BloodUnit, error)
+	Delete(ctx context.Context, id uuid.UUID, movement entity.StockMovement) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/pkg/compatibility"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DonorCandidateFilter membatasi pendonor yang dapat dipanggil untuk sebuah permintaan darah.
+type DonorCandidateFilter struct {
+	BloodGroups []compatibility.BloodGroup
+	// Day menyaring pendonor yang masih memiliki penundaan aktif pada tanggal tersebut.
+	Day time.Time
+	// ExcludeRequestID melewati pendonor yang sudah dipanggil untuk permintaan yang sama.
+	ExcludeRequestID uuid.UUID
+	// Latitude dan Longitude adalah titik asal panggilan. Pendonor disaring dengan kotak batas RadiusKm
+	// di sekitar titik ini dan diurutkan dari yang terdekat.
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+type DonorCallUpRepository interface {
+	FindCandidates(ctx context.Context, filter DonorCandidateFilter) ([]entity.UserDetail, error)
+	Create(ctx context.Context, callUp *entity.DonorCallUp, notifications []entity.Notification) error
+	FindByID(ctx context.Context, id uuid.UUID) (entity.DonorCallUp, error)
+	FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.DonorCallUp, error)
+	FindRecipient(ctx context.Context, callUpID, userID uuid.UUID) (entity.DonorCallUpRecipient, error)
+	UpdateRecipient(ctx context.Context, recipient entity.DonorCallUpRecipient) (entity.DonorCallUpRecipient, error)
+}
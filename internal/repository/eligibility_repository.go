@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EligibilityRepository interface {
+	FindPolicy(ctx context.Context, tenantID uuid.UUID) (entity.EligibilityPolicy, error)
+	SavePolicy(ctx context.Context, policy *entity.EligibilityPolicy) error
+	FindDonorDetail(ctx context.Context, userID uuid.UUID) (entity.UserDetail, error)
+	// FindLastCompletedDonation mengembalikan donasi selesai terakhir milik pendonor sampai tanggal day,
+	// atau nil bila belum pernah ada.
+	FindLastCompletedDonation(ctx context.Context, userID uuid.UUID, day time.Time) (*entity.Donation, error)
+	FindActiveDeferrals(ctx context.Context, userID uuid.UUID, day time.Time) ([]entity.DonorDeferral, error)
+}
@@ -3,13 +3,33 @@ package repository
 import (
 	"context"
 	"donor-api/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// EventFilter membatasi daftar acara. Nilai kosong berarti tanpa filter.
+type EventFilter struct {
+	TenantID  uuid.UUID
+	CreatedBy uuid.UUID
+}
+
+// PublicEvent adalah acara yang ditampilkan tanpa autentikasi beserta nama dan kota lokasinya.
+type PublicEvent struct {
+	EventName    string
+	Slug         string
+	Description  string
+	StartDate    time.Time
+	EndDate      time.Time
+	LocationName string
+	City         string
+}
+
 type EventRepository interface {
 	Save(ctx context.Context, event *entity.Event) error
-	FindAll(ctx context.Context, limit, offset int) ([]entity.Event, int64, error)
+	FindAll(ctx context.Context, filter EventFilter, limit, offset int) ([]entity.Event, int64, error)
+	// FindPublic mengambil acara yang belum berakhir pada tanggal from, yang paling dekat lebih dulu.
+	FindPublic(ctx context.Context, city string, from time.Time, limit, offset int) ([]PublicEvent, int64, error)
 	FindByID(ctx context.Context, id uuid.UUID) (entity.Event, error)
 	Update(ctx context.Context, event entity.Event) (entity.Event, error)
 	Delete(ctx context.Context, id uuid.UUID) error
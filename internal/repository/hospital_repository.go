@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type HospitalFilter struct {
+	TenantID uuid.UUID
+	City     string
+}
+
+type HospitalRepository interface {
+	Save(ctx context.Context, hospital *entity.Hospital) error
+	FindAll(ctx context.Context, filter HospitalFilter, limit, offset int) ([]entity.Hospital, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.Hospital, error)
+	Update(ctx context.Context, hospital entity.Hospital) (entity.Hospital, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
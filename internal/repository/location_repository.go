@@ -12,6 +12,8 @@ type LocationRepository interface {
 	FindAll(ctx context.Context, limit, offset int) ([]entity.Location, int64, error)
 	FindByID(ctx context.Context, id uuid.UUID) (entity.Location, error)
 	FindByTenantID(ctx context.Context, limit, offset int, tenantID uuid.UUID) ([]entity.Location, int64, error)
+	// FindAllByTenantID mengambil seluruh lokasi milik tenant tanpa paginasi.
+	FindAllByTenantID(ctx context.Context, tenantID uuid.UUID) ([]entity.Location, error)
 	Update(ctx context.Context, location entity.Location) (entity.Location, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
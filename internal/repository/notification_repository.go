@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type NotificationFilter struct {
+	UserID uuid.UUID
+	Unread *bool
+}
+
+type NotificationRepository interface {
+	FindAll(ctx context.Context, filter NotificationFilter, limit, offset int) ([]entity.Notification, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.Notification, error)
+	MarkRead(ctx context.Context, notification entity.Notification) (entity.Notification, error)
+}
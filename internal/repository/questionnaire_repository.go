@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type QuestionnaireRepository interface {
+	// Create menyimpan kuesioner beserta pertanyaannya sebagai versi berikutnya milik tenant.
+	Create(ctx context.Context, questionnaire *entity.Questionnaire) error
+	FindAll(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]entity.Questionnaire, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.Questionnaire, error)
+	// FindCurrent mengambil versi tertinggi kuesioner tenant.
+	FindCurrent(ctx context.Context, tenantID uuid.UUID) (entity.Questionnaire, error)
+	FindSubmission(ctx context.Context, donationID uuid.UUID) (entity.QuestionnaireSubmission, error)
+	// Submit menyimpan jawaban beserta penundaan yang dipicunya; donasi yang ditunda dibatalkan.
+	Submit(ctx context.Context, submission *entity.QuestionnaireSubmission, deferrals []entity.DonorDeferral) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type DonorDeferralFilter struct {
+	UserID          uuid.UUID
+	TenantID        uuid.UUID // donasi yang ditunda berada di lokasi milik tenant ini
+	FollowUpPending *bool
+}
+
+type ScreeningRepository interface {
+	FindByDonationID(ctx context.Context, donationID uuid.UUID) ([]entity.ScreeningResult, error)
+	Record(ctx context.Context, donationID uuid.UUID, results []entity.ScreeningResult, actorID uuid.UUID) (entity.Donation, error)
+	FindDeferrals(ctx context.Context, filter DonorDeferralFilter, limit, offset int) ([]entity.DonorDeferral, int64, error)
+	FindDeferralByID(ctx context.Context, id uuid.UUID) (entity.DonorDeferral, error)
+	UpdateDeferral(ctx context.Context, deferral entity.DonorDeferral) (entity.DonorDeferral, error)
+	FindVitalSigns(ctx context.Context, donationID uuid.UUID) (entity.VitalSigns, error)
+	// RecordVitalSigns menyimpan pemeriksaan pra-donor, memperbarui berat badan pendonor, dan
+	// membatalkan donasi bila hasilnya tidak diterima. deferral boleh nil.
+	RecordVitalSigns(ctx context.Context, vitals *entity.VitalSigns, deferral *entity.DonorDeferral) (entity.Donation, error)
+}
@@ -3,14 +3,106 @@ package repository
 import (
 	"context"
 	"donor-api/internal/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type StockFilter struct {
+	TenantID   uuid.UUID
+	BloodType  string
+	Rhesus     string
+	Component  string
+	LocationID uuid.UUID
+}
+
+// StockAggregate adalah total kantong per golongan, rhesus, dan komponen.
+type StockAggregate struct {
+	BloodType   string
+	Rhesus      string
+	Component   string
+	BagQuantity int64
+}
+
+type StockAvailabilityFilter struct {
+	City      string
+	Component string
+}
+
+// StockAvailabilityRow adalah jumlah kantong available per lokasi, golongan, dan rhesus.
+// BloodType dan Rhesus kosong untuk lokasi yang belum memiliki stok.
+type StockAvailabilityRow struct {
+	TenantID     uuid.UUID
+	LocationID   uuid.UUID
+	LocationName string
+	City         string
+	BloodType    string
+	Rhesus       string
+	BagQuantity  int64
+}
+
+// ConsumptionFilter membatasi data pemakaian historis untuk forecasting.
+type ConsumptionFilter struct {
+	TenantID   uuid.UUID
+	LocationID uuid.UUID
+	Component  string
+	Rhesus     string
+}
+
+// DailyConsumption adalah jumlah kantong yang terpakai per hari, golongan darah, dan rhesus.
+type DailyConsumption struct {
+	Day       time.Time
+	BloodType string
+	Rhesus    string
+	Quantity  int64
+}
+
+type StockThresholdFilter struct {
+	TenantID   uuid.UUID
+	LocationID uuid.UUID
+}
+
+type StockAlertFilter struct {
+	TenantID     uuid.UUID
+	LocationID   uuid.UUID
+	Level        string
+	Acknowledged *bool
+}
+
 type StockRepository interface {
 	Save(ctx context.Context, stock *entity.Stock) error
-	FindAll(ctx context.Context, limit, offset int) ([]entity.Stock, int64, error)
+	FindAll(ctx context.Context, filter StockFilter, limit, offset int) ([]entity.Stock, int64, error)
+	// FindByFilter mengambil seluruh stok yang cocok dengan filter tanpa paginasi.
+	FindByFilter(ctx context.Context, filter StockFilter) ([]entity.Stock, error)
+	Summarize(ctx context.Context, filter StockFilter) ([]StockAggregate, error)
+	Availability(ctx context.Context, filter StockAvailabilityFilter) ([]StockAvailabilityRow, error)
 	FindByID(ctx context.Context, id uuid.UUID) (entity.Stock, error)
 	Update(ctx context.Context, stock entity.Stock) (entity.Stock, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AllocateUnits mengeluarkan unit available dengan kedaluwarsa terdekat (FEFO).
+	AllocateUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, movement entity.StockMovement) ([]entity.BloodUnit, error)
+	// ReceiveUnits mendaftarkan kantong baru dari donasi yang sudah lolos skrining dan belum pernah
+	// menjadi unit, lalu menambah stoknya.
+	ReceiveUnits(ctx context.Context, units []entity.BloodUnit, movement entity.StockMovement) error
+	// WriteOffUnits memusnahkan unit available dengan kedaluwarsa terdekat (FEFO).
+	WriteOffUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, movement entity.StockMovement) ([]entity.BloodUnit, error)
+	// ExpireUnits menandai unit yang sudah lewat tanggal kedaluwarsa dan mengeluarkannya dari stok.
+	ExpireUnits(ctx context.Context, today time.Time) ([]entity.BloodUnit, error)
+
+	// ledger
+	FindMovements(ctx context.Context, stockID uuid.UUID, limit, offset int) ([]entity.StockMovement, int64, error)
+	SumMovements(ctx context.Context, stockID uuid.UUID) (int64, error)
+	CountAvailableUnits(ctx context.Context, stock entity.Stock) (int64, error)
+	DailyIssued(ctx context.Context, filter ConsumptionFilter, from, to time.Time) ([]DailyConsumption, error)
+	DailyReservedIssued(ctx context.Context, filter ConsumptionFilter, from, to time.Time) ([]DailyConsumption, error)
+
+	// threshold & alert
+	SaveThreshold(ctx context.Context, threshold *entity.StockThreshold) error
+	FindThresholds(ctx context.Context, filter StockThresholdFilter) ([]entity.StockThreshold, error)
+	FindThresholdByID(ctx context.Context, id uuid.UUID) (entity.StockThreshold, error)
+	DeleteThreshold(ctx context.Context, id uuid.UUID) error
+	FindAlerts(ctx context.Context, filter StockAlertFilter, limit, offset int) ([]entity.StockAlert, int64, error)
+	FindAlertByID(ctx context.Context, id uuid.UUID) (entity.StockAlert, error)
+	AcknowledgeAlert(ctx context.Context, alert entity.StockAlert) (entity.StockAlert, error)
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationPlanItem adalah jumlah kantong satu golongan di satu lokasi yang akan ditahan dari rencana pemenuhan.
+type ReservationPlanItem struct {
+	LocationID uuid.UUID
+	BloodType  string
+	Rhesus     string
+	Component  string
+	Quantity   int
+}
+
+type StockReservationRepository interface {
+	// SaveAndReserve membuat permintaan darah dan menahan stoknya dalam satu transaksi.
+	SaveAndReserve(ctx context.Context, bloodRequest *entity.BloodRequest, today, expiresAt time.Time, movement entity.StockMovement) ([]entity.StockReservation, error)
+	// UpdateAndReserve menyimpan perubahan permintaan dan riwayat statusnya, lalu melepas dan menahan ulang
+	// stoknya dalam satu transaksi.
+	UpdateAndReserve(ctx context.Context, bloodRequest entity.BloodRequest, history *entity.BloodRequestStatusHistory, rereserve bool, today, expiresAt time.Time, release, movement entity.StockMovement) (entity.BloodRequest, error)
+	// ReservePlan menahan seluruh kantong dari rencana pemenuhan dalam satu transaksi; bila salah satu
+	// item tidak lagi tersedia, tidak ada kantong yang ditahan. Jumlah yang ditahan tidak pernah melebihi
+	// sisa kebutuhan permintaan saat transaksi berjalan.
+	ReservePlan(ctx context.Context, bloodRequest entity.BloodRequest, items []ReservationPlanItem, today, expiresAt time.Time, movement entity.StockMovement) ([]entity.StockReservation, error)
+	FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.StockReservation, error)
+	// ReleaseExpired melepas tahanan aktif permintaan yang expires_at-nya sudah lewat dari now.
+	ReleaseExpired(ctx context.Context, bloodRequestID uuid.UUID, now time.Time, movement entity.StockMovement) (int, error)
+	FindExpiredRequestIDs(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StorageDeviceFilter struct {
+	TenantID    uuid.UUID
+	LocationID  uuid.UUID
+	DeviceClass string
+}
+
+type TemperatureReadingFilter struct {
+	DeviceID uuid.UUID
+	From     time.Time
+	To       time.Time
+}
+
+type TemperatureExcursionFilter struct {
+	TenantID   uuid.UUID
+	LocationID uuid.UUID
+	DeviceID   uuid.UUID
+	Status     string
+}
+
+type StorageDeviceRepository interface {
+	Save(ctx context.Context, device *entity.StorageDevice) error
+	FindAll(ctx context.Context, filter StorageDeviceFilter, limit, offset int) ([]entity.StorageDevice, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.StorageDevice, error)
+	Update(ctx context.Context, device entity.StorageDevice) (entity.StorageDevice, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// CountAssignedUnits menghitung unit yang masih disimpan di alat (belum dikeluarkan, dimusnahkan, atau kedaluwarsa).
+	CountAssignedUnits(ctx context.Context, id uuid.UUID) (int64, error)
+	RecordReadings(ctx context.Context, device entity.StorageDevice, readings []entity.TemperatureReading) ([]entity.TemperatureExcursion, error)
+	FindReadings(ctx context.Context, filter TemperatureReadingFilter, limit, offset int) ([]entity.TemperatureReading, int64, error)
+	FindExcursions(ctx context.Context, filter TemperatureExcursionFilter, limit, offset int) ([]entity.TemperatureExcursion, int64, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferFilter struct {
+	Status     string
+	LocationID uuid.UUID // lokasi asal atau tujuan
+	TenantID   uuid.UUID // lokasi asal atau tujuan milik tenant ini
+}
+
+type TransferRepository interface {
+	// Dispatch mengunci kantong available yang belum kedaluwarsa per today dengan golongan persis transfer
+	// di lokasi asal, menandainya in_transit, dan mencatat pengurangan stok asal dalam transaksi yang sama
+	// dengan pembuatan transfer.
+	Dispatch(ctx context.Context, transfer *entity.Transfer, today time.Time, actorID uuid.UUID) error
+	FindAll(ctx context.Context, filter TransferFilter, limit, offset int) ([]entity.Transfer, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (entity.Transfer, error)
+
+	// Ship menandai transfer dispatched menjadi in_transit setelah mengunci barisnya.
+	Ship(ctx context.Context, id uuid.UUID) (entity.Transfer, error)
+
+	// Receive memindahkan kantong yang dikunci saat dispatch ke lokasi tujuan dan mencatat
+	// penambahan stok tujuan dalam satu transaksi. Kantong yang tidak diterima utuh dimusnahkan.
+	Receive(ctx context.Context, transfer entity.Transfer, actorID uuid.UUID) (entity.Transfer, error)
+
+	// Reject membatalkan transfer dan mengembalikan kantongnya ke stok lokasi asal.
+	Reject(ctx context.Context, transfer entity.Transfer, actorID uuid.UUID) (entity.Transfer, error)
+}
@@ -12,6 +12,7 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindAll(ctx context.Context, limit, offset int) ([]entity.User, int64, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	FindByTenantRole(ctx context.Context, tenantID uuid.UUID, role string) ([]entity.User, error)
 	UpdateUser(ctx context.Context, user *entity.User) error
 
 	// user detail
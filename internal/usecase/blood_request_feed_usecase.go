@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/repository"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// --- Interface ---
+type BloodRequestFeedUsecase interface {
+	FindPublic(ctx context.Context, filter repository.PublicFeedFilter, page, limit int) (dto.PaginatedResponse[dto.PublicBloodRequestResponse], error)
+}
+
+// --- Implementation ---
+type feedCacheEntry struct {
+	response  dto.PaginatedResponse[dto.PublicBloodRequestResponse]
+	expiresAt time.Time
+}
+
+type bloodRequestFeedUsecaseImpl struct {
+	repo     repository.BloodRequestRepository
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]feedCacheEntry
+}
+
+func NewBloodRequestFeedUsecase(repo repository.BloodRequestRepository, cacheTTL time.Duration) BloodRequestFeedUsecase {
+	return &bloodRequestFeedUsecaseImpl{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		cache:    map[string]feedCacheEntry{},
+	}
+}
+
+// FindPublic mengembalikan umpan anonim permintaan darah berjalan. Hasil per kombinasi filter
+// dan halaman disimpan di memori selama cacheTTL sehingga lonjakan akses publik tidak membebani basis data.
+func (uc *bloodRequestFeedUsecaseImpl) FindPublic(ctx context.Context, filter repository.PublicFeedFilter, page, limit int) (dto.PaginatedResponse[dto.PublicBloodRequestResponse], error) {
+	key := fmt.Sprintf("%s|%s|%d|%d", filter.City, filter.BloodType, page, limit)
+	now := time.Now()
+
+	uc.mu.Lock()
+	entry, ok := uc.cache[key]
+	uc.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.response, nil
+	}
+
+	offset := (page - 1) * limit
+	rows, total, err := uc.repo.FindPublicFeed(ctx, filter, limit, offset)
+	if err != nil {
+		return dto.PaginatedResponse[dto.PublicBloodRequestResponse]{}, err
+	}
+
+	items := make([]dto.PublicBloodRequestResponse, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, dto.PublicBloodRequestResponse{
+			BloodType:    row.BloodType,
+			Rhesus:       row.Rhesus,
+			Quantity:     row.Quantity,
+			City:         row.City,
+			LocationName: row.LocationName,
+			Urgency:      row.Urgency,
+		})
+	}
+	response := dto.PaginatedResponse[dto.PublicBloodRequestResponse]{
+		Data:       items,
+		TotalItems: total,
+		Page:       page,
+		Limit:      limit,
+	}
+
+	uc.mu.Lock()
+	for k, e := range uc.cache {
+		if !now.Before(e.expiresAt) {
+			delete(uc.cache, k)
+		}
+	}
+	uc.cache[key] = feedCacheEntry{response: response, expiresAt: now.Add(uc.cacheTTL)}
+	uc.mu.Unlock()
+
+	return response, nil
+}
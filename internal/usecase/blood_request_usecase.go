@@ -3,52 +3,114 @@ package usecase
 import (
   "context"
   "donor-api/internal/delivery/http/dto"
+  "donor-api/internal/delivery/http/helper"
   "donor-api/internal/entity"
   "donor-api/internal/repository"
+  "donor-api/pkg/compatibility"
+  "fmt"
+  "log"
+  "sort"
+  "time"
 
   "github.com/google/uuid"
   "github.com/jinzhu/copier"
+  "gorm.io/gorm"
 )
 
 // --- Interface ---
 type BloodRequestUsecase interface {
-  Create(ctx context.Context, req dto.BloodRequestRequest) (dto.BloodRequestResponse, error)
-  FindAll(ctx context.Context, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error)
-  FindByID(ctx context.Context, id uuid.UUID) (dto.BloodRequestResponse, error)
-  Update(ctx context.Context, id uuid.UUID, req dto.BloodRequestRequest) (dto.BloodRequestResponse, error)
-  Delete(ctx context.Context, id uuid.UUID) error
+  Create(ctx context.Context, req dto.BloodRequestRequest, actor dto.Actor) (dto.BloodRequestResponse, error)
+  FindAll(ctx context.Context, actor dto.Actor, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error)
+  FindQueue(ctx context.Context, actor dto.Actor, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error)
+  FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestResponse, error)
+  Update(ctx context.Context, id uuid.UUID, req dto.BloodRequestRequest, actor dto.Actor, version int) (dto.BloodRequestResponse, error)
+  UpdateStatus(ctx context.Context, id uuid.UUID, req dto.UpdateBloodRequestStatusDTO, actor dto.Actor, version int) (dto.BloodRequestResponse, error)
+  Issue(ctx context.Context, id uuid.UUID, req dto.IssueBloodRequestDTO, actor dto.Actor, version int) (dto.BloodRequestResponse, error)
+  Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error
+  MatchPlan(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestMatchPlanResponse, error)
+  ApproveMatchPlan(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestResponse, error)
+  ReleaseExpiredReservations(ctx context.Context) (int, error)
+  EscalateOverdue(ctx context.Context) (int, error)
 }
 
 // --- Implementation ---
 type bloodRequestUsecaseImpl struct {
-  repo repository.BloodRequestRepository
+  repo            repository.BloodRequestRepository
+  reservationRepo repository.StockReservationRepository
+  fulfillmentRepo repository.BloodRequestFulfillmentRepository
+  stockRepo       repository.StockRepository
+  locationRepo    repository.LocationRepository
+  hospitalRepo    repository.HospitalRepository
+  userRepo        repository.UserRepository
+  reservationTTL  time.Duration
 }
 
-func NewBloodRequestUsecase(repo repository.BloodRequestRepository) BloodRequestUsecase {
-  return &bloodRequestUsecaseImpl{repo: repo}
+func NewBloodRequestUsecase(repo repository.BloodRequestRepository, reservationRepo repository.StockReservationRepository, fulfillmentRepo repository.BloodRequestFulfillmentRepository, stockRepo repository.StockRepository, locationRepo repository.LocationRepository, hospitalRepo repository.HospitalRepository, userRepo repository.UserRepository, reservationTTL time.Duration) BloodRequestUsecase {
+  return &bloodRequestUsecaseImpl{
+    repo:            repo,
+    reservationRepo: reservationRepo,
+    fulfillmentRepo: fulfillmentRepo,
+    stockRepo:       stockRepo,
+    locationRepo:    locationRepo,
+    hospitalRepo:    hospitalRepo,
+    userRepo:        userRepo,
+    reservationTTL:  reservationTTL,
+  }
 }
 
-func (uc *bloodRequestUsecaseImpl) Create(ctx context.Context, req dto.BloodRequestRequest) (dto.BloodRequestResponse, error) {
+// Create mencatat pemanggil sebagai pembuat dan tenant lokasi permintaan sebagai tenant pemilik.
+// Pengguna tenant hanya dapat membuat permintaan untuk lokasi milik tenantnya.
+func (uc *bloodRequestUsecaseImpl) Create(ctx context.Context, req dto.BloodRequestRequest, actor dto.Actor) (dto.BloodRequestResponse, error) {
   var bloodRequest entity.BloodRequest
   var res dto.BloodRequestResponse
+  if !compatibility.Valid(req.BloodType, req.Rhesus) {
+    return res, entity.ErrInvalidBloodGroup
+  }
 
   copier.Copy(&bloodRequest, &req)
+  if bloodRequest.Component == "" {
+    bloodRequest.Component = entity.ComponentWholeBlood
+  }
+  if bloodRequest.Urgency == "" {
+    bloodRequest.Urgency = entity.BloodRequestUrgencyRoutine
+  }
+  location, err := findTenantLocation(ctx, uc.locationRepo, bloodRequest.LocationID, actor.TenantID)
+  if err != nil {
+    return res, err
+  }
+  hospitalID, err := uc.resolveHospital(ctx, req.HospitalID, bloodRequest.LocationID)
+  if err != nil {
+    return res, err
+  }
+  bloodRequest.HospitalID = hospitalID
+  bloodRequest.TenantID = location.TenantID
+  bloodRequest.CreatedBy = actor.UserID
+  bloodRequest.Status = entity.BloodRequestStatusPending
 
-  if err := uc.repo.Save(ctx, &bloodRequest); err != nil {
+  // Permintaan dan tahanan stoknya disimpan dalam satu transaksi, jadi kegagalan menahan stok
+  // tidak meninggalkan permintaan tanpa tahanan.
+  now := time.Now()
+  reservations, err := uc.reservationRepo.SaveAndReserve(ctx, &bloodRequest, startOfDay(now), now.Add(uc.reservationTTL), reserveMovement())
+  if err != nil {
     return res, err
   }
 
   // Salin field yang cocok, lalu atur ID secara manual.
   copier.Copy(&res, &bloodRequest)
   res.ID = bloodRequest.ID.String()
+  attachSLA(&res, bloodRequest, now)
+  attachClinicalDetails(&res, bloodRequest)
+  attachReservations(&res, reservations)
   return res, nil
 }
 
-func (uc *bloodRequestUsecaseImpl) FindAll(ctx context.Context, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error) {
+// FindAll mengambil permintaan darah dalam cakupan pemanggil (lihat scopeOf).
+func (uc *bloodRequestUsecaseImpl) FindAll(ctx context.Context, actor dto.Actor, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error) {
   offset := (page - 1) * limit
   var paginatedResponse dto.PaginatedResponse[dto.BloodRequestResponse]
 
-  items, total, err := uc.repo.FindAll(ctx, limit, offset)
+  tenantID, createdBy := scopeOf(actor)
+  items, total, err := uc.repo.FindAll(ctx, repository.BloodRequestFilter{TenantID: tenantID, CreatedBy: createdBy}, limit, offset)
   if err != nil {
     return paginatedResponse, err
   }
@@ -57,8 +119,41 @@ func (uc *bloodRequestUsecaseImpl) FindAll(ctx context.Context, page, limit int)
   copier.Copy(&itemResponses, &items)
 
   // ID perlu di-mapping manual karena tipe berbeda (uuid.UUID -> string)
+  now := time.Now()
+  for i := range items {
+    itemResponses[i].ID = items[i].ID.String()
+    attachSLA(&itemResponses[i], items[i], now)
+    attachClinicalDetails(&itemResponses[i], items[i])
+  }
+
+  paginatedResponse = dto.PaginatedResponse[dto.BloodRequestResponse]{
+    Data:       itemResponses,
+    TotalItems: total,
+    Page:       page,
+    Limit:      limit,
+  }
+  return paginatedResponse, nil
+}
+
+// FindQueue mengambil antrean kerja petugas: permintaan yang masih berjalan, paling mendesak dan paling lama lebih dulu.
+func (uc *bloodRequestUsecaseImpl) FindQueue(ctx context.Context, actor dto.Actor, page, limit int) (dto.PaginatedResponse[dto.BloodRequestResponse], error) {
+  offset := (page - 1) * limit
+  var paginatedResponse dto.PaginatedResponse[dto.BloodRequestResponse]
+
+  tenantID, createdBy := scopeOf(actor)
+  items, total, err := uc.repo.FindQueue(ctx, repository.BloodRequestFilter{TenantID: tenantID, CreatedBy: createdBy}, limit, offset)
+  if err != nil {
+    return paginatedResponse, err
+  }
+
+  var itemResponses []dto.BloodRequestResponse
+  copier.Copy(&itemResponses, &items)
+
+  now := time.Now()
   for i := range items {
     itemResponses[i].ID = items[i].ID.String()
+    attachSLA(&itemResponses[i], items[i], now)
+    attachClinicalDetails(&itemResponses[i], items[i])
   }
 
   paginatedResponse = dto.PaginatedResponse[dto.BloodRequestResponse]{
@@ -70,41 +165,665 @@ func (uc *bloodRequestUsecaseImpl) FindAll(ctx context.Context, page, limit int)
   return paginatedResponse, nil
 }
 
-func (uc *bloodRequestUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (dto.BloodRequestResponse, error) {
+func (uc *bloodRequestUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestResponse, error) {
   var res dto.BloodRequestResponse
-  bloodRequest, err := uc.repo.FindByID(ctx, id)
+  bloodRequest, err := uc.findVisible(ctx, id, actor)
+  if err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, bloodRequest.ID)
+  if err != nil {
+    return res, err
+  }
+  histories, err := uc.repo.FindStatusHistory(ctx, bloodRequest.ID)
+  if err != nil {
+    return res, err
+  }
+  fulfillments, err := uc.fulfillmentRepo.FindByRequestID(ctx, bloodRequest.ID)
   if err != nil {
     return res, err
   }
 
   copier.Copy(&res, &bloodRequest)
   res.ID = bloodRequest.ID.String()
+  attachSLA(&res, bloodRequest, time.Now())
+  attachClinicalDetails(&res, bloodRequest)
+  attachReservations(&res, reservations)
+  attachStatusHistory(&res, histories)
+  attachFulfillments(&res, fulfillments)
   return res, nil
 }
 
-func (uc *bloodRequestUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.BloodRequestRequest) (dto.BloodRequestResponse, error) {
+// Update mengubah permintaan yang masih terbuka. Jumlah tidak boleh di bawah kantong yang sudah diserahkan;
+// jumlah yang diturunkan sampai sama dengan kantong terserah menutup permintaan sebagai fulfilled.
+// Perubahan, riwayat status, dan penyusunan ulang tahanan disimpan dalam satu transaksi.
+func (uc *bloodRequestUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.BloodRequestRequest, actor dto.Actor, version int) (dto.BloodRequestResponse, error) {
   var res dto.BloodRequestResponse
-  bloodRequest, err := uc.repo.FindByID(ctx, id)
+  if !compatibility.Valid(req.BloodType, req.Rhesus) {
+    return res, entity.ErrInvalidBloodGroup
+  }
+  bloodRequest, err := uc.findManaged(ctx, id, actor)
   if err != nil {
     return res, err
   }
+  if err := checkVersion(bloodRequest.Version, version); err != nil {
+    return res, err
+  }
+  if !bloodRequest.IsOpen() {
+    return res, fmt.Errorf("%w: %s is closed", entity.ErrInvalidRequestStatus, bloodRequest.Status)
+  }
+
+  if req.Quantity < bloodRequest.FulfilledQuantity {
+    return res, fmt.Errorf("%w: %d bags have already been issued", entity.ErrExceedsRequestQuantity, bloodRequest.FulfilledQuantity)
+  }
 
+  previous := bloodRequest
   copier.Copy(&bloodRequest, &req)
+  if bloodRequest.Component == "" {
+    bloodRequest.Component = entity.ComponentWholeBlood
+  }
+  if bloodRequest.Urgency == "" {
+    bloodRequest.Urgency = previous.Urgency
+  }
+  location, err := findTenantLocation(ctx, uc.locationRepo, bloodRequest.LocationID, actor.TenantID)
+  if err != nil {
+    return res, err
+  }
+  bloodRequest.TenantID = location.TenantID
+  bloodRequest.HospitalID, err = uc.resolveHospital(ctx, req.HospitalID, bloodRequest.LocationID)
+  if err != nil {
+    return res, err
+  }
+  // Urgensi yang berubah memberi batas SLA baru sehingga permintaan dapat dieskalasi lagi.
+  if bloodRequest.Urgency != previous.Urgency {
+    bloodRequest.EscalatedAt = nil
+  }
 
-  updatedBloodRequest, err := uc.repo.Update(ctx, bloodRequest)
+  var history *entity.BloodRequestStatusHistory
+  if bloodRequest.FulfilledQuantity > 0 && bloodRequest.FulfilledQuantity >= bloodRequest.Quantity {
+    history = &entity.BloodRequestStatusHistory{
+      FromStatus: previous.Status,
+      ToStatus:   entity.BloodRequestStatusFulfilled,
+      Note:       "Jumlah permintaan diturunkan sampai sama dengan kantong yang sudah diserahkan",
+      ChangedBy:  &actor.UserID,
+    }
+    bloodRequest.Status = entity.BloodRequestStatusFulfilled
+  }
+
+  // Tahanan disusun ulang bila golongan, lokasi, atau jumlah kantong permintaan berubah,
+  // dan dilepas seluruhnya bila permintaan menjadi fulfilled.
+  now := time.Now()
+  release := entity.NewStockMovement(entity.StockMovementRelease, "Permintaan darah diubah", actor.UserID, nil)
+  updatedBloodRequest, err := uc.reservationRepo.UpdateAndReserve(ctx, bloodRequest, history, reservationChanged(previous, bloodRequest),
+    startOfDay(now), now.Add(uc.reservationTTL), release, reserveMovement())
+  if err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, id)
+  if err != nil {
+    return res, err
+  }
+
+  copier.Copy(&res, &updatedBloodRequest)
+  res.ID = updatedBloodRequest.ID.String()
+  attachSLA(&res, updatedBloodRequest, time.Now())
+  attachClinicalDetails(&res, updatedBloodRequest)
+  attachReservations(&res, reservations)
+  return res, nil
+}
+
+// UpdateStatus memindahkan status permintaan sesuai state machine dan mencatat riwayatnya.
+// cancelled dan rejected melepas tahanan kembali ke stok. partially_fulfilled dan fulfilled
+// hanya dicapai lewat Issue karena dihitung dari jumlah kantong yang diserahkan.
+// approved dan rejected hanya dapat diberikan admin tenant atau superadmin; pembuat hanya dapat membatalkan.
+func (uc *bloodRequestUsecaseImpl) UpdateStatus(ctx context.Context, id uuid.UUID, req dto.UpdateBloodRequestStatusDTO, actor dto.Actor, version int) (dto.BloodRequestResponse, error) {
+  var res dto.BloodRequestResponse
+  bloodRequest, err := uc.findManaged(ctx, id, actor)
+  if err != nil {
+    return res, err
+  }
+  if err := checkVersion(bloodRequest.Version, version); err != nil {
+    return res, err
+  }
+  if !bloodRequest.CanTransitionTo(req.Status) {
+    return res, fmt.Errorf("%w: %s to %s", entity.ErrInvalidRequestStatus, bloodRequest.Status, req.Status)
+  }
+  if req.Status == entity.BloodRequestStatusPartiallyFulfilled || req.Status == entity.BloodRequestStatusFulfilled {
+    return res, fmt.Errorf("%w: %s is set by issuing bags", entity.ErrInvalidRequestStatus, req.Status)
+  }
+  if req.Status != entity.BloodRequestStatusCancelled && !canManage(actor, uuid.Nil, bloodRequest.TenantID) {
+    return res, fmt.Errorf("%w: only tenant admins can set %s", entity.ErrForbidden, req.Status)
+  }
+
+  history := entity.BloodRequestStatusHistory{
+    FromStatus: bloodRequest.Status,
+    ToStatus:   req.Status,
+    Note:       req.Note,
+  }
+  if actor.UserID != uuid.Nil {
+    history.ChangedBy = &actor.UserID
+  }
+  bloodRequest.Status = req.Status
+  release := entity.NewStockMovement(entity.StockMovementRelease, "Permintaan darah "+req.Status, actor.UserID, nil)
+  updatedBloodRequest, err := uc.repo.UpdateStatus(ctx, bloodRequest, history, release)
+  if err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, id)
+  if err != nil {
+    return res, err
+  }
+  histories, err := uc.repo.FindStatusHistory(ctx, id)
+  if err != nil {
+    return res, err
+  }
+
+  copier.Copy(&res, &updatedBloodRequest)
+  res.ID = updatedBloodRequest.ID.String()
+  attachSLA(&res, updatedBloodRequest, time.Now())
+  attachClinicalDetails(&res, updatedBloodRequest)
+  attachReservations(&res, reservations)
+  attachStatusHistory(&res, histories)
+  return res, nil
+}
+
+// Issue menyerahkan kantong untuk permintaan yang sudah disetujui dan mencatatnya sebagai fulfillment.
+// Kantong diambil dari lokasi permintaan kecuali LocationID diisi dengan lokasi lain milik tenant yang sama.
+// Setelah seluruh Quantity terpenuhi, tahanan yang tersisa di lokasi lain dilepas kembali ke stok.
+func (uc *bloodRequestUsecaseImpl) Issue(ctx context.Context, id uuid.UUID, req dto.IssueBloodRequestDTO, actor dto.Actor, version int) (dto.BloodRequestResponse, error) {
+  var res dto.BloodRequestResponse
+  bloodRequest, err := uc.findManaged(ctx, id, actor)
+  if err != nil {
+    return res, err
+  }
+  if err := checkVersion(bloodRequest.Version, version); err != nil {
+    return res, err
+  }
+  if !bloodRequest.CanTransitionTo(entity.BloodRequestStatusPartiallyFulfilled) {
+    return res, fmt.Errorf("%w: cannot issue bags while %s", entity.ErrInvalidRequestStatus, bloodRequest.Status)
+  }
+  if bloodRequest.Rhesus == "" {
+    return res, fmt.Errorf("%w: rhesus is required before issuing", entity.ErrInvalidBloodGroup)
+  }
+
+  locationID := bloodRequest.LocationID
+  if req.LocationID != "" {
+    locationID, err = uuid.Parse(req.LocationID)
+    if err != nil {
+      return res, err
+    }
+    home, err := uc.locationRepo.FindByID(ctx, bloodRequest.LocationID)
+    if err != nil {
+      return res, err
+    }
+    if err := checkLocationTenant(ctx, uc.locationRepo, locationID, home.TenantID); err != nil {
+      return res, err
+    }
+  }
+
+  now := time.Now()
+  fulfillment := entity.BloodRequestFulfillment{
+    LocationID: locationID,
+    Quantity:   req.Quantity,
+    Note:       req.Note,
+    IssuedBy:   actor.UserID,
+    IssuedAt:   now,
+  }
+  movement := entity.NewStockMovement(entity.StockMovementIssue, "Diserahkan untuk permintaan darah", actor.UserID, nil)
+  release := entity.NewStockMovement(entity.StockMovementRelease, "Permintaan darah terpenuhi", actor.UserID, nil)
+  updatedBloodRequest, err := uc.fulfillmentRepo.Issue(ctx, bloodRequest, &fulfillment, startOfDay(now), movement, release)
+  if err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, id)
+  if err != nil {
+    return res, err
+  }
+  histories, err := uc.repo.FindStatusHistory(ctx, id)
+  if err != nil {
+    return res, err
+  }
+  fulfillments, err := uc.fulfillmentRepo.FindByRequestID(ctx, id)
   if err != nil {
     return res, err
   }
 
   copier.Copy(&res, &updatedBloodRequest)
   res.ID = updatedBloodRequest.ID.String()
+  attachSLA(&res, updatedBloodRequest, now)
+  attachClinicalDetails(&res, updatedBloodRequest)
+  attachReservations(&res, reservations)
+  attachStatusHistory(&res, histories)
+  attachFulfillments(&res, fulfillments)
   return res, nil
 }
 
-func (uc *bloodRequestUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
-  _, err := uc.repo.FindByID(ctx, id)
+// Delete menghapus permintaan pending atau yang sudah dibatalkan tanpa penyerahan. Permintaan lain
+// tetap tersimpan karena penyerahan dan riwayat statusnya menjadi jejak audit.
+func (uc *bloodRequestUsecaseImpl) Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error {
+  bloodRequest, err := uc.findManaged(ctx, id, actor)
   if err != nil {
     return err
   }
-  return uc.repo.Delete(ctx, id)
+  if !bloodRequest.IsDeletable() {
+    return entity.ErrRequestNotDeletable
+  }
+
+  movement := entity.NewStockMovement(entity.StockMovementRelease, "Permintaan darah dihapus", actor.UserID, nil)
+  return uc.repo.Delete(ctx, id, movement)
+}
+
+// MatchPlan menyusun rencana pemenuhan permintaan dari stok yang kompatibel.
+// Golongan yang sama persis didahulukan dari golongan pengganti; di dalam tiap tingkat,
+// stok di lokasi permintaan lebih dulu, lalu lokasi lain milik tenant yang sama diurutkan menurut jarak Haversine.
+// Lokasi tanpa koordinat ditempatkan paling akhir.
+func (uc *bloodRequestUsecaseImpl) MatchPlan(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestMatchPlanResponse, error) {
+  bloodRequest, err := uc.findVisible(ctx, id, actor)
+  if err != nil {
+    return dto.BloodRequestMatchPlanResponse{}, err
+  }
+  return uc.buildMatchPlan(ctx, bloodRequest)
+}
+
+// ApproveMatchPlan menyusun ulang rencana pemenuhan dari stok terkini lalu menahan seluruh kantong
+// yang direncanakan dalam satu transaksi. Hanya admin tenant permintaan atau superadmin yang dapat menyetujui.
+func (uc *bloodRequestUsecaseImpl) ApproveMatchPlan(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestResponse, error) {
+  var res dto.BloodRequestResponse
+  bloodRequest, err := uc.findVisible(ctx, id, actor)
+  if err != nil {
+    return res, err
+  }
+  if !canManage(actor, uuid.Nil, bloodRequest.TenantID) {
+    return res, entity.ErrForbidden
+  }
+
+  plan, err := uc.buildMatchPlan(ctx, bloodRequest)
+  if err != nil {
+    return res, err
+  }
+  if len(plan.Items) == 0 {
+    return res, fmt.Errorf("%w: no compatible stock to reserve", entity.ErrInsufficientStock)
+  }
+  items := make([]repository.ReservationPlanItem, 0, len(plan.Items))
+  for _, item := range plan.Items {
+    items = append(items, repository.ReservationPlanItem{
+      LocationID: item.LocationID,
+      BloodType:  item.BloodType,
+      Rhesus:     item.Rhesus,
+      Component:  item.Component,
+      Quantity:   item.Quantity,
+    })
+  }
+
+  now := time.Now()
+  movement := entity.NewStockMovement(entity.StockMovementReserve, "Ditahan dari rencana pemenuhan", actor.UserID, nil)
+  if _, err := uc.reservationRepo.ReservePlan(ctx, bloodRequest, items, startOfDay(now), now.Add(uc.reservationTTL), movement); err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, id)
+  if err != nil {
+    return res, err
+  }
+  histories, err := uc.repo.FindStatusHistory(ctx, id)
+  if err != nil {
+    return res, err
+  }
+
+  copier.Copy(&res, &bloodRequest)
+  res.ID = bloodRequest.ID.String()
+  attachSLA(&res, bloodRequest, now)
+  attachClinicalDetails(&res, bloodRequest)
+  attachReservations(&res, reservations)
+  attachStatusHistory(&res, histories)
+  return res, nil
+}
+
+// buildMatchPlan menghitung rencana pemenuhan untuk permintaan yang masih berjalan.
+func (uc *bloodRequestUsecaseImpl) buildMatchPlan(ctx context.Context, bloodRequest entity.BloodRequest) (dto.BloodRequestMatchPlanResponse, error) {
+  var res dto.BloodRequestMatchPlanResponse
+  if !bloodRequest.IsOpen() {
+    return res, fmt.Errorf("%w: %s is closed", entity.ErrInvalidRequestStatus, bloodRequest.Status)
+  }
+
+  home, err := uc.locationRepo.FindByID(ctx, bloodRequest.LocationID)
+  if err != nil {
+    return res, err
+  }
+  locations, err := uc.locationRepo.FindAllByTenantID(ctx, home.TenantID)
+  if err != nil {
+    return res, err
+  }
+  locationByID := make(map[uuid.UUID]entity.Location, len(locations))
+  for _, location := range locations {
+    locationByID[location.ID] = location
+  }
+
+  stocks, err := uc.stockRepo.FindByFilter(ctx, repository.StockFilter{
+    TenantID:  home.TenantID,
+    Component: bloodRequest.Component,
+  })
+  if err != nil {
+    return res, err
+  }
+
+  reservations, err := uc.reservationRepo.FindByRequestID(ctx, bloodRequest.ID)
+  if err != nil {
+    return res, err
+  }
+  for _, reservation := range reservations {
+    if reservation.Status == entity.StockReservationActive {
+      res.ReservedQuantity++
+    }
+  }
+
+  recipient := compatibility.BloodGroup{BloodType: bloodRequest.BloodType, Rhesus: bloodRequest.Rhesus}
+  var candidates []dto.MatchPlanItemResponse
+  for _, stock := range stocks {
+    location, ok := locationByID[stock.LocationID]
+    if !ok || stock.BagQuantity <= 0 {
+      continue
+    }
+    match := compatibility.Check(stock.Component, compatibility.BloodGroup{BloodType: stock.BloodType, Rhesus: stock.Rhesus}, recipient)
+    if match == compatibility.Incompatible {
+      continue
+    }
+    candidates = append(candidates, dto.MatchPlanItemResponse{
+      Match:        match.String(),
+      LocationID:   location.ID,
+      LocationName: location.LocationName,
+      City:         location.City,
+      DistanceKm:   locationDistance(home, location),
+      BloodType:    stock.BloodType,
+      Rhesus:       stock.Rhesus,
+      Component:    stock.Component,
+      Available:    stock.BagQuantity,
+    })
+  }
+  sort.SliceStable(candidates, func(i, j int) bool {
+    a, b := candidates[i], candidates[j]
+    if a.Match != b.Match {
+      return a.Match == compatibility.Exact.String()
+    }
+    if (a.LocationID == home.ID) != (b.LocationID == home.ID) {
+      return a.LocationID == home.ID
+    }
+    if (a.DistanceKm == nil) != (b.DistanceKm == nil) {
+      return a.DistanceKm != nil
+    }
+    if a.DistanceKm != nil && *a.DistanceKm != *b.DistanceKm {
+      return *a.DistanceKm < *b.DistanceKm
+    }
+    return a.LocationName < b.LocationName
+  })
+
+  remaining := bloodRequest.Quantity - res.ReservedQuantity
+  res.Items = make([]dto.MatchPlanItemResponse, 0, len(candidates))
+  for _, candidate := range candidates {
+    if remaining <= 0 {
+      break
+    }
+    candidate.Quantity = min(candidate.Available, remaining)
+    candidate.Rank = len(res.Items) + 1
+    res.Items = append(res.Items, candidate)
+    res.PlannedQuantity += candidate.Quantity
+    remaining -= candidate.Quantity
+  }
+
+  res.BloodRequestID = bloodRequest.ID.String()
+  res.BloodType = bloodRequest.BloodType
+  res.Rhesus = bloodRequest.Rhesus
+  res.Component = bloodRequest.Component
+  res.Quantity = bloodRequest.Quantity
+  res.Shortfall = max(remaining, 0)
+  return res, nil
+}
+
+// locationDistance mengembalikan jarak dalam kilometer, atau nil bila salah satu lokasi belum memiliki koordinat.
+func locationDistance(from, to entity.Location) *float64 {
+  if from.ID == to.ID {
+    distance := 0.0
+    return &distance
+  }
+  if from.Latitude == nil || from.Longitude == nil || to.Latitude == nil || to.Longitude == nil {
+    return nil
+  }
+  distance := helper.Haversine(*from.Latitude, *from.Longitude, *to.Latitude, *to.Longitude)
+  return &distance
+}
+
+// ReleaseExpiredReservations melepas tahanan yang melewati TTL agar kantong bisa dipakai permintaan lain.
+func (uc *bloodRequestUsecaseImpl) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+  now := time.Now()
+  ids, err := uc.reservationRepo.FindExpiredRequestIDs(ctx, now)
+  if err != nil {
+    return 0, err
+  }
+
+  total := 0
+  for _, id := range ids {
+    movement := entity.NewStockMovement(entity.StockMovementRelease, "Tahanan kedaluwarsa", uuid.Nil, nil)
+    released, err := uc.reservationRepo.ReleaseExpired(ctx, id, now, movement)
+    if err != nil {
+      return total, err
+    }
+    total += released
+  }
+  return total, nil
+}
+
+// EscalateOverdue memberi tahu admin tenant tentang permintaan yang melewati SLA.
+// Setiap permintaan hanya dieskalasi sekali untuk tingkat urgensi yang sama.
+func (uc *bloodRequestUsecaseImpl) EscalateOverdue(ctx context.Context) (int, error) {
+  now := time.Now()
+  // SLA terpendek menjadi batas kasar; pemeriksaan per urgensi dilakukan di bawah.
+  shortest := entity.BloodRequestUrgencySLA[entity.BloodRequestUrgencyRoutine]
+  for _, sla := range entity.BloodRequestUrgencySLA {
+    shortest = min(shortest, sla)
+  }
+  candidates, err := uc.repo.FindUnescalated(ctx, now.Add(-shortest))
+  if err != nil {
+    return 0, err
+  }
+
+  admins := map[uuid.UUID][]entity.User{}
+  escalated := 0
+  for _, bloodRequest := range candidates {
+    if !bloodRequest.IsOverdue(now) {
+      continue
+    }
+
+    // Kegagalan pada satu permintaan dicatat saja agar permintaan lain tetap dieskalasi.
+    location, err := uc.locationRepo.FindByID(ctx, bloodRequest.LocationID)
+    if err != nil {
+      log.Printf("gagal mengeskalasi permintaan darah %s: %v", bloodRequest.ID, err)
+      continue
+    }
+    tenantAdmins, ok := admins[location.TenantID]
+    if !ok {
+      tenantAdmins, err = uc.userRepo.FindByTenantRole(ctx, location.TenantID, "admin")
+      if err != nil {
+        log.Printf("gagal mengambil admin tenant %s: %v", location.TenantID, err)
+        continue
+      }
+      admins[location.TenantID] = tenantAdmins
+    }
+    // Tanpa admin tidak ada yang diberi tahu; permintaan dibiarkan belum dieskalasi
+    // agar tetap dilaporkan begitu tenant memiliki admin.
+    if len(tenantAdmins) == 0 {
+      continue
+    }
+
+    title := fmt.Sprintf("Permintaan darah %s melewati SLA", bloodRequest.Urgency)
+    message := fmt.Sprintf("Permintaan %d kantong %s%s %s di %s belum terpenuhi sejak %s (batas %s).",
+      bloodRequest.Quantity, bloodRequest.BloodType, bloodRequest.Rhesus, bloodRequest.Component,
+      location.LocationName, bloodRequest.CreatedAt.Format(time.DateTime), bloodRequest.DueAt().Format(time.DateTime))
+    notifications := make([]entity.Notification, 0, len(tenantAdmins))
+    for _, admin := range tenantAdmins {
+      notifications = append(notifications, entity.Notification{
+        UserID:      admin.ID,
+        TenantID:    &location.TenantID,
+        Type:        entity.NotificationBloodRequestOverdue,
+        Title:       title,
+        Message:     message,
+        ReferenceID: &bloodRequest.ID,
+      })
+    }
+
+    ok, err = uc.repo.Escalate(ctx, bloodRequest, notifications)
+    if err != nil {
+      log.Printf("gagal mengeskalasi permintaan darah %s: %v", bloodRequest.ID, err)
+      continue
+    }
+    if ok {
+      escalated++
+    }
+  }
+  return escalated, nil
+}
+
+// findVisible mengambil permintaan dalam cakupan pemanggil; permintaan di luar cakupan diperlakukan sebagai tidak ditemukan.
+func (uc *bloodRequestUsecaseImpl) findVisible(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.BloodRequest, error) {
+  bloodRequest, err := uc.repo.FindByID(ctx, id)
+  if err != nil {
+    return entity.BloodRequest{}, err
+  }
+  if !canView(actor, bloodRequest.CreatedBy, bloodRequest.TenantID) {
+    return entity.BloodRequest{}, gorm.ErrRecordNotFound
+  }
+  return bloodRequest, nil
+}
+
+// findManaged mengambil permintaan yang boleh diubah pemanggil: pembuatnya, admin tenant yang sama, atau superadmin.
+func (uc *bloodRequestUsecaseImpl) findManaged(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.BloodRequest, error) {
+  bloodRequest, err := uc.findVisible(ctx, id, actor)
+  if err != nil {
+    return entity.BloodRequest{}, err
+  }
+  if !canManage(actor, bloodRequest.CreatedBy, bloodRequest.TenantID) {
+    return entity.BloodRequest{}, entity.ErrForbidden
+  }
+  return bloodRequest, nil
+}
+
+// resolveHospital memastikan rumah sakit milik tenant yang sama dengan lokasi permintaan.
+// Rumah sakit tenant lain diperlakukan sebagai tidak ditemukan.
+func (uc *bloodRequestUsecaseImpl) resolveHospital(ctx context.Context, hospitalID string, locationID uuid.UUID) (*uuid.UUID, error) {
+  if hospitalID == "" {
+    return nil, nil
+  }
+  id, err := uuid.Parse(hospitalID)
+  if err != nil {
+    return nil, err
+  }
+
+  hospital, err := uc.hospitalRepo.FindByID(ctx, id)
+  if err != nil {
+    return nil, err
+  }
+  location, err := uc.locationRepo.FindByID(ctx, locationID)
+  if err != nil {
+    return nil, err
+  }
+  if hospital.TenantID != location.TenantID {
+    return nil, gorm.ErrRecordNotFound
+  }
+  return &hospital.ID, nil
+}
+
+// reserveMovement menyiapkan template ledger untuk kantong yang ditahan otomatis saat permintaan
+// dibuat atau diubah.
+func reserveMovement() entity.StockMovement {
+  return entity.NewStockMovement(entity.StockMovementReserve, "Ditahan untuk permintaan darah", uuid.Nil, nil)
+}
+
+func reservationChanged(before, after entity.BloodRequest) bool {
+  return before.LocationID != after.LocationID ||
+    before.BloodType != after.BloodType ||
+    before.Rhesus != after.Rhesus ||
+    before.Component != after.Component ||
+    before.Quantity != after.Quantity
+}
+
+// attachReservations menambahkan daftar tahanan dan jumlah kantong yang masih ditahan ke respons.
+func attachReservations(res *dto.BloodRequestResponse, reservations []entity.StockReservation) {
+  res.Reservations = make([]dto.StockReservationResponse, 0, len(reservations))
+  for _, reservation := range reservations {
+    if reservation.Status == entity.StockReservationActive {
+      res.ReservedQuantity++
+    }
+    res.Reservations = append(res.Reservations, dto.StockReservationResponse{
+      ID:          reservation.ID.String(),
+      BloodUnitID: reservation.BloodUnitID.String(),
+      BagNumber:   reservation.BagNumber,
+      Status:      reservation.Status,
+      ExpiresAt:   reservation.ExpiresAt,
+      ReleasedAt:  reservation.ReleasedAt,
+      CreatedAt:   reservation.CreatedAt,
+    })
+  }
+}
+
+// attachClinicalDetails menyalin rumah sakit dan data pasien ke respons. Penyaringan data pasien
+// menurut peran pemanggil dilakukan di handler.
+func attachClinicalDetails(res *dto.BloodRequestResponse, bloodRequest entity.BloodRequest) {
+  res.HospitalID = ""
+  if bloodRequest.HospitalID != nil {
+    res.HospitalID = bloodRequest.HospitalID.String()
+  }
+  res.Patient = &dto.BloodRequestPatientResponse{
+    MedicalRecordNumber: bloodRequest.PatientMRN,
+    Name:                bloodRequest.PatientName,
+    Age:                 bloodRequest.PatientAge,
+    Diagnosis:           bloodRequest.Diagnosis,
+    Hb:                  bloodRequest.PatientHb,
+  }
+}
+
+func attachSLA(res *dto.BloodRequestResponse, bloodRequest entity.BloodRequest, now time.Time) {
+  res.DueAt = bloodRequest.DueAt()
+  res.Overdue = bloodRequest.IsOverdue(now)
+}
+
+func attachFulfillments(res *dto.BloodRequestResponse, fulfillments []entity.BloodRequestFulfillment) {
+  res.Fulfillments = make([]dto.BloodRequestFulfillmentResponse, 0, len(fulfillments))
+  for _, fulfillment := range fulfillments {
+    item := dto.BloodRequestFulfillmentResponse{
+      ID:         fulfillment.ID.String(),
+      LocationID: fulfillment.LocationID.String(),
+      Quantity:   fulfillment.Quantity,
+      Note:       fulfillment.Note,
+      IssuedBy:   fulfillment.IssuedBy.String(),
+      IssuedAt:   fulfillment.IssuedAt,
+      Units:      make([]dto.FulfillmentUnitResponse, 0, len(fulfillment.Units)),
+    }
+    for _, unit := range fulfillment.Units {
+      item.Units = append(item.Units, dto.FulfillmentUnitResponse{
+        BloodUnitID: unit.BloodUnitID.String(),
+        BagNumber:   unit.BagNumber,
+        BloodType:   unit.BloodType,
+        Rhesus:      unit.Rhesus,
+        Component:   unit.Component,
+        FromReserve: unit.FromReserve,
+      })
+    }
+    res.Fulfillments = append(res.Fulfillments, item)
+  }
+}
+
+func attachStatusHistory(res *dto.BloodRequestResponse, histories []entity.BloodRequestStatusHistory) {
+  res.StatusHistory = make([]dto.BloodRequestStatusHistoryResponse, 0, len(histories))
+  for _, history := range histories {
+    res.StatusHistory = append(res.StatusHistory, dto.BloodRequestStatusHistoryResponse{
+      FromStatus: history.FromStatus,
+      ToStatus:   history.ToStatus,
+      Note:       history.Note,
+      ChangedBy:  history.ChangedBy,
+      ChangedAt:  history.CreatedAt,
+    })
+  }
 }
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBloodRequestUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		fulfilled  int
+		quantity   int
+		want       error
+		wantStatus string
+	}{
+		{"closed request cannot be edited", entity.BloodRequestStatusFulfilled, 2, 4, entity.ErrInvalidRequestStatus, ""},
+		{"cancelled request cannot be edited", entity.BloodRequestStatusCancelled, 0, 4, entity.ErrInvalidRequestStatus, ""},
+		{"raising quantity keeps partial status", entity.BloodRequestStatusPartiallyFulfilled, 2, 5, nil, entity.BloodRequestStatusPartiallyFulfilled},
+		{"lowering quantity to issued bags fulfils", entity.BloodRequestStatusPartiallyFulfilled, 2, 2, nil, entity.BloodRequestStatusFulfilled},
+		{"quantity below issued bags", entity.BloodRequestStatusPartiallyFulfilled, 2, 1, entity.ErrExceedsRequestQuantity, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := entity.Location{ID: uuid.New(), TenantID: tenantA}
+			bloodRequest := entity.BloodRequest{
+				ID:                uuid.New(),
+				TenantID:          tenantA,
+				LocationID:        location.ID,
+				BloodType:         "A",
+				Rhesus:            "+",
+				Component:         entity.ComponentPRC,
+				Quantity:          3,
+				FulfilledQuantity: tt.fulfilled,
+				Status:            tt.status,
+				Version:           1,
+			}
+			reservations := &fakeReservationRepo{}
+			uc := &bloodRequestUsecaseImpl{
+				repo:            &fakeBloodRequestRepo{requests: map[uuid.UUID]entity.BloodRequest{bloodRequest.ID: bloodRequest}},
+				reservationRepo: reservations,
+				locationRepo:    newFakeLocationRepo(location),
+			}
+			req := dto.BloodRequestRequest{
+				LocationID:  location.ID.String(),
+				BloodType:   "A",
+				Rhesus:      "+",
+				Component:   entity.ComponentPRC,
+				Quantity:    tt.quantity,
+				Description: "Operasi",
+			}
+
+			res, err := uc.Update(context.Background(), bloodRequest.ID, req, adminA, 0)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Update() err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(reservations.updated) != 0 {
+					t.Fatal("request was saved despite the error")
+				}
+				return
+			}
+			if res.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", res.Status, tt.wantStatus)
+			}
+			if changed := reservations.histories[0] != nil; changed != (tt.wantStatus != tt.status) {
+				t.Errorf("history recorded = %v, want %v", changed, tt.wantStatus != tt.status)
+			}
+		})
+	}
+}
+
+func TestBloodRequestDeleteOnlyBeforeProcessing(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		fulfilled int
+		want      error
+	}{
+		{"pending", entity.BloodRequestStatusPending, 0, nil},
+		{"cancelled", entity.BloodRequestStatusCancelled, 0, nil},
+		{"approved", entity.BloodRequestStatusApproved, 0, entity.ErrRequestNotDeletable},
+		{"cancelled after issuing bags", entity.BloodRequestStatusCancelled, 1, entity.ErrRequestNotDeletable},
+		{"fulfilled", entity.BloodRequestStatusFulfilled, 3, entity.ErrRequestNotDeletable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bloodRequest := entity.BloodRequest{
+				ID:                uuid.New(),
+				TenantID:          tenantA,
+				Quantity:          3,
+				FulfilledQuantity: tt.fulfilled,
+				Status:            tt.status,
+			}
+			repo := &fakeBloodRequestRepo{requests: map[uuid.UUID]entity.BloodRequest{bloodRequest.ID: bloodRequest}}
+			uc := &bloodRequestUsecaseImpl{repo: repo}
+
+			err := uc.Delete(context.Background(), bloodRequest.ID, adminA)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Delete() err = %v, want %v", err, tt.want)
+			}
+			if deleted := len(repo.deleted) == 1; deleted != (tt.want == nil) {
+				t.Errorf("deleted = %v, want %v", deleted, tt.want == nil)
+			}
+		})
+	}
+}
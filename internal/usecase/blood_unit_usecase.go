@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type BloodUnitUsecase interface {
+	Create(ctx context.Context, req dto.BloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error)
+	FindAll(ctx context.Context, filter repository.BloodUnitFilter, page, limit int, actor dto.Actor) ([]entity.BloodUnit, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.BloodUnit, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.BloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, req dto.UpdateBloodUnitStatusRequest, actor dto.Actor) (entity.BloodUnit, error)
+	Review(ctx context.Context, id uuid.UUID, req dto.ReviewBloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error)
+	Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error
+	ProcessDonation(ctx context.Context, donationID uuid.UUID, req dto.ProcessDonationRequest, actor dto.Actor) ([]entity.BloodUnit, error)
+}
+
+// --- Implementation ---
+type bloodUnitUsecaseImpl struct {
+	repo         repository.BloodUnitRepository
+	donationRepo repository.DonationRepository
+	userRepo     repository.UserRepository
+	deviceRepo   repository.StorageDeviceRepository
+	locationRepo repository.LocationRepository
+}
+
+func NewBloodUnitUsecase(repo repository.BloodUnitRepository, donationRepo repository.DonationRepository, userRepo repository.UserRepository, deviceRepo repository.StorageDeviceRepository, locationRepo repository.LocationRepository) BloodUnitUsecase {
+	return &bloodUnitUsecaseImpl{
+		repo:         repo,
+		donationRepo: donationRepo,
+		userRepo:     userRepo,
+		deviceRepo:   deviceRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// Create mendaftarkan satu kantong dari donasi tenant yang sama dengan golongan pendonor yang cocok.
+// Donasi yang sudah pernah menjadi unit ditolak repository di dalam transaksi yang mengunci donasi.
+func (uc *bloodUnitUsecaseImpl) Create(ctx context.Context, req dto.BloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error) {
+	if err := uc.checkManage(ctx, actor, req.LocationID); err != nil {
+		return entity.BloodUnit{}, err
+	}
+
+	var unit entity.BloodUnit
+	copier.Copy(&unit, &req)
+
+	if unit.Component == "" {
+		unit.Component = entity.ComponentWholeBlood
+	}
+	if unit.ExpiryDate.IsZero() {
+		unit.ExpiryDate, _ = entity.ComponentExpiryDate(unit.Component, unit.CollectionDate)
+	}
+	// Status awal selalu mengikuti hasil skrining donasi asal, sehingga unit baru tidak pernah
+	// masuk stok available tanpa skrining yang lengkap negatif.
+	donation, err := checkUnitDonation(ctx, uc.donationRepo, uc.userRepo, uc.locationRepo, *req.DonationID, unit)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	unit.Status, err = screenedUnitStatus(donation)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	if err := uc.checkStorageDevice(ctx, unit); err != nil {
+		return entity.BloodUnit{}, err
+	}
+
+	movement := entity.NewStockMovement(entity.StockMovementInbound, "Unit baru didaftarkan", actor.UserID, nil)
+	err = uc.repo.Save(ctx, &unit, movement)
+	return unit, err
+}
+
+// FindAll membatasi daftar unit ke tenant pemanggil. Unit tidak mencatat pembuatnya, jadi pengguna
+// tanpa tenant selain superadmin tidak melihat unit apa pun.
+func (uc *bloodUnitUsecaseImpl) FindAll(ctx context.Context, filter repository.BloodUnitFilter, page, limit int, actor dto.Actor) ([]entity.BloodUnit, int64, error) {
+	tenantID, createdBy := scopeOf(actor)
+	if createdBy != uuid.Nil {
+		return []entity.BloodUnit{}, 0, nil
+	}
+	filter.TenantID = tenantID
+
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, filter, limit, offset)
+}
+
+// FindByID mengambil unit; unit di lokasi tenant lain diperlakukan sebagai tidak ditemukan.
+func (uc *bloodUnitUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.BloodUnit, error) {
+	unit, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	location, err := uc.locationRepo.FindByID(ctx, unit.LocationID)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	if !canView(actor, uuid.Nil, location.TenantID) {
+		return entity.BloodUnit{}, gorm.ErrRecordNotFound
+	}
+	return unit, nil
+}
+
+// Update mengoreksi data kantong unit yang masih available. Lokasi hanya berpindah lewat transfer
+// dan donasi asal tidak pernah berubah, sehingga keduanya harus sama dengan data yang tersimpan.
+func (uc *bloodUnitUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.BloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error) {
+	before, err := uc.findManaged(ctx, id, actor)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	if before.Status != entity.BloodUnitStatusAvailable {
+		return entity.BloodUnit{}, entity.ErrUnitNotEditable
+	}
+	if req.LocationID != before.LocationID {
+		return entity.BloodUnit{}, entity.ErrUnitLocationFixed
+	}
+	if req.DonationID == nil || before.DonationID == nil || *req.DonationID != *before.DonationID {
+		return entity.BloodUnit{}, entity.ErrUnitDonationFixed
+	}
+
+	unit := before
+	copier.Copy(&unit, &req)
+	if unit.Component == "" {
+		unit.Component = entity.ComponentWholeBlood
+	}
+	if req.ExpiryDate.IsZero() {
+		unit.ExpiryDate, _ = entity.ComponentExpiryDate(unit.Component, unit.CollectionDate)
+	}
+	if err := uc.checkStorageDevice(ctx, unit); err != nil {
+		return entity.BloodUnit{}, err
+	}
+
+	movement := entity.NewStockMovement(entity.StockMovementAdjustment, "Data unit diperbarui", actor.UserID, nil)
+	return uc.repo.Update(ctx, before, unit, movement)
+}
+
+func (uc *bloodUnitUsecaseImpl) UpdateStatus(ctx context.Context, id uuid.UUID, req dto.UpdateBloodUnitStatusRequest, actor dto.Actor) (entity.BloodUnit, error) {
+	before, err := uc.findManaged(ctx, id, actor)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+
+	if before.Status == entity.BloodUnitStatusQuarantine && req.Status != entity.BloodUnitStatusDiscarded {
+		return entity.BloodUnit{}, entity.ErrUnitInQuarantine
+	}
+	if !before.CanTransitionTo(req.Status) {
+		return entity.BloodUnit{}, entity.ErrInvalidUnitStatus
+	}
+	unit := before
+	unit.Status = req.Status
+
+	// Jenis pergerakan ditentukan repository dari status baru unit.
+	movement := entity.NewStockMovement("", req.Reason, actor.UserID, nil)
+	return uc.repo.Update(ctx, before, unit, movement)
+}
+
+// Review menyelesaikan penandaan unit akibat excursion suhu. Unit yang dilepas kembali bisa
+// dialokasikan; unit yang dimusnahkan keluar dari stok lewat pergerakan discard.
+func (uc *bloodUnitUsecaseImpl) Review(ctx context.Context, id uuid.UUID, req dto.ReviewBloodUnitRequest, actor dto.Actor) (entity.BloodUnit, error) {
+	before, err := uc.findManaged(ctx, id, actor)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	if !before.NeedsReview {
+		return entity.BloodUnit{}, entity.ErrUnitNotUnderReview
+	}
+
+	unit := before
+	unit.NeedsReview = false
+	reason := req.Note
+	if req.Decision == "discard" {
+		unit.Status = entity.BloodUnitStatusDiscarded
+		if reason == "" {
+			reason = "Dimusnahkan setelah excursion suhu"
+		}
+	} else if reason == "" {
+		reason = "Dilepas setelah review excursion suhu"
+	}
+
+	movement := entity.NewStockMovement("", reason, actor.UserID, nil)
+	return uc.repo.Update(ctx, before, unit, movement)
+}
+
+// Delete menghapus unit karantina atau available; unit yang sudah ditahan, dikirim, atau keluar dari
+// stok tetap tersimpan karena dirujuk tahanan, penyerahan, dan transfer.
+func (uc *bloodUnitUsecaseImpl) Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error {
+	unit, err := uc.findManaged(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+	if !unit.IsDeletable() {
+		return entity.ErrUnitNotDeletable
+	}
+	movement := entity.NewStockMovement(entity.StockMovementAdjustment, "Unit dihapus", actor.UserID, nil)
+	return uc.repo.Delete(ctx, id, movement)
+}
+
+// ProcessDonation memecah donasi yang sudah selesai menjadi unit-unit komponen,
+// masing-masing dengan tanggal kedaluwarsa sesuai masa simpan komponennya.
+// Unit tetap dikarantina sampai hasil skrining donasi lengkap negatif.
+func (uc *bloodUnitUsecaseImpl) ProcessDonation(ctx context.Context, donationID uuid.UUID, req dto.ProcessDonationRequest, actor dto.Actor) ([]entity.BloodUnit, error) {
+	donation, err := uc.donationRepo.FindByID(ctx, donationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkManage(ctx, actor, donation.LocationID); err != nil {
+		return nil, err
+	}
+	if donation.Status != entity.DonationStatusCompleted {
+		return nil, entity.ErrDonationNotCompleted
+	}
+	status, err := screenedUnitStatus(donation)
+	if err != nil {
+		return nil, err
+	}
+
+	bloodType, rhesus := req.BloodType, req.Rhesus
+	if bloodType == "" || rhesus == "" {
+		donorType, donorRhesus := donorBloodGroup(ctx, uc.userRepo, donation)
+		if bloodType == "" {
+			bloodType = donorType
+		}
+		if rhesus == "" {
+			rhesus = donorRhesus
+		}
+	}
+	if bloodType == "" || rhesus == "" {
+		return nil, entity.ErrUnknownBloodGroup
+	}
+
+	seen := map[string]bool{}
+	var units []entity.BloodUnit
+	for _, component := range req.Components {
+		if seen[component] {
+			continue
+		}
+		seen[component] = true
+
+		expiryDate, _ := entity.ComponentExpiryDate(component, donation.DonationDate)
+		units = append(units, entity.BloodUnit{
+			BagNumber:      fmt.Sprintf("%s-%s", req.BagNumber, component),
+			BloodType:      bloodType,
+			Rhesus:         rhesus,
+			Component:      component,
+			CollectionDate: donation.DonationDate,
+			ExpiryDate:     expiryDate,
+			LocationID:     donation.LocationID,
+			DonationID:     &donation.ID,
+			Status:         status,
+		})
+	}
+
+	movement := entity.NewStockMovement(entity.StockMovementInbound, "Pemrosesan komponen donasi", actor.UserID, nil)
+	// Donasi yang sudah pernah diproses ditolak di repository, di dalam transaksi yang mengunci donasi.
+	if err := uc.repo.SaveDonationComponents(ctx, donation.ID, units, movement); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+// findManaged mengambil unit dan memastikan pemanggil boleh mengelola lokasi tempat unit berada.
+func (uc *bloodUnitUsecaseImpl) findManaged(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.BloodUnit, error) {
+	unit, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.BloodUnit{}, err
+	}
+	if err := uc.checkManage(ctx, actor, unit.LocationID); err != nil {
+		return entity.BloodUnit{}, err
+	}
+	return unit, nil
+}
+
+// checkManage memastikan lokasi milik tenant pemanggil. Unit tidak mencatat pembuatnya,
+// jadi hanya admin tenant lokasi tersebut atau superadmin yang boleh mengelolanya.
+func (uc *bloodUnitUsecaseImpl) checkManage(ctx context.Context, actor dto.Actor, locationID uuid.UUID) error {
+	location, err := uc.locationRepo.FindByID(ctx, locationID)
+	if err != nil {
+		return err
+	}
+	if !canManage(actor, uuid.Nil, location.TenantID) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
+
+// checkStorageDevice memastikan alat simpan unit berada di lokasi yang sama dengan unit.
+func (uc *bloodUnitUsecaseImpl) checkStorageDevice(ctx context.Context, unit entity.BloodUnit) error {
+	if unit.StorageDeviceID == nil {
+		return nil
+	}
+	device, err := uc.deviceRepo.FindByID(ctx, *unit.StorageDeviceID)
+	if err != nil {
+		return err
+	}
+	if device.LocationID != unit.LocationID {
+		return entity.ErrStorageDeviceMismatch
+	}
+	return nil
+}
+
+// checkUnitDonation memastikan donasi asal berada di tenant yang sama dengan lokasi unit dan, bila
+// golongan pendonor tercatat di profil, golongan tersebut sama dengan golongan unit. Donasi tenant lain
+// diperlakukan sebagai tidak ditemukan. Status donasi diperiksa ulang repository di dalam transaksi
+// yang mengunci donasi.
+func checkUnitDonation(ctx context.Context, donationRepo repository.DonationRepository, userRepo repository.UserRepository, locationRepo repository.LocationRepository, donationID uuid.UUID, unit entity.BloodUnit) (entity.Donation, error) {
+	donation, err := donationRepo.FindByID(ctx, donationID)
+	if err != nil {
+		return entity.Donation{}, err
+	}
+	location, err := locationRepo.FindByID(ctx, unit.LocationID)
+	if err != nil {
+		return entity.Donation{}, err
+	}
+	if _, err := findTenantLocation(ctx, locationRepo, donation.LocationID, location.TenantID); err != nil {
+		return entity.Donation{}, err
+	}
+
+	bloodType, rhesus := donorBloodGroup(ctx, userRepo, donation)
+	if (bloodType != "" && bloodType != unit.BloodType) || (rhesus != "" && rhesus != unit.Rhesus) {
+		return entity.Donation{}, entity.ErrDonorBloodGroupMismatch
+	}
+	return donation, nil
+}
+
+// donorBloodGroup membaca golongan darah pendonor dari profilnya; nilai kosong berarti tidak diketahui.
+func donorBloodGroup(ctx context.Context, userRepo repository.UserRepository, donation entity.Donation) (bloodType, rhesus string) {
+	if donation.UserID == nil {
+		return "", ""
+	}
+	detail, err := userRepo.FindDetailByUserID(ctx, *donation.UserID)
+	if err != nil {
+		return "", ""
+	}
+	if detail.BloodType != nil {
+		bloodType = strings.ToUpper(*detail.BloodType)
+	}
+	if detail.Rhesus != nil {
+		rhesus = normalizeRhesus(*detail.Rhesus)
+	}
+	return bloodType, rhesus
+}
+
+// screenedUnitStatus menentukan status awal unit dari hasil skrining donasinya:
+// unit tetap dikarantina sampai semua hasil negatif, dan donasi reaktif tidak boleh diproses.
+func screenedUnitStatus(donation entity.Donation) (string, error) {
+	switch donation.ScreeningStatus {
+	case entity.DonationScreeningCleared:
+		return entity.BloodUnitStatusAvailable, nil
+	case entity.DonationScreeningReactive:
+		return "", entity.ErrDonationReactive
+	}
+	return entity.BloodUnitStatusQuarantine, nil
+}
+
+// normalizeRhesus menyeragamkan isian rhesus profil ("positif", "pos", "+") menjadi "+" atau "-".
+func normalizeRhesus(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case strings.HasPrefix(value, "+"), strings.HasPrefix(value, "pos"):
+		return "+"
+	case strings.HasPrefix(value, "-"), strings.HasPrefix(value, "neg"):
+		return "-"
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestBloodUnitDeleteOnlyBeforeLeavingStock(t *testing.T) {
+	tests := []struct {
+		status string
+		want   error
+	}{
+		{entity.BloodUnitStatusQuarantine, nil},
+		{entity.BloodUnitStatusAvailable, nil},
+		{entity.BloodUnitStatusReserved, entity.ErrUnitNotDeletable},
+		{entity.BloodUnitStatusInTransit, entity.ErrUnitNotDeletable},
+		{entity.BloodUnitStatusIssued, entity.ErrUnitNotDeletable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			location := entity.Location{ID: uuid.New(), TenantID: tenantA}
+			unit := entity.BloodUnit{ID: uuid.New(), LocationID: location.ID, Status: tt.status}
+			repo := newFakeBloodUnitRepo(unit)
+			uc := &bloodUnitUsecaseImpl{repo: repo, locationRepo: newFakeLocationRepo(location)}
+
+			err := uc.Delete(context.Background(), unit.ID, adminA)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Delete() err = %v, want %v", err, tt.want)
+			}
+			if deleted := len(repo.deleted) == 1; deleted != (tt.want == nil) {
+				t.Fatalf("deleted = %v, want %v", deleted, tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestBloodUnitCreateChecksDonation(t *testing.T) {
+	location := entity.Location{ID: uuid.New(), TenantID: tenantA}
+	otherLocation := entity.Location{ID: uuid.New(), TenantID: tenantB}
+	donor := uuid.New()
+	bloodType, rhesus := "B", "negatif"
+	users := &fakeUserRepo{details: map[uuid.UUID]entity.UserDetail{donor: {BloodType: &bloodType, Rhesus: &rhesus}}}
+	own := entity.Donation{ID: uuid.New(), UserID: &donor, LocationID: location.ID, ScreeningStatus: entity.DonationScreeningCleared}
+	other := entity.Donation{ID: uuid.New(), UserID: &donor, LocationID: otherLocation.ID, ScreeningStatus: entity.DonationScreeningCleared}
+
+	tests := []struct {
+		name       string
+		donationID uuid.UUID
+		bloodType  string
+		want       error
+	}{
+		{"donation at the unit's tenant", own.ID, "B", nil},
+		{"donation of another tenant", other.ID, "B", gorm.ErrRecordNotFound},
+		{"donor blood group differs from unit", own.ID, "O", entity.ErrDonorBloodGroupMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeBloodUnitRepo()
+			uc := &bloodUnitUsecaseImpl{
+				repo:         repo,
+				donationRepo: newFakeDonationRepo(own, other),
+				userRepo:     users,
+				locationRepo: newFakeLocationRepo(location, otherLocation),
+			}
+			donationID := tt.donationID
+			req := dto.BloodUnitRequest{
+				BagNumber:      "BAG-1",
+				BloodType:      tt.bloodType,
+				Rhesus:         "-",
+				Component:      entity.ComponentPRC,
+				CollectionDate: time.Now(),
+				LocationID:     location.ID,
+				DonationID:     &donationID,
+			}
+
+			unit, err := uc.Create(context.Background(), req, adminA)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Create() err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(repo.saved) != 0 {
+					t.Fatalf("saved %d units, want none", len(repo.saved))
+				}
+				return
+			}
+			if unit.Status != entity.BloodUnitStatusAvailable {
+				t.Errorf("status = %s, want %s", unit.Status, entity.BloodUnitStatusAvailable)
+			}
+		})
+	}
+}
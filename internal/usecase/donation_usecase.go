@@ -5,10 +5,13 @@ import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/entity"
 	"donor-api/internal/repository"
+	"errors"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
 type DonationUsecase interface {
@@ -20,13 +23,24 @@ type DonationUsecase interface {
 }
 
 type donationUsecaseImpl struct {
-	repo repository.DonationRepository
+	repo               repository.DonationRepository
+	questionnaireRepo  repository.QuestionnaireRepository
+	screeningRepo      repository.ScreeningRepository
+	eligibilityUsecase EligibilityUsecase
 }
 
-func NewDonationUsecase(repo repository.DonationRepository) DonationUsecase {
-	return &donationUsecaseImpl{repo: repo}
+func NewDonationUsecase(repo repository.DonationRepository, questionnaireRepo repository.QuestionnaireRepository, screeningRepo repository.ScreeningRepository, eligibilityUsecase EligibilityUsecase) DonationUsecase {
+	return &donationUsecaseImpl{
+		repo:               repo,
+		questionnaireRepo:  questionnaireRepo,
+		screeningRepo:      screeningRepo,
+		eligibilityUsecase: eligibilityUsecase,
+	}
 }
 
+// Create mencatat pendaftaran (booking) donasi baru dengan status pending; pendonor harus lolos penilaian
+// kelayakan pada tanggal donasi menurut kebijakan tenant lokasi. Donasi hanya bisa diselesaikan lewat
+// Update, yang mensyaratkan kuesioner dan pemeriksaan pra-donor.
 func (uc *donationUsecaseImpl) Create(ctx context.Context, req dto.CreateDonationRequest) (*entity.Donation, error) {
 	var donation entity.Donation
 	copier.Copy(&donation, &req)
@@ -38,6 +52,14 @@ func (uc *donationUsecaseImpl) Create(ctx context.Context, req dto.CreateDonatio
 	}
 
 	donation.UserID = &userID
+	donation.Status = entity.DonationStatusPending
+	if donation.DonationType == "" {
+		donation.DonationType = entity.DonationTypeWholeBlood
+	}
+
+	if err := uc.checkEligibility(ctx, userID, donation.LocationID, donation.DonationType, donation.DonationDate); err != nil {
+		return nil, err
+	}
 
 	if err := uc.repo.Save(ctx, &donation); err != nil {
 		log.Print(err.Error())
@@ -61,6 +83,30 @@ func (uc *donationUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto
 		return entity.Donation{}, err
 	}
 
+	// UpdateDonationRequest hanya membawa status, jadi kelayakan dinilai dengan tanggal, lokasi,
+	// dan jenis donasi yang tersimpan saat pendaftaran.
+	if req.Status == entity.DonationStatusCompleted && donation.Status != entity.DonationStatusCompleted && donation.UserID != nil {
+		if err := uc.checkEligibility(ctx, *donation.UserID, donation.LocationID, donation.DonationType, donation.DonationDate); err != nil {
+			return entity.Donation{}, err
+		}
+	}
+	if req.Status == entity.DonationStatusCompleted && donation.Status != entity.DonationStatusCompleted {
+		if _, err := uc.questionnaireRepo.FindSubmission(ctx, donation.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entity.Donation{}, entity.ErrQuestionnaireMissing
+			}
+			return entity.Donation{}, err
+		}
+
+		vitals, err := uc.screeningRepo.FindVitalSigns(ctx, donation.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && vitals.Outcome != entity.VitalSignsAccepted) {
+			return entity.Donation{}, entity.ErrVitalSignsMissing
+		}
+		if err != nil {
+			return entity.Donation{}, err
+		}
+	}
+
 	copier.Copy(&donation, &req)
 
 	return uc.repo.Update(ctx, donation)
@@ -73,3 +119,11 @@ func (uc *donationUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return uc.repo.Delete(ctx, id)
 }
+
+func (uc *donationUsecaseImpl) checkEligibility(ctx context.Context, userID, locationID uuid.UUID, donationType string, day time.Time) error {
+	result, err := uc.eligibilityUsecase.EvaluateAtLocation(ctx, userID, locationID, donationType, day)
+	if err != nil {
+		return err
+	}
+	return checkEligible(result)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDonationCreateIsAlwaysPending(t *testing.T) {
+	repo := newFakeDonationRepo()
+	uc := &donationUsecaseImpl{repo: repo, eligibilityUsecase: &fakeEligibilityUsecase{}}
+
+	donation, err := uc.Create(context.Background(), dto.CreateDonationRequest{
+		LocationID:   uuid.New(),
+		UserID:       uuid.NewString(),
+		DonationDate: time.Now(),
+		Name:         "Budi",
+	})
+	if err != nil {
+		t.Fatalf("Create() err = %v", err)
+	}
+	if donation.Status != entity.DonationStatusPending {
+		t.Fatalf("Status = %q, want %q", donation.Status, entity.DonationStatusPending)
+	}
+}
+
+func TestDonationCreateRequiresEligibility(t *testing.T) {
+	repo := newFakeDonationRepo()
+	uc := &donationUsecaseImpl{repo: repo, eligibilityUsecase: &fakeEligibilityUsecase{ineligible: true}}
+
+	_, err := uc.Create(context.Background(), dto.CreateDonationRequest{
+		LocationID:   uuid.New(),
+		UserID:       uuid.NewString(),
+		DonationDate: time.Now(),
+		Name:         "Budi",
+	})
+	if !errors.Is(err, entity.ErrDonorNotEligible) {
+		t.Fatalf("Create() err = %v, want ErrDonorNotEligible", err)
+	}
+	if len(repo.saved) != 0 {
+		t.Fatalf("saved %d donations, want 0", len(repo.saved))
+	}
+}
+
+func TestDonationUpdateToCompleted(t *testing.T) {
+	tests := []struct {
+		name          string
+		questionnaire bool
+		vitals        string // Outcome pemeriksaan pra-donor; kosong berarti belum dicatat
+		want          error
+	}{
+		{"questionnaire and accepted vitals", true, entity.VitalSignsAccepted, nil},
+		{"questionnaire missing", false, entity.VitalSignsAccepted, entity.ErrQuestionnaireMissing},
+		{"vitals missing", true, "", entity.ErrVitalSignsMissing},
+		{"vitals cancelled", true, entity.VitalSignsCancelled, entity.ErrVitalSignsMissing},
+		{"vitals deferred", true, entity.VitalSignsDeferred, entity.ErrVitalSignsMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			donation := entity.Donation{ID: uuid.New(), UserID: &userID, LocationID: uuid.New(), Status: entity.DonationStatusPending}
+
+			questionnaireRepo := &fakeQuestionnaireRepo{submissions: map[uuid.UUID]entity.QuestionnaireSubmission{}}
+			if tt.questionnaire {
+				questionnaireRepo.submissions[donation.ID] = entity.QuestionnaireSubmission{DonationID: donation.ID}
+			}
+			screeningRepo := &fakeScreeningRepo{vitals: map[uuid.UUID]entity.VitalSigns{}}
+			if tt.vitals != "" {
+				screeningRepo.vitals[donation.ID] = entity.VitalSigns{DonationID: donation.ID, Outcome: tt.vitals}
+			}
+
+			repo := newFakeDonationRepo(donation)
+			uc := &donationUsecaseImpl{
+				repo:               repo,
+				questionnaireRepo:  questionnaireRepo,
+				screeningRepo:      screeningRepo,
+				eligibilityUsecase: &fakeEligibilityUsecase{},
+			}
+
+			_, err := uc.Update(context.Background(), donation.ID, dto.UpdateDonationRequest{Status: entity.DonationStatusCompleted})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Update() err = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && len(repo.updated) != 0 {
+				t.Fatalf("updated %d donations, want 0", len(repo.updated))
+			}
+		})
+	}
+}
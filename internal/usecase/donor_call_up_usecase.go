@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/delivery/http/helper"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"donor-api/pkg/compatibility"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultCallUpRadiusKm      = 10.0
+	defaultCallUpMaxRecipients = 100
+)
+
+// --- Interface ---
+type DonorCallUpUsecase interface {
+	Broadcast(ctx context.Context, bloodRequestID uuid.UUID, req dto.BroadcastDonorCallUpRequest, actor dto.Actor) (entity.DonorCallUp, error)
+	FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID, actor dto.Actor) ([]entity.DonorCallUp, error)
+	FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.DonorCallUp, error)
+	Respond(ctx context.Context, id, userID uuid.UUID, req dto.RespondDonorCallUpRequest) (entity.DonorCallUpRecipient, error)
+	MarkArrived(ctx context.Context, id, userID uuid.UUID, actor dto.Actor) (entity.DonorCallUpRecipient, error)
+}
+
+// --- Implementation ---
+type donorCallUpUsecaseImpl struct {
+	repo                repository.DonorCallUpRepository
+	bloodRequestRepo    repository.BloodRequestRepository
+	locationRepo        repository.LocationRepository
+	bloodRequestUsecase BloodRequestUsecase
+	eligibilityUsecase  EligibilityUsecase
+}
+
+func NewDonorCallUpUsecase(repo repository.DonorCallUpRepository, bloodRequestRepo repository.BloodRequestRepository, locationRepo repository.LocationRepository, bloodRequestUsecase BloodRequestUsecase, eligibilityUsecase EligibilityUsecase) DonorCallUpUsecase {
+	return &donorCallUpUsecaseImpl{
+		repo:                repo,
+		bloodRequestRepo:    bloodRequestRepo,
+		locationRepo:        locationRepo,
+		bloodRequestUsecase: bloodRequestUsecase,
+		eligibilityUsecase:  eligibilityUsecase,
+	}
+}
+
+// Broadcast memanggil pendonor bila rencana pemenuhan dari stok masih menyisakan kekurangan.
+// Pendonor dipilih dari yang aktif, golongannya cocok untuk komponen yang diminta, berada dalam radius
+// lokasi permintaan, dan layak mendonor menurut kebijakan tenant lokasi; yang terdekat didahulukan. Pendonor yang sudah dipanggil
+// untuk permintaan yang sama dilewati sehingga panggilan ulang dengan radius lebih luas hanya menjangkau pendonor baru.
+// Kelayakan hanya dinilai sampai maxRecipients pendonor layak terkumpul.
+func (uc *donorCallUpUsecaseImpl) Broadcast(ctx context.Context, bloodRequestID uuid.UUID, req dto.BroadcastDonorCallUpRequest, actor dto.Actor) (entity.DonorCallUp, error) {
+	plan, err := uc.bloodRequestUsecase.MatchPlan(ctx, bloodRequestID, actor)
+	if err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	if plan.Shortfall <= 0 {
+		return entity.DonorCallUp{}, entity.ErrStockCoversRequest
+	}
+
+	bloodRequest, err := uc.bloodRequestRepo.FindByID(ctx, bloodRequestID)
+	if err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	location, err := uc.locationRepo.FindByID(ctx, bloodRequest.LocationID)
+	if err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	if location.Latitude == nil || location.Longitude == nil {
+		return entity.DonorCallUp{}, entity.ErrLocationNotGeocoded
+	}
+
+	radius := req.RadiusKm
+	if radius == 0 {
+		radius = defaultCallUpRadiusKm
+	}
+	maxRecipients := req.MaxRecipients
+	if maxRecipients == 0 {
+		maxRecipients = defaultCallUpMaxRecipients
+	}
+
+	now := time.Now()
+	recipient := compatibility.BloodGroup{BloodType: bloodRequest.BloodType, Rhesus: bloodRequest.Rhesus}
+	candidates, err := uc.repo.FindCandidates(ctx, repository.DonorCandidateFilter{
+		BloodGroups:      compatibility.Donors(bloodRequest.Component, recipient),
+		Day:              startOfDay(now),
+		ExcludeRequestID: bloodRequest.ID,
+		Latitude:         *location.Latitude,
+		Longitude:        *location.Longitude,
+		RadiusKm:         radius,
+	})
+	if err != nil {
+		return entity.DonorCallUp{}, err
+	}
+
+	var recipients []entity.DonorCallUpRecipient
+	for _, detail := range candidates {
+		if len(recipients) == maxRecipients {
+			break
+		}
+		distance := helper.Haversine(*location.Latitude, *location.Longitude, *detail.Latitude, *detail.Longitude)
+		if distance > radius {
+			continue
+		}
+		eligibility, err := uc.eligibilityUsecase.Evaluate(ctx, detail.UserID, location.TenantID, entity.DonationTypeWholeBlood, now)
+		if err != nil {
+			return entity.DonorCallUp{}, err
+		}
+		if !eligibility.Eligible {
+			continue
+		}
+		recipients = append(recipients, entity.DonorCallUpRecipient{
+			UserID:      detail.UserID,
+			FullName:    detail.FullName,
+			PhoneNumber: detail.PhoneNumber,
+			BloodType:   *detail.BloodType,
+			Rhesus:      *detail.Rhesus,
+			DistanceKm:  distance,
+			Status:      entity.DonorCallUpInvited,
+		})
+	}
+	if len(recipients) == 0 {
+		return entity.DonorCallUp{}, entity.ErrNoDonorsInRange
+	}
+	sort.SliceStable(recipients, func(i, j int) bool {
+		return recipients[i].DistanceKm < recipients[j].DistanceKm
+	})
+
+	callUp := entity.DonorCallUp{
+		BloodRequestID: bloodRequest.ID,
+		LocationID:     location.ID,
+		RadiusKm:       radius,
+		Shortfall:      plan.Shortfall,
+		Message:        req.Message,
+		CreatedBy:      actor.UserID,
+		Recipients:     recipients,
+	}
+
+	title := fmt.Sprintf("Dibutuhkan donor darah %s%s", bloodRequest.BloodType, bloodRequest.Rhesus)
+	notifications := make([]entity.Notification, 0, len(recipients))
+	for _, r := range recipients {
+		message := fmt.Sprintf("%s (%s) membutuhkan %d kantong %s untuk pasien bergolongan %s%s, sekitar %.1f km dari Anda. Mohon konfirmasi kesediaan Anda.",
+			location.LocationName, location.City, plan.Shortfall, bloodRequest.Component, bloodRequest.BloodType, bloodRequest.Rhesus, r.DistanceKm)
+		if req.Message != "" {
+			message += " " + req.Message
+		}
+		notifications = append(notifications, entity.Notification{
+			UserID:   r.UserID,
+			TenantID: &location.TenantID,
+			Type:     entity.NotificationDonorCallUp,
+			Title:    title,
+			Message:  message,
+		})
+	}
+
+	if err := uc.repo.Create(ctx, &callUp, notifications); err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	return callUp, nil
+}
+
+func (uc *donorCallUpUsecaseImpl) FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID, actor dto.Actor) ([]entity.DonorCallUp, error) {
+	if err := uc.checkRequestVisible(ctx, bloodRequestID, actor); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindByRequestID(ctx, bloodRequestID)
+}
+
+func (uc *donorCallUpUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.DonorCallUp, error) {
+	callUp, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	if err := uc.checkRequestVisible(ctx, callUp.BloodRequestID, actor); err != nil {
+		return entity.DonorCallUp{}, err
+	}
+	return callUp, nil
+}
+
+// Respond mencatat jawaban pendonor atas panggilan. Jawaban dapat diubah selama permintaan
+// masih berjalan dan pendonor belum tercatat datang.
+func (uc *donorCallUpUsecaseImpl) Respond(ctx context.Context, id, userID uuid.UUID, req dto.RespondDonorCallUpRequest) (entity.DonorCallUpRecipient, error) {
+	callUp, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.DonorCallUpRecipient{}, err
+	}
+	recipient, err := uc.repo.FindRecipient(ctx, id, userID)
+	if err != nil {
+		return entity.DonorCallUpRecipient{}, err
+	}
+	if recipient.ArrivedAt != nil {
+		return entity.DonorCallUpRecipient{}, entity.ErrDonorAlreadyArrived
+	}
+
+	bloodRequest, err := uc.bloodRequestRepo.FindByID(ctx, callUp.BloodRequestID)
+	if err != nil {
+		return entity.DonorCallUpRecipient{}, err
+	}
+	if !bloodRequest.IsOpen() {
+		return entity.DonorCallUpRecipient{}, fmt.Errorf("%w: %s is closed", entity.ErrInvalidRequestStatus, bloodRequest.Status)
+	}
+
+	now := time.Now()
+	recipient.Status = entity.DonorCallUpDeclined
+	if req.Response == "accept" {
+		recipient.Status = entity.DonorCallUpAccepted
+	}
+	recipient.RespondedAt = &now
+	return uc.repo.UpdateRecipient(ctx, recipient)
+}
+
+// MarkArrived mencatat kedatangan pendonor di lokasi. Pendonor yang datang dianggap menerima panggilan.
+func (uc *donorCallUpUsecaseImpl) MarkArrived(ctx context.Context, id, userID uuid.UUID, actor dto.Actor) (entity.DonorCallUpRecipient, error) {
+	if _, err := uc.FindByID(ctx, id, actor); err != nil {
+		return entity.DonorCallUpRecipient{}, err
+	}
+	recipient, err := uc.repo.FindRecipient(ctx, id, userID)
+	if err != nil {
+		return entity.DonorCallUpRecipient{}, err
+	}
+	if recipient.ArrivedAt != nil {
+		return entity.DonorCallUpRecipient{}, entity.ErrDonorAlreadyArrived
+	}
+
+	now := time.Now()
+	recipient.ArrivedAt = &now
+	recipient.Status = entity.DonorCallUpAccepted
+	if recipient.RespondedAt == nil {
+		recipient.RespondedAt = &now
+	}
+	return uc.repo.UpdateRecipient(ctx, recipient)
+}
+
+// checkRequestVisible memastikan permintaan darah panggilan berada dalam cakupan pemanggil.
+func (uc *donorCallUpUsecaseImpl) checkRequestVisible(ctx context.Context, bloodRequestID uuid.UUID, actor dto.Actor) error {
+	bloodRequest, err := uc.bloodRequestRepo.FindByID(ctx, bloodRequestID)
+	if err != nil {
+		return err
+	}
+	if !canView(actor, bloodRequest.CreatedBy, bloodRequest.TenantID) {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDonorCallUpBroadcastStopsAtMaxRecipients(t *testing.T) {
+	latitude, longitude := -6.2, 106.8
+	location := entity.Location{ID: uuid.New(), TenantID: tenantA, Latitude: &latitude, Longitude: &longitude}
+	bloodRequest := entity.BloodRequest{
+		ID:         uuid.New(),
+		TenantID:   tenantA,
+		LocationID: location.ID,
+		BloodType:  "A",
+		Rhesus:     "+",
+		Component:  entity.ComponentPRC,
+		Quantity:   2,
+		Status:     entity.BloodRequestStatusApproved,
+	}
+
+	// Kandidat sudah diurutkan repository dari yang terdekat; yang terakhir berada di luar radius.
+	bloodType, rhesus := "A", "+"
+	var candidates []entity.UserDetail
+	for _, offset := range []float64{0.001, 0.002, 0.003, 0.004, 0.5} {
+		lat, lng := latitude+offset, longitude
+		candidates = append(candidates, entity.UserDetail{
+			UserID:    uuid.New(),
+			FullName:  "Pendonor",
+			BloodType: &bloodType,
+			Rhesus:    &rhesus,
+			Latitude:  &lat,
+			Longitude: &lng,
+		})
+	}
+
+	repo := &fakeDonorCallUpRepo{candidates: candidates}
+	eligibility := &fakeEligibilityUsecase{}
+	uc := &donorCallUpUsecaseImpl{
+		repo:                repo,
+		bloodRequestRepo:    &fakeBloodRequestRepo{requests: map[uuid.UUID]entity.BloodRequest{bloodRequest.ID: bloodRequest}},
+		locationRepo:        newFakeLocationRepo(location),
+		bloodRequestUsecase: &fakeBloodRequestUsecase{shortfall: 2},
+		eligibilityUsecase:  eligibility,
+	}
+
+	callUp, err := uc.Broadcast(context.Background(), bloodRequest.ID, dto.BroadcastDonorCallUpRequest{RadiusKm: 5, MaxRecipients: 2}, adminA)
+	if err != nil {
+		t.Fatalf("Broadcast() err = %v", err)
+	}
+	if repo.filter.RadiusKm != 5 || repo.filter.Latitude != latitude || repo.filter.Longitude != longitude {
+		t.Errorf("candidate filter = %+v, want radius 5 around the request location", repo.filter)
+	}
+	if len(callUp.Recipients) != 2 {
+		t.Fatalf("recipients = %d, want 2", len(callUp.Recipients))
+	}
+	if eligibility.evaluated != 2 {
+		t.Errorf("eligibility evaluated %d times, want 2", eligibility.evaluated)
+	}
+	if callUp.Recipients[0].UserID != candidates[0].UserID || callUp.Recipients[1].UserID != candidates[1].UserID {
+		t.Error("recipients are not the nearest donors")
+	}
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	EligibilityRuleMinAge           = "min_age"
+	EligibilityRuleMaxAge           = "max_age"
+	EligibilityRuleMinWeight        = "min_weight"
+	EligibilityRuleDonationInterval = "donation_interval"
+	EligibilityRuleDeferral         = "deferral"
+)
+
+// --- Interface ---
+type EligibilityUsecase interface {
+	Evaluate(ctx context.Context, userID, tenantID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error)
+	EvaluateAtLocation(ctx context.Context, userID, locationID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error)
+	FindPolicy(ctx context.Context, tenantID uuid.UUID) (dto.EligibilityPolicyResponse, error)
+	// PolicyFor mengambil kebijakan tenant, atau kebijakan bawaan bila tenant belum mengaturnya.
+	PolicyFor(ctx context.Context, tenantID uuid.UUID) (entity.EligibilityPolicy, error)
+	SavePolicy(ctx context.Context, req dto.EligibilityPolicyRequest, tenantID uuid.UUID) (dto.EligibilityPolicyResponse, error)
+}
+
+// --- Implementation ---
+type eligibilityUsecaseImpl struct {
+	repo         repository.EligibilityRepository
+	locationRepo repository.LocationRepository
+}
+
+func NewEligibilityUsecase(repo repository.EligibilityRepository, locationRepo repository.LocationRepository) EligibilityUsecase {
+	return &eligibilityUsecaseImpl{
+		repo:         repo,
+		locationRepo: locationRepo,
+	}
+}
+
+// Evaluate menilai apakah pendonor boleh mendonor pada tanggal day menurut kebijakan tenant:
+// usia dari tanggal lahir, berat badan, jarak sejak donasi selesai terakhir sesuai jenis kelamin
+// dan jenis donasi yang direncanakan (bawaan whole_blood), serta penundaan yang masih berlaku.
+// NextEligibleDate adalah tanggal paling awal semua aturan terpenuhi, atau kosong bila ada aturan
+// yang tidak akan terpenuhi dengan menunggu.
+func (uc *eligibilityUsecaseImpl) Evaluate(ctx context.Context, userID, tenantID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error) {
+	day = startOfDay(day)
+	if donationType == "" {
+		donationType = entity.DonationTypeWholeBlood
+	}
+	policy, err := uc.PolicyFor(ctx, tenantID)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	detail, err := uc.repo.FindDonorDetail(ctx, userID)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+
+	var failed []dto.EligibilityRuleResult
+	fail := func(rule, message string, eligibleOn *time.Time) {
+		failed = append(failed, dto.EligibilityRuleResult{Rule: rule, Message: message, EligibleOn: eligibleOn})
+	}
+
+	if detail.DateOfBirth.IsZero() {
+		fail(EligibilityRuleMinAge, "date of birth is not recorded", nil)
+	} else {
+		age := ageOn(detail.DateOfBirth, day)
+		switch {
+		case age < policy.MinAge:
+			eligibleOn := startOfDay(detail.DateOfBirth.AddDate(policy.MinAge, 0, 0))
+			fail(EligibilityRuleMinAge, fmt.Sprintf("donor is %d years old, minimum is %d", age, policy.MinAge), &eligibleOn)
+		case age > policy.MaxAge:
+			fail(EligibilityRuleMaxAge, fmt.Sprintf("donor is %d years old, maximum is %d", age, policy.MaxAge), nil)
+		}
+	}
+
+	if detail.Weight < policy.MinWeightKg {
+		fail(EligibilityRuleMinWeight, fmt.Sprintf("weight %.1f kg is below the minimum of %.1f kg", detail.Weight, policy.MinWeightKg), nil)
+	}
+
+	last, err := uc.repo.FindLastCompletedDonation(ctx, userID, day)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	if last != nil {
+		interval := policy.IntervalDays(detail.Gender, donationType)
+		eligibleOn := startOfDay(last.DonationDate).AddDate(0, 0, interval)
+		if day.Before(eligibleOn) {
+			fail(EligibilityRuleDonationInterval, fmt.Sprintf("last donation was on %s, minimum interval before a %s donation is %d days",
+				last.DonationDate.Format("2006-01-02"), donationType, interval), &eligibleOn)
+		}
+	}
+
+	deferrals, err := uc.repo.FindActiveDeferrals(ctx, userID, day)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	for _, deferral := range deferrals {
+		fail(EligibilityRuleDeferral, deferral.Reason, deferral.DeferredUntil)
+	}
+
+	res := dto.EligibilityResponse{
+		UserID:      userID.String(),
+		EvaluatedOn: day,
+		Eligible:    len(failed) == 0,
+		FailedRules: []dto.EligibilityRuleResult{},
+	}
+	if res.Eligible {
+		res.NextEligibleDate = &day
+		return res, nil
+	}
+
+	res.FailedRules = failed
+	next := day
+	for _, rule := range failed {
+		if rule.EligibleOn == nil {
+			return res, nil
+		}
+		if rule.EligibleOn.After(next) {
+			next = *rule.EligibleOn
+		}
+	}
+	res.NextEligibleDate = &next
+	return res, nil
+}
+
+// EvaluateAtLocation menilai kelayakan dengan kebijakan tenant pemilik lokasi donasi.
+func (uc *eligibilityUsecaseImpl) EvaluateAtLocation(ctx context.Context, userID, locationID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error) {
+	location, err := uc.locationRepo.FindByID(ctx, locationID)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	return uc.Evaluate(ctx, userID, location.TenantID, donationType, day)
+}
+
+func (uc *eligibilityUsecaseImpl) FindPolicy(ctx context.Context, tenantID uuid.UUID) (dto.EligibilityPolicyResponse, error) {
+	if tenantID == uuid.Nil {
+		return dto.EligibilityPolicyResponse{}, entity.ErrTenantRequired
+	}
+	policy, err := uc.PolicyFor(ctx, tenantID)
+	return toEligibilityPolicyResponse(policy), err
+}
+
+// SavePolicy membuat atau memperbarui kebijakan kelayakan tenant pemanggil; superadmin wajib menyebutkan tenant_id.
+func (uc *eligibilityUsecaseImpl) SavePolicy(ctx context.Context, req dto.EligibilityPolicyRequest, tenantID uuid.UUID) (dto.EligibilityPolicyResponse, error) {
+	if tenantID == uuid.Nil {
+		if req.TenantID == nil || *req.TenantID == uuid.Nil {
+			return dto.EligibilityPolicyResponse{}, entity.ErrTenantRequired
+		}
+		tenantID = *req.TenantID
+	}
+	if err := checkPolicyRanges(req); err != nil {
+		return dto.EligibilityPolicyResponse{}, err
+	}
+
+	policy := entity.EligibilityPolicy{
+		TenantID:                     tenantID,
+		MinAge:                       req.MinAge,
+		MaxAge:                       req.MaxAge,
+		MinWeightKg:                  req.MinWeightKg,
+		WholeBloodIntervalMaleDays:   req.WholeBloodIntervalMaleDays,
+		WholeBloodIntervalFemaleDays: req.WholeBloodIntervalFemaleDays,
+		ApheresisIntervalMaleDays:    req.ApheresisIntervalMaleDays,
+		ApheresisIntervalFemaleDays:  req.ApheresisIntervalFemaleDays,
+		MinHaemoglobinMaleGdl:        req.MinHaemoglobinMaleGdl,
+		MinHaemoglobinFemaleGdl:      req.MinHaemoglobinFemaleGdl,
+		MaxHaemoglobinGdl:            req.MaxHaemoglobinGdl,
+		MinSystolicMmHg:              req.MinSystolicMmHg,
+		MaxSystolicMmHg:              req.MaxSystolicMmHg,
+		MinDiastolicMmHg:             req.MinDiastolicMmHg,
+		MaxDiastolicMmHg:             req.MaxDiastolicMmHg,
+		MinPulseBpm:                  req.MinPulseBpm,
+		MaxPulseBpm:                  req.MaxPulseBpm,
+		MinTemperatureC:              req.MinTemperatureC,
+		MaxTemperatureC:              req.MaxTemperatureC,
+	}
+	if err := uc.repo.SavePolicy(ctx, &policy); err != nil {
+		return dto.EligibilityPolicyResponse{}, err
+	}
+	return toEligibilityPolicyResponse(policy), nil
+}
+
+func (uc *eligibilityUsecaseImpl) PolicyFor(ctx context.Context, tenantID uuid.UUID) (entity.EligibilityPolicy, error) {
+	if tenantID == uuid.Nil {
+		return entity.DefaultEligibilityPolicy(tenantID), nil
+	}
+	policy, err := uc.repo.FindPolicy(ctx, tenantID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.DefaultEligibilityPolicy(tenantID), nil
+	}
+	return policy, err
+}
+
+// checkPolicyRanges memastikan setiap batas bawah kebijakan tidak melebihi batas atasnya.
+func checkPolicyRanges(req dto.EligibilityPolicyRequest) error {
+	switch {
+	case req.MinAge > req.MaxAge:
+		return fmt.Errorf("%w: min_age > max_age", entity.ErrInvalidEligibilityPolicy)
+	case max(req.MinHaemoglobinMaleGdl, req.MinHaemoglobinFemaleGdl) > req.MaxHaemoglobinGdl:
+		return fmt.Errorf("%w: min_haemoglobin > max_haemoglobin_gdl", entity.ErrInvalidEligibilityPolicy)
+	case req.MinSystolicMmHg > req.MaxSystolicMmHg:
+		return fmt.Errorf("%w: min_systolic_mmhg > max_systolic_mmhg", entity.ErrInvalidEligibilityPolicy)
+	case req.MinDiastolicMmHg > req.MaxDiastolicMmHg:
+		return fmt.Errorf("%w: min_diastolic_mmhg > max_diastolic_mmhg", entity.ErrInvalidEligibilityPolicy)
+	case req.MinPulseBpm > req.MaxPulseBpm:
+		return fmt.Errorf("%w: min_pulse_bpm > max_pulse_bpm", entity.ErrInvalidEligibilityPolicy)
+	case req.MinTemperatureC > req.MaxTemperatureC:
+		return fmt.Errorf("%w: min_temperature_c > max_temperature_c", entity.ErrInvalidEligibilityPolicy)
+	}
+	return nil
+}
+
+// checkEligible mengubah hasil penilaian yang tidak layak menjadi ErrDonorNotEligible beserta alasannya.
+func checkEligible(result dto.EligibilityResponse) error {
+	if result.Eligible {
+		return nil
+	}
+	reasons := make([]string, 0, len(result.FailedRules))
+	for _, rule := range result.FailedRules {
+		reasons = append(reasons, rule.Message)
+	}
+	return fmt.Errorf("%w: %s", entity.ErrDonorNotEligible, strings.Join(reasons, "; "))
+}
+
+// ageOn menghitung usia dalam tahun penuh pada tanggal day.
+func ageOn(dateOfBirth, day time.Time) int {
+	age := day.Year() - dateOfBirth.Year()
+	if day.Month() < dateOfBirth.Month() || (day.Month() == dateOfBirth.Month() && day.Day() < dateOfBirth.Day()) {
+		age--
+	}
+	return age
+}
+
+func toEligibilityPolicyResponse(policy entity.EligibilityPolicy) dto.EligibilityPolicyResponse {
+	res := dto.EligibilityPolicyResponse{
+		TenantID:                     policy.TenantID.String(),
+		MinAge:                       policy.MinAge,
+		MaxAge:                       policy.MaxAge,
+		MinWeightKg:                  policy.MinWeightKg,
+		WholeBloodIntervalMaleDays:   policy.WholeBloodIntervalMaleDays,
+		WholeBloodIntervalFemaleDays: policy.WholeBloodIntervalFemaleDays,
+		ApheresisIntervalMaleDays:    policy.ApheresisIntervalMaleDays,
+		ApheresisIntervalFemaleDays:  policy.ApheresisIntervalFemaleDays,
+		MinHaemoglobinMaleGdl:        policy.MinHaemoglobinMaleGdl,
+		MinHaemoglobinFemaleGdl:      policy.MinHaemoglobinFemaleGdl,
+		MaxHaemoglobinGdl:            policy.MaxHaemoglobinGdl,
+		MinSystolicMmHg:              policy.MinSystolicMmHg,
+		MaxSystolicMmHg:              policy.MaxSystolicMmHg,
+		MinDiastolicMmHg:             policy.MinDiastolicMmHg,
+		MaxDiastolicMmHg:             policy.MaxDiastolicMmHg,
+		MinPulseBpm:                  policy.MinPulseBpm,
+		MaxPulseBpm:                  policy.MaxPulseBpm,
+		MinTemperatureC:              policy.MinTemperatureC,
+		MaxTemperatureC:              policy.MaxTemperatureC,
+		IsDefault:                    policy.ID == uuid.Nil,
+	}
+	if !policy.UpdatedAt.IsZero() {
+		res.UpdatedAt = &policy.UpdatedAt
+	}
+	return res
+}
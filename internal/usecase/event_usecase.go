@@ -6,62 +6,110 @@ import (
 	"donor-api/internal/delivery/http/helper"
 	"donor-api/internal/entity"
 	"donor-api/internal/repository"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
 // --- Interface ---
 type EventUsecase interface {
-	Create(ctx context.Context, req dto.EventRequest) (entity.Event, error)
-	FindAll(ctx context.Context, page, limit int) ([]entity.Event, int64, error)
-	FindByID(ctx context.Context, id uuid.UUID) (entity.Event, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.EventRequest) (entity.Event, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Create(ctx context.Context, req dto.EventRequest, actor dto.Actor) (entity.Event, error)
+	FindAll(ctx context.Context, actor dto.Actor, page, limit int) ([]entity.Event, int64, error)
+	FindPublic(ctx context.Context, city string, page, limit int) ([]repository.PublicEvent, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.Event, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.EventRequest, actor dto.Actor, version int) (entity.Event, error)
+	Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error
 }
 
 // --- Implementation ---
 type eventUsecaseImpl struct {
-	repo repository.EventRepository
+	repo         repository.EventRepository
+	locationRepo repository.LocationRepository
 }
 
-func NewEventUsecase(repo repository.EventRepository) EventUsecase {
-	return &eventUsecaseImpl{repo: repo}
+func NewEventUsecase(repo repository.EventRepository, locationRepo repository.LocationRepository) EventUsecase {
+	return &eventUsecaseImpl{
+		repo:         repo,
+		locationRepo: locationRepo,
+	}
 }
 
-func (uc *eventUsecaseImpl) Create(ctx context.Context, req dto.EventRequest) (entity.Event, error) {
+// Create mencatat pemanggil sebagai pembuat dan tenant lokasi acara sebagai tenant pemilik.
+func (uc *eventUsecaseImpl) Create(ctx context.Context, req dto.EventRequest, actor dto.Actor) (entity.Event, error) {
+	location, err := findTenantLocation(ctx, uc.locationRepo, req.LocationID, actor.TenantID)
+	if err != nil {
+		return entity.Event{}, err
+	}
+
 	var event entity.Event
 	copier.Copy(&event, &req)
 
 	event.Slug = helper.GenerateSlug(req.EventName)
-	err := uc.repo.Save(ctx, &event)
+	event.TenantID = location.TenantID
+	event.CreatedBy = actor.UserID
+	err = uc.repo.Save(ctx, &event)
 	return event, err
 }
 
-func (uc *eventUsecaseImpl) FindAll(ctx context.Context, page, limit int) ([]entity.Event, int64, error) {
+func (uc *eventUsecaseImpl) FindAll(ctx context.Context, actor dto.Actor, page, limit int) ([]entity.Event, int64, error) {
 	offset := (page - 1) * limit
-	return uc.repo.FindAll(ctx, limit, offset)
+	tenantID, createdBy := scopeOf(actor)
+	return uc.repo.FindAll(ctx, repository.EventFilter{TenantID: tenantID, CreatedBy: createdBy}, limit, offset)
 }
 
-func (uc *eventUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Event, error) {
-	return uc.repo.FindByID(ctx, id)
+// FindPublic mengambil acara yang belum berakhir untuk ditampilkan tanpa autentikasi.
+func (uc *eventUsecaseImpl) FindPublic(ctx context.Context, city string, page, limit int) ([]repository.PublicEvent, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindPublic(ctx, city, startOfDay(time.Now()), limit, offset)
 }
 
-func (uc *eventUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.EventRequest) (entity.Event, error) {
+func (uc *eventUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.Event, error) {
 	event, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return entity.Event{}, err
 	}
+	if !canView(actor, event.CreatedBy, event.TenantID) {
+		return entity.Event{}, gorm.ErrRecordNotFound
+	}
+	return event, nil
+}
+
+func (uc *eventUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.EventRequest, actor dto.Actor, version int) (entity.Event, error) {
+	event, err := uc.findManaged(ctx, id, actor)
+	if err != nil {
+		return entity.Event{}, err
+	}
+	if err := checkVersion(event.Version, version); err != nil {
+		return entity.Event{}, err
+	}
+	location, err := findTenantLocation(ctx, uc.locationRepo, req.LocationID, actor.TenantID)
+	if err != nil {
+		return entity.Event{}, err
+	}
 
 	copier.Copy(&event, &req)
+	event.TenantID = location.TenantID
 
 	return uc.repo.Update(ctx, event)
 }
 
-func (uc *eventUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := uc.repo.FindByID(ctx, id)
-	if err != nil {
+func (uc *eventUsecaseImpl) Delete(ctx context.Context, id uuid.UUID, actor dto.Actor) error {
+	if _, err := uc.findManaged(ctx, id, actor); err != nil {
 		return err
 	}
 	return uc.repo.Delete(ctx, id)
 }
+
+// findManaged mengambil acara yang boleh diubah pemanggil: pembuatnya, admin tenant yang sama, atau superadmin.
+func (uc *eventUsecaseImpl) findManaged(ctx context.Context, id uuid.UUID, actor dto.Actor) (entity.Event, error) {
+	event, err := uc.FindByID(ctx, id, actor)
+	if err != nil {
+		return entity.Event{}, err
+	}
+	if !canManage(actor, event.CreatedBy, event.TenantID) {
+		return entity.Event{}, entity.ErrForbidden
+	}
+	return event, nil
+}
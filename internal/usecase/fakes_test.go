@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Repository palsu untuk pengujian usecase. Setiap fake menyematkan interface repository-nya,
+// jadi method yang tidak dipakai pengujian akan panic bila terpanggil.
+
+var (
+	tenantA = uuid.New()
+	tenantB = uuid.New()
+
+	superadmin = dto.Actor{UserID: uuid.New(), Role: "superadmin"}
+	adminA     = dto.Actor{UserID: uuid.New(), TenantID: tenantA, Role: "admin"}
+	adminB     = dto.Actor{UserID: uuid.New(), TenantID: tenantB, Role: "admin"}
+)
+
+type fakeLocationRepo struct {
+	repository.LocationRepository
+	locations map[uuid.UUID]entity.Location
+}
+
+func newFakeLocationRepo(locations ...entity.Location) *fakeLocationRepo {
+	repo := &fakeLocationRepo{locations: map[uuid.UUID]entity.Location{}}
+	for _, location := range locations {
+		repo.locations[location.ID] = location
+	}
+	return repo
+}
+
+func (r *fakeLocationRepo) FindByID(ctx context.Context, id uuid.UUID) (entity.Location, error) {
+	location, ok := r.locations[id]
+	if !ok {
+		return entity.Location{}, gorm.ErrRecordNotFound
+	}
+	return location, nil
+}
+
+type fakeDonationRepo struct {
+	repository.DonationRepository
+	donations map[uuid.UUID]entity.Donation
+	saved     []entity.Donation
+	updated   []entity.Donation
+}
+
+func newFakeDonationRepo(donations ...entity.Donation) *fakeDonationRepo {
+	repo := &fakeDonationRepo{donations: map[uuid.UUID]entity.Donation{}}
+	for _, donation := range donations {
+		repo.donations[donation.ID] = donation
+	}
+	return repo
+}
+
+func (r *fakeDonationRepo) FindByID(ctx context.Context, id uuid.UUID) (entity.Donation, error) {
+	donation, ok := r.donations[id]
+	if !ok {
+		return entity.Donation{}, gorm.ErrRecordNotFound
+	}
+	return donation, nil
+}
+
+func (r *fakeDonationRepo) Save(ctx context.Context, donation *entity.Donation) error {
+	donation.ID = uuid.New()
+	r.saved = append(r.saved, *donation)
+	return nil
+}
+
+func (r *fakeDonationRepo) Update(ctx context.Context, donation entity.Donation) (entity.Donation, error) {
+	r.updated = append(r.updated, donation)
+	return donation, nil
+}
+
+type fakeScreeningRepo struct {
+	repository.ScreeningRepository
+	vitals    map[uuid.UUID]entity.VitalSigns
+	deferrals map[uuid.UUID]entity.DonorDeferral
+	filter    repository.DonorDeferralFilter
+	recorded  int
+}
+
+func (r *fakeScreeningRepo) Record(ctx context.Context, donationID uuid.UUID, results []entity.ScreeningResult, actorID uuid.UUID) (entity.Donation, error) {
+	r.recorded++
+	return entity.Donation{ID: donationID}, nil
+}
+
+func (r *fakeScreeningRepo) FindByDonationID(ctx context.Context, donationID uuid.UUID) ([]entity.ScreeningResult, error) {
+	return nil, nil
+}
+
+func (r *fakeScreeningRepo) FindVitalSigns(ctx context.Context, donationID uuid.UUID) (entity.VitalSigns, error) {
+	vitals, ok := r.vitals[donationID]
+	if !ok {
+		return entity.VitalSigns{}, gorm.ErrRecordNotFound
+	}
+	return vitals, nil
+}
+
+func (r *fakeScreeningRepo) FindDeferrals(ctx context.Context, filter repository.DonorDeferralFilter, limit, offset int) ([]entity.DonorDeferral, int64, error) {
+	r.filter = filter
+	return nil, 0, nil
+}
+
+func (r *fakeScreeningRepo) FindDeferralByID(ctx context.Context, id uuid.UUID) (entity.DonorDeferral, error) {
+	deferral, ok := r.deferrals[id]
+	if !ok {
+		return entity.DonorDeferral{}, gorm.ErrRecordNotFound
+	}
+	return deferral, nil
+}
+
+func (r *fakeScreeningRepo) UpdateDeferral(ctx context.Context, deferral entity.DonorDeferral) (entity.DonorDeferral, error) {
+	return deferral, nil
+}
+
+type fakeQuestionnaireRepo struct {
+	repository.QuestionnaireRepository
+	submissions map[uuid.UUID]entity.QuestionnaireSubmission
+}
+
+func (r *fakeQuestionnaireRepo) FindSubmission(ctx context.Context, donationID uuid.UUID) (entity.QuestionnaireSubmission, error) {
+	submission, ok := r.submissions[donationID]
+	if !ok {
+		return entity.QuestionnaireSubmission{}, gorm.ErrRecordNotFound
+	}
+	return submission, nil
+}
+
+// fakeEligibilityUsecase menilai setiap pendonor layak kecuali ineligible bernilai true.
+type fakeEligibilityUsecase struct {
+	EligibilityUsecase
+	ineligible bool
+	evaluated  int
+}
+
+func (uc *fakeEligibilityUsecase) Evaluate(ctx context.Context, userID, tenantID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error) {
+	uc.evaluated++
+	return dto.EligibilityResponse{Eligible: !uc.ineligible}, nil
+}
+
+func (uc *fakeEligibilityUsecase) EvaluateAtLocation(ctx context.Context, userID, locationID uuid.UUID, donationType string, day time.Time) (dto.EligibilityResponse, error) {
+	return dto.EligibilityResponse{Eligible: !uc.ineligible}, nil
+}
+
+type fakeStockRepo struct {
+	repository.StockRepository
+	stocks     map[uuid.UUID]entity.Stock
+	received   []entity.BloodUnit
+	writtenOff int
+	movement   entity.StockMovement
+}
+
+func newFakeStockRepo(stocks ...entity.Stock) *fakeStockRepo {
+	repo := &fakeStockRepo{stocks: map[uuid.UUID]entity.Stock{}}
+	for _, stock := range stocks {
+		repo.stocks[stock.ID] = stock
+	}
+	return repo
+}
+
+func (r *fakeStockRepo) FindByID(ctx context.Context, id uuid.UUID) (entity.Stock, error) {
+	stock, ok := r.stocks[id]
+	if !ok {
+		return entity.Stock{}, gorm.ErrRecordNotFound
+	}
+	return stock, nil
+}
+
+func (r *fakeStockRepo) ReceiveUnits(ctx context.Context, units []entity.BloodUnit, movement entity.StockMovement) error {
+	r.received = append(r.received, units...)
+	r.movement = movement
+	return nil
+}
+
+func (r *fakeStockRepo) WriteOffUnits(ctx context.Context, key entity.Stock, quantity int, today time.Time, movement entity.StockMovement) ([]entity.BloodUnit, error) {
+	if quantity > key.BagQuantity {
+		return nil, entity.ErrInsufficientStock
+	}
+	r.writtenOff += quantity
+	r.movement = movement
+	return make([]entity.BloodUnit, quantity), nil
+}
+
+// fakeConsumptionRepo menyediakan pemakaian harian dan stok saat ini untuk pengujian forecast.
+type fakeConsumptionRepo struct {
+	repository.StockRepository
+	issued         []repository.DailyConsumption
+	reservedIssued []repository.DailyConsumption
+	aggregates     []repository.StockAggregate
+}
+
+func (r *fakeConsumptionRepo) DailyIssued(ctx context.Context, filter repository.ConsumptionFilter, from, to time.Time) ([]repository.DailyConsumption, error) {
+	return r.issued, nil
+}
+
+func (r *fakeConsumptionRepo) DailyReservedIssued(ctx context.Context, filter repository.ConsumptionFilter, from, to time.Time) ([]repository.DailyConsumption, error) {
+	return r.reservedIssued, nil
+}
+
+func (r *fakeConsumptionRepo) Summarize(ctx context.Context, filter repository.StockFilter) ([]repository.StockAggregate, error) {
+	return r.aggregates, nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	details map[uuid.UUID]entity.UserDetail
+}
+
+func (r *fakeUserRepo) FindDetailByUserID(ctx context.Context, userID uuid.UUID) (entity.UserDetail, error) {
+	detail, ok := r.details[userID]
+	if !ok {
+		return entity.UserDetail{}, gorm.ErrRecordNotFound
+	}
+	return detail, nil
+}
+
+type fakeBloodUnitRepo struct {
+	repository.BloodUnitRepository
+	units   map[uuid.UUID]entity.BloodUnit
+	saved   []entity.BloodUnit
+	deleted []uuid.UUID
+}
+
+func newFakeBloodUnitRepo(units ...entity.BloodUnit) *fakeBloodUnitRepo {
+	repo := &fakeBloodUnitRepo{units: map[uuid.UUID]entity.BloodUnit{}}
+	for _, unit := range units {
+		repo.units[unit.ID] = unit
+	}
+	return repo
+}
+
+func (r *fakeBloodUnitRepo) FindByID(ctx context.Context, id uuid.UUID) (entity.BloodUnit, error) {
+	unit, ok := r.units[id]
+	if !ok {
+		return entity.BloodUnit{}, gorm.ErrRecordNotFound
+	}
+	return unit, nil
+}
+
+func (r *fakeBloodUnitRepo) Save(ctx context.Context, unit *entity.BloodUnit, movement entity.StockMovement) error {
+	unit.ID = uuid.New()
+	r.saved = append(r.saved, *unit)
+	return nil
+}
+
+func (r *fakeBloodUnitRepo) Delete(ctx context.Context, id uuid.UUID, movement entity.StockMovement) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type fakeBloodRequestRepo struct {
+	repository.BloodRequestRepository
+	requests map[uuid.UUID]entity.BloodRequest
+	deleted  []uuid.UUID
+}
+
+func (r *fakeBloodRequestRepo) Delete(ctx context.Context, id uuid.UUID, release entity.StockMovement) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *fakeBloodRequestRepo) FindByID(ctx context.Context, id uuid.UUID) (entity.BloodRequest, error) {
+	bloodRequest, ok := r.requests[id]
+	if !ok {
+		return entity.BloodRequest{}, gorm.ErrRecordNotFound
+	}
+	return bloodRequest, nil
+}
+
+type fakeReservationRepo struct {
+	repository.StockReservationRepository
+	updated   []entity.BloodRequest
+	histories []*entity.BloodRequestStatusHistory
+}
+
+func (r *fakeReservationRepo) UpdateAndReserve(ctx context.Context, bloodRequest entity.BloodRequest, history *entity.BloodRequestStatusHistory, rereserve bool, today, expiresAt time.Time, release, movement entity.StockMovement) (entity.BloodRequest, error) {
+	r.updated = append(r.updated, bloodRequest)
+	r.histories = append(r.histories, history)
+	bloodRequest.Version++
+	return bloodRequest, nil
+}
+
+func (r *fakeReservationRepo) FindByRequestID(ctx context.Context, bloodRequestID uuid.UUID) ([]entity.StockReservation, error) {
+	return nil, nil
+}
+
+type fakeBloodRequestUsecase struct {
+	BloodRequestUsecase
+	shortfall int
+}
+
+func (uc *fakeBloodRequestUsecase) MatchPlan(ctx context.Context, id uuid.UUID, actor dto.Actor) (dto.BloodRequestMatchPlanResponse, error) {
+	return dto.BloodRequestMatchPlanResponse{Shortfall: uc.shortfall}, nil
+}
+
+type fakeDonorCallUpRepo struct {
+	repository.DonorCallUpRepository
+	candidates []entity.UserDetail
+	filter     repository.DonorCandidateFilter
+	created    []entity.DonorCallUp
+}
+
+func (r *fakeDonorCallUpRepo) FindCandidates(ctx context.Context, filter repository.DonorCandidateFilter) ([]entity.UserDetail, error) {
+	r.filter = filter
+	return r.candidates, nil
+}
+
+func (r *fakeDonorCallUpRepo) Create(ctx context.Context, callUp *entity.DonorCallUp, notifications []entity.Notification) error {
+	r.created = append(r.created, *callUp)
+	return nil
+}
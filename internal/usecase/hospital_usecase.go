@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type HospitalUsecase interface {
+	Create(ctx context.Context, req dto.HospitalRequest, tenantID uuid.UUID) (entity.Hospital, error)
+	FindAll(ctx context.Context, filter repository.HospitalFilter, page, limit int) ([]entity.Hospital, int64, error)
+	FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Hospital, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.HospitalRequest, tenantID uuid.UUID, version int) (entity.Hospital, error)
+	Delete(ctx context.Context, id, tenantID uuid.UUID) error
+}
+
+// --- Implementation ---
+type hospitalUsecaseImpl struct {
+	repo repository.HospitalRepository
+}
+
+func NewHospitalUsecase(repo repository.HospitalRepository) HospitalUsecase {
+	return &hospitalUsecaseImpl{repo: repo}
+}
+
+// Create menyimpan rumah sakit untuk tenant pemanggil; superadmin wajib menyebutkan tenant_id.
+func (uc *hospitalUsecaseImpl) Create(ctx context.Context, req dto.HospitalRequest, tenantID uuid.UUID) (entity.Hospital, error) {
+	if tenantID == uuid.Nil {
+		if req.TenantID == nil || *req.TenantID == uuid.Nil {
+			return entity.Hospital{}, entity.ErrTenantRequired
+		}
+		tenantID = *req.TenantID
+	}
+
+	hospital := entity.Hospital{
+		TenantID:    tenantID,
+		Name:        req.Name,
+		Address:     req.Address,
+		City:        req.City,
+		PhoneNumber: req.PhoneNumber,
+	}
+	err := uc.repo.Save(ctx, &hospital)
+	return hospital, err
+}
+
+func (uc *hospitalUsecaseImpl) FindAll(ctx context.Context, filter repository.HospitalFilter, page, limit int) ([]entity.Hospital, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, filter, limit, offset)
+}
+
+func (uc *hospitalUsecaseImpl) FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Hospital, error) {
+	hospital, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Hospital{}, err
+	}
+	if tenantID != uuid.Nil && hospital.TenantID != tenantID {
+		return entity.Hospital{}, gorm.ErrRecordNotFound
+	}
+	return hospital, nil
+}
+
+// Update memperbarui data rumah sakit. Tenant pemilik tidak dapat dipindahkan.
+func (uc *hospitalUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.HospitalRequest, tenantID uuid.UUID, version int) (entity.Hospital, error) {
+	hospital, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.Hospital{}, err
+	}
+	if err := checkVersion(hospital.Version, version); err != nil {
+		return entity.Hospital{}, err
+	}
+
+	hospital.Name = req.Name
+	hospital.Address = req.Address
+	hospital.City = req.City
+	hospital.PhoneNumber = req.PhoneNumber
+	return uc.repo.Update(ctx, hospital)
+}
+
+func (uc *hospitalUsecaseImpl) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
+	if _, err := uc.FindByID(ctx, id, tenantID); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, id)
+}
@@ -16,7 +16,7 @@ type LocationUsecase interface {
 	Create(ctx context.Context, req dto.LocationRequest, tenantID uuid.UUID) (*entity.Location, error)
 	FindAll(ctx context.Context, page, limit int, tenantID uuid.UUID) ([]entity.Location, int64, error)
 	FindByID(ctx context.Context, id uuid.UUID) (entity.Location, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.LocationRequest) (entity.Location, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.LocationRequest, version int) (entity.Location, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetAllByUserLocation(ctx context.Context, lat float64, lon float64) ([]dto.LocationByUserResponse, error)
 }
@@ -57,11 +57,14 @@ func (uc *locationUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (enti
 	return uc.repo.FindByID(ctx, id)
 }
 
-func (uc *locationUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.LocationRequest) (entity.Location, error) {
+func (uc *locationUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.LocationRequest, version int) (entity.Location, error) {
 	location, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return entity.Location{}, err
 	}
+	if err := checkVersion(location.Version, version); err != nil {
+		return entity.Location{}, err
+	}
 
 	copier.Copy(&location, &req)
 
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type NotificationUsecase interface {
+	FindAll(ctx context.Context, filter repository.NotificationFilter, page, limit int) ([]entity.Notification, int64, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) (entity.Notification, error)
+}
+
+// --- Implementation ---
+type notificationUsecaseImpl struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationUsecase(repo repository.NotificationRepository) NotificationUsecase {
+	return &notificationUsecaseImpl{repo: repo}
+}
+
+// FindAll mengambil notifikasi milik satu pengguna, terbaru lebih dulu.
+func (uc *notificationUsecaseImpl) FindAll(ctx context.Context, filter repository.NotificationFilter, page, limit int) ([]entity.Notification, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, filter, limit, offset)
+}
+
+// MarkRead menandai notifikasi sudah dibaca. Notifikasi milik pengguna lain diperlakukan sebagai tidak ditemukan.
+func (uc *notificationUsecaseImpl) MarkRead(ctx context.Context, id, userID uuid.UUID) (entity.Notification, error) {
+	notification, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Notification{}, err
+	}
+	if notification.UserID != userID {
+		return entity.Notification{}, gorm.ErrRecordNotFound
+	}
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	now := time.Now()
+	notification.ReadAt = &now
+	return uc.repo.MarkRead(ctx, notification)
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type QuestionnaireUsecase interface {
+	Create(ctx context.Context, req dto.QuestionnaireRequest, actor dto.Actor) (entity.Questionnaire, error)
+	FindAll(ctx context.Context, tenantID uuid.UUID, page, limit int) ([]entity.Questionnaire, int64, error)
+	FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Questionnaire, error)
+	FindForDonation(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Questionnaire, *entity.QuestionnaireSubmission, error)
+	Submit(ctx context.Context, donationID uuid.UUID, req dto.SubmitQuestionnaireRequest, actor dto.Actor) (entity.QuestionnaireSubmission, error)
+}
+
+// --- Implementation ---
+type questionnaireUsecaseImpl struct {
+	repo         repository.QuestionnaireRepository
+	donationRepo repository.DonationRepository
+	locationRepo repository.LocationRepository
+}
+
+func NewQuestionnaireUsecase(repo repository.QuestionnaireRepository, donationRepo repository.DonationRepository, locationRepo repository.LocationRepository) QuestionnaireUsecase {
+	return &questionnaireUsecaseImpl{
+		repo:         repo,
+		donationRepo: donationRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// Create menerbitkan versi baru kuesioner untuk tenant pemanggil; superadmin wajib menyebutkan tenant_id.
+func (uc *questionnaireUsecaseImpl) Create(ctx context.Context, req dto.QuestionnaireRequest, actor dto.Actor) (entity.Questionnaire, error) {
+	tenantID := actor.TenantID
+	if tenantID == uuid.Nil {
+		if req.TenantID == nil || *req.TenantID == uuid.Nil {
+			return entity.Questionnaire{}, entity.ErrTenantRequired
+		}
+		tenantID = *req.TenantID
+	}
+
+	questions := make([]entity.QuestionnaireQuestion, 0, len(req.Questions))
+	codes := map[string]bool{}
+	for i, input := range req.Questions {
+		if codes[input.Code] {
+			return entity.Questionnaire{}, fmt.Errorf("%w: question code %s is duplicated", entity.ErrInvalidQuestionnaire, input.Code)
+		}
+		codes[input.Code] = true
+
+		question := entity.QuestionnaireQuestion{
+			Position:     i + 1,
+			Code:         input.Code,
+			Text:         input.Text,
+			Type:         input.Type,
+			Required:     input.Required,
+			Options:      input.Options,
+			DeferAnswers: input.DeferAnswers,
+			DeferDays:    input.DeferDays,
+			DeferReason:  input.DeferReason,
+		}
+		if err := validateQuestion(question); err != nil {
+			return entity.Questionnaire{}, err
+		}
+		questions = append(questions, question)
+	}
+
+	questionnaire := entity.Questionnaire{
+		TenantID:  tenantID,
+		Title:     req.Title,
+		CreatedBy: actor.UserID,
+		Questions: questions,
+	}
+	err := uc.repo.Create(ctx, &questionnaire)
+	return questionnaire, err
+}
+
+func (uc *questionnaireUsecaseImpl) FindAll(ctx context.Context, tenantID uuid.UUID, page, limit int) ([]entity.Questionnaire, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, tenantID, limit, offset)
+}
+
+func (uc *questionnaireUsecaseImpl) FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Questionnaire, error) {
+	questionnaire, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Questionnaire{}, err
+	}
+	if tenantID != uuid.Nil && questionnaire.TenantID != tenantID {
+		return entity.Questionnaire{}, gorm.ErrRecordNotFound
+	}
+	return questionnaire, nil
+}
+
+// FindForDonation mengambil kuesioner yang harus diisi untuk donasi: versi yang sudah dijawab bila
+// jawaban sudah dikirim, selain itu versi terbaru milik tenant lokasi donasi.
+func (uc *questionnaireUsecaseImpl) FindForDonation(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Questionnaire, *entity.QuestionnaireSubmission, error) {
+	_, location, err := uc.findDonation(ctx, donationID, actor)
+	if err != nil {
+		return entity.Questionnaire{}, nil, err
+	}
+
+	submission, err := uc.repo.FindSubmission(ctx, donationID)
+	switch {
+	case err == nil:
+		questionnaire, err := uc.repo.FindByID(ctx, submission.QuestionnaireID)
+		return questionnaire, &submission, err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		questionnaire, err := uc.repo.FindCurrent(ctx, location.TenantID)
+		return questionnaire, nil, err
+	default:
+		return entity.Questionnaire{}, nil, err
+	}
+}
+
+// Submit menyimpan jawaban kuesioner versi terbaru untuk donasi yang masih pending. Jawaban yang
+// memicu penundaan mencatat DonorDeferral untuk pendonor dan membatalkan donasi.
+func (uc *questionnaireUsecaseImpl) Submit(ctx context.Context, donationID uuid.UUID, req dto.SubmitQuestionnaireRequest, actor dto.Actor) (entity.QuestionnaireSubmission, error) {
+	donation, location, err := uc.findDonation(ctx, donationID, actor)
+	if err != nil {
+		return entity.QuestionnaireSubmission{}, err
+	}
+	if donation.Status != entity.DonationStatusPending {
+		return entity.QuestionnaireSubmission{}, entity.ErrDonationNotPending
+	}
+
+	questionnaire, err := uc.repo.FindCurrent(ctx, location.TenantID)
+	if err != nil {
+		return entity.QuestionnaireSubmission{}, err
+	}
+
+	values := make(map[uuid.UUID]string, len(req.Answers))
+	for _, answer := range req.Answers {
+		if _, ok := values[answer.QuestionID]; ok {
+			return entity.QuestionnaireSubmission{}, fmt.Errorf("%w: question %s is answered more than once", entity.ErrInvalidQuestionAnswer, answer.QuestionID)
+		}
+		values[answer.QuestionID] = answer.Value
+	}
+
+	submission := entity.QuestionnaireSubmission{
+		DonationID:      donation.ID,
+		QuestionnaireID: questionnaire.ID,
+		UserID:          *donation.UserID,
+		SubmittedBy:     actor.UserID,
+	}
+	var deferrals []entity.DonorDeferral
+	for _, question := range questionnaire.Questions {
+		value, answered := values[question.ID]
+		delete(values, question.ID)
+		if !validAnswer(question, value) {
+			return entity.QuestionnaireSubmission{}, fmt.Errorf("%w: answer to %s is missing or not allowed", entity.ErrInvalidQuestionAnswer, question.Code)
+		}
+		if !answered {
+			continue
+		}
+
+		defers := question.Defers(value)
+		submission.Answers = append(submission.Answers, entity.QuestionnaireAnswer{
+			QuestionID: question.ID,
+			Code:       question.Code,
+			Value:      value,
+			Defers:     defers,
+		})
+		if !defers {
+			continue
+		}
+
+		submission.Deferred = true
+		reason := question.DeferReason
+		if reason == "" {
+			reason = fmt.Sprintf("Kuesioner pra-donor: %s (%s)", question.Text, value)
+		}
+		deferral := entity.DonorDeferral{
+			UserID:     *donation.UserID,
+			DonationID: &donation.ID,
+			Reason:     reason,
+		}
+		if question.DeferDays != nil {
+			until := startOfDay(donation.DonationDate).AddDate(0, 0, *question.DeferDays)
+			deferral.DeferredUntil = &until
+		}
+		deferrals = append(deferrals, deferral)
+	}
+	if len(values) > 0 {
+		return entity.QuestionnaireSubmission{}, fmt.Errorf("%w: some answers are not part of questionnaire version %d", entity.ErrInvalidQuestionAnswer, questionnaire.Version)
+	}
+
+	if err := uc.repo.Submit(ctx, &submission, deferrals); err != nil {
+		return entity.QuestionnaireSubmission{}, err
+	}
+	return submission, nil
+}
+
+// findDonation mengambil donasi beserta lokasinya bila pemanggil adalah pendonornya,
+// admin tenant pemilik lokasi, atau superadmin.
+func (uc *questionnaireUsecaseImpl) findDonation(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Donation, entity.Location, error) {
+	donation, err := uc.donationRepo.FindByID(ctx, donationID)
+	if err != nil {
+		return entity.Donation{}, entity.Location{}, err
+	}
+	location, err := uc.locationRepo.FindByID(ctx, donation.LocationID)
+	if err != nil {
+		return entity.Donation{}, entity.Location{}, err
+	}
+	if donation.UserID == nil || !canManage(actor, *donation.UserID, location.TenantID) {
+		return entity.Donation{}, entity.Location{}, entity.ErrForbidden
+	}
+	return donation, location, nil
+}
+
+// validateQuestion memastikan opsi dan jawaban penunda sesuai dengan tipe pertanyaan.
+func validateQuestion(question entity.QuestionnaireQuestion) error {
+	switch question.Type {
+	case entity.QuestionTypeSingleChoice:
+		if len(question.Options) < 2 {
+			return fmt.Errorf("%w: question %s needs at least two options", entity.ErrInvalidQuestionnaire, question.Code)
+		}
+		for i, option := range question.Options {
+			if option == "" || slices.Contains(question.Options[:i], option) {
+				return fmt.Errorf("%w: options of question %s must be unique and not empty", entity.ErrInvalidQuestionnaire, question.Code)
+			}
+		}
+	default:
+		if len(question.Options) > 0 {
+			return fmt.Errorf("%w: only single_choice questions have options", entity.ErrInvalidQuestionnaire)
+		}
+	}
+
+	if len(question.DeferAnswers) == 0 {
+		if question.DeferDays != nil {
+			return fmt.Errorf("%w: question %s has defer_days without defer_answers", entity.ErrInvalidQuestionnaire, question.Code)
+		}
+		return nil
+	}
+	if question.Type != entity.QuestionTypeYesNo && question.Type != entity.QuestionTypeSingleChoice {
+		return fmt.Errorf("%w: only yes_no and single_choice questions can defer donors", entity.ErrInvalidQuestionnaire)
+	}
+	for _, answer := range question.DeferAnswers {
+		if answer == "" || !validAnswer(question, answer) {
+			return fmt.Errorf("%w: defer answer %q is not a valid answer to question %s", entity.ErrInvalidQuestionnaire, answer, question.Code)
+		}
+	}
+	return nil
+}
+
+// validAnswer menandakan value adalah jawaban yang sah untuk pertanyaan; jawaban kosong hanya sah
+// untuk pertanyaan yang tidak wajib.
+func validAnswer(question entity.QuestionnaireQuestion, value string) bool {
+	if value == "" {
+		return !question.Required
+	}
+	switch question.Type {
+	case entity.QuestionTypeYesNo:
+		return value == "yes" || value == "no"
+	case entity.QuestionTypeSingleChoice:
+		return slices.Contains(question.Options, value)
+	case entity.QuestionTypeNumber:
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case entity.QuestionTypeDate:
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type ScreeningUsecase interface {
+	Record(ctx context.Context, donationID uuid.UUID, req dto.RecordScreeningRequest, actor dto.Actor) (entity.Donation, []entity.ScreeningResult, error)
+	FindByDonationID(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Donation, []entity.ScreeningResult, error)
+	FindDeferrals(ctx context.Context, filter repository.DonorDeferralFilter, actor dto.Actor, page, limit int) ([]entity.DonorDeferral, int64, error)
+	CompleteFollowUp(ctx context.Context, id uuid.UUID, req dto.CompleteFollowUpRequest, actor dto.Actor) (entity.DonorDeferral, error)
+	RecordVitalSigns(ctx context.Context, donationID uuid.UUID, req dto.RecordVitalSignsRequest, actor dto.Actor) (entity.Donation, entity.VitalSigns, error)
+	FindVitalSigns(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.VitalSigns, error)
+}
+
+// --- Implementation ---
+type screeningUsecaseImpl struct {
+	repo               repository.ScreeningRepository
+	donationRepo       repository.DonationRepository
+	locationRepo       repository.LocationRepository
+	userRepo           repository.UserRepository
+	eligibilityUsecase EligibilityUsecase
+}
+
+func NewScreeningUsecase(repo repository.ScreeningRepository, donationRepo repository.DonationRepository, locationRepo repository.LocationRepository, userRepo repository.UserRepository, eligibilityUsecase EligibilityUsecase) ScreeningUsecase {
+	return &screeningUsecaseImpl{
+		repo:               repo,
+		donationRepo:       donationRepo,
+		locationRepo:       locationRepo,
+		userRepo:           userRepo,
+		eligibilityUsecase: eligibilityUsecase,
+	}
+}
+
+// Record mencatat hasil uji saring. Status donasi dan unitnya diperbarui di repository
+// begitu hasil lengkap negatif atau ada hasil reaktif.
+func (uc *screeningUsecaseImpl) Record(ctx context.Context, donationID uuid.UUID, req dto.RecordScreeningRequest, actor dto.Actor) (entity.Donation, []entity.ScreeningResult, error) {
+	if _, _, err := uc.findManagedDonation(ctx, donationID, actor); err != nil {
+		return entity.Donation{}, nil, err
+	}
+
+	seen := map[string]bool{}
+	results := make([]entity.ScreeningResult, 0, len(req.Results))
+	for _, input := range req.Results {
+		if seen[input.Marker] {
+			return entity.Donation{}, nil, entity.ErrDuplicateScreeningMarker
+		}
+		seen[input.Marker] = true
+
+		results = append(results, entity.ScreeningResult{
+			Marker:   input.Marker,
+			Result:   input.Result,
+			Method:   input.Method,
+			TestedAt: input.TestedAt,
+			Note:     input.Note,
+		})
+	}
+
+	donation, err := uc.repo.Record(ctx, donationID, results, actor.UserID)
+	if err != nil {
+		return entity.Donation{}, nil, err
+	}
+
+	all, err := uc.repo.FindByDonationID(ctx, donationID)
+	return donation, all, err
+}
+
+func (uc *screeningUsecaseImpl) FindByDonationID(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Donation, []entity.ScreeningResult, error) {
+	donation, _, err := uc.findManagedDonation(ctx, donationID, actor)
+	if err != nil {
+		return entity.Donation{}, nil, err
+	}
+
+	results, err := uc.repo.FindByDonationID(ctx, donationID)
+	return donation, results, err
+}
+
+// FindDeferrals mengambil penundaan pendonor; selain superadmin, hanya penundaan atas donasi
+// di lokasi tenant pemanggil yang terlihat.
+func (uc *screeningUsecaseImpl) FindDeferrals(ctx context.Context, filter repository.DonorDeferralFilter, actor dto.Actor, page, limit int) ([]entity.DonorDeferral, int64, error) {
+	if actor.Role != "superadmin" {
+		if actor.TenantID == uuid.Nil {
+			return nil, 0, entity.ErrForbidden
+		}
+		filter.TenantID = actor.TenantID
+	}
+
+	offset := (page - 1) * limit
+	return uc.repo.FindDeferrals(ctx, filter, limit, offset)
+}
+
+// CompleteFollowUp mencatat hasil tindak lanjut (konseling/uji konfirmasi) atas penundaan pendonor.
+func (uc *screeningUsecaseImpl) CompleteFollowUp(ctx context.Context, id uuid.UUID, req dto.CompleteFollowUpRequest, actor dto.Actor) (entity.DonorDeferral, error) {
+	deferral, err := uc.repo.FindDeferralByID(ctx, id)
+	if err != nil {
+		return entity.DonorDeferral{}, err
+	}
+	// Penundaan tanpa donasi tidak terikat ke tenant mana pun, jadi hanya superadmin yang boleh menindaklanjutinya.
+	if deferral.DonationID == nil {
+		if !canManage(actor, uuid.Nil, uuid.Nil) {
+			return entity.DonorDeferral{}, entity.ErrForbidden
+		}
+	} else if _, _, err := uc.findManagedDonation(ctx, *deferral.DonationID, actor); err != nil {
+		return entity.DonorDeferral{}, err
+	}
+	if !deferral.FollowUpRequired || deferral.FollowUpCompletedAt != nil {
+		return entity.DonorDeferral{}, entity.ErrFollowUpNotRequired
+	}
+
+	now := time.Now()
+	deferral.FollowUpCompletedAt = &now
+	deferral.FollowUpNote = req.Note
+	if req.DeferredUntil != nil {
+		deferral.DeferredUntil = req.DeferredUntil
+	}
+	return uc.repo.UpdateDeferral(ctx, deferral)
+}
+
+// RecordVitalSigns mencatat pemeriksaan pra-donor untuk donasi yang masih pending dan memperbarui
+// berat badan pendonor. Rentang diambil dari kebijakan kelayakan tenant lokasi donasi, dengan batas
+// Hb sesuai jenis kelamin pendonor. Hasil di luar rentang membatalkan donasi dengan alasannya; Hb
+// atau suhu di luar rentang juga menunda pendonor sementara.
+func (uc *screeningUsecaseImpl) RecordVitalSigns(ctx context.Context, donationID uuid.UUID, req dto.RecordVitalSignsRequest, actor dto.Actor) (entity.Donation, entity.VitalSigns, error) {
+	if req.SystolicMmHg <= req.DiastolicMmHg {
+		return entity.Donation{}, entity.VitalSigns{}, entity.ErrInvalidVitalSigns
+	}
+
+	donation, location, err := uc.findManagedDonation(ctx, donationID, actor)
+	if err != nil {
+		return entity.Donation{}, entity.VitalSigns{}, err
+	}
+	if donation.Status != entity.DonationStatusPending {
+		return entity.Donation{}, entity.VitalSigns{}, entity.ErrDonationNotPending
+	}
+	policy, err := uc.eligibilityUsecase.PolicyFor(ctx, location.TenantID)
+	if err != nil {
+		return entity.Donation{}, entity.VitalSigns{}, err
+	}
+
+	// Pendonor tanpa akun tidak memiliki jenis kelamin tercatat; Findings memakai batas Hb yang lebih ketat.
+	var gender string
+	if donation.UserID != nil {
+		detail, err := uc.userRepo.FindDetailByUserID(ctx, *donation.UserID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Donation{}, entity.VitalSigns{}, entity.ErrDonorDetailMissing
+		}
+		if err != nil {
+			return entity.Donation{}, entity.VitalSigns{}, err
+		}
+		gender = detail.Gender
+	}
+
+	now := time.Now()
+	vitals := entity.VitalSigns{
+		DonationID:     donation.ID,
+		HaemoglobinGdl: req.HaemoglobinGdl,
+		SystolicMmHg:   req.SystolicMmHg,
+		DiastolicMmHg:  req.DiastolicMmHg,
+		PulseBpm:       req.PulseBpm,
+		TemperatureC:   req.TemperatureC,
+		WeightKg:       req.WeightKg,
+		Outcome:        entity.VitalSignsAccepted,
+		MeasuredBy:     actor.UserID,
+		MeasuredAt:     now,
+	}
+	if req.MeasuredAt != nil {
+		vitals.MeasuredAt = *req.MeasuredAt
+	}
+
+	var deferral *entity.DonorDeferral
+	findings, deferDays := vitals.Findings(policy, gender)
+	if len(findings) > 0 {
+		vitals.Outcome = entity.VitalSignsCancelled
+		vitals.Reason = "Pemeriksaan pra-donor: " + strings.Join(findings, "; ")
+	}
+	if deferDays > 0 && donation.UserID != nil {
+		vitals.Outcome = entity.VitalSignsDeferred
+		until := startOfDay(now).AddDate(0, 0, deferDays)
+		deferral = &entity.DonorDeferral{
+			UserID:        *donation.UserID,
+			DonationID:    &donation.ID,
+			Reason:        vitals.Reason,
+			DeferredUntil: &until,
+		}
+	}
+
+	donation, err = uc.repo.RecordVitalSigns(ctx, &vitals, deferral)
+	if err != nil {
+		return entity.Donation{}, entity.VitalSigns{}, err
+	}
+	return donation, vitals, nil
+}
+
+func (uc *screeningUsecaseImpl) FindVitalSigns(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.VitalSigns, error) {
+	if _, _, err := uc.findManagedDonation(ctx, donationID, actor); err != nil {
+		return entity.VitalSigns{}, err
+	}
+	return uc.repo.FindVitalSigns(ctx, donationID)
+}
+
+// findManagedDonation mengambil donasi beserta lokasinya dan memastikan pemanggil boleh mengelola
+// tenant lokasi tersebut. Hasil skrining dan pemeriksaan pra-donor hanya boleh diakses tenant itu.
+func (uc *screeningUsecaseImpl) findManagedDonation(ctx context.Context, donationID uuid.UUID, actor dto.Actor) (entity.Donation, entity.Location, error) {
+	donation, err := uc.donationRepo.FindByID(ctx, donationID)
+	if err != nil {
+		return entity.Donation{}, entity.Location{}, err
+	}
+	location, err := uc.locationRepo.FindByID(ctx, donation.LocationID)
+	if err != nil {
+		return entity.Donation{}, entity.Location{}, err
+	}
+	if !canManage(actor, uuid.Nil, location.TenantID) {
+		return entity.Donation{}, entity.Location{}, entity.ErrForbidden
+	}
+	return donation, location, nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newScreeningFixture() (*screeningUsecaseImpl, *fakeScreeningRepo, entity.Donation, entity.DonorDeferral) {
+	location := entity.Location{ID: uuid.New(), TenantID: tenantA}
+	donation := entity.Donation{ID: uuid.New(), LocationID: location.ID, Status: entity.DonationStatusPending}
+	deferral := entity.DonorDeferral{ID: uuid.New(), DonationID: &donation.ID, FollowUpRequired: true}
+
+	repo := &fakeScreeningRepo{
+		vitals:    map[uuid.UUID]entity.VitalSigns{donation.ID: {DonationID: donation.ID}},
+		deferrals: map[uuid.UUID]entity.DonorDeferral{deferral.ID: deferral},
+	}
+	uc := &screeningUsecaseImpl{
+		repo:         repo,
+		donationRepo: newFakeDonationRepo(donation),
+		locationRepo: newFakeLocationRepo(location),
+	}
+	return uc, repo, donation, deferral
+}
+
+func TestScreeningTenantScope(t *testing.T) {
+	ctx := context.Background()
+	calls := []struct {
+		name string
+		call func(uc *screeningUsecaseImpl, donation entity.Donation, deferral entity.DonorDeferral, actor dto.Actor) error
+	}{
+		{"Record", func(uc *screeningUsecaseImpl, donation entity.Donation, _ entity.DonorDeferral, actor dto.Actor) error {
+			req := dto.RecordScreeningRequest{Results: []dto.ScreeningResultInput{{Marker: entity.ScreeningMarkers[0], Result: "negative"}}}
+			_, _, err := uc.Record(ctx, donation.ID, req, actor)
+			return err
+		}},
+		{"FindByDonationID", func(uc *screeningUsecaseImpl, donation entity.Donation, _ entity.DonorDeferral, actor dto.Actor) error {
+			_, _, err := uc.FindByDonationID(ctx, donation.ID, actor)
+			return err
+		}},
+		{"FindVitalSigns", func(uc *screeningUsecaseImpl, donation entity.Donation, _ entity.DonorDeferral, actor dto.Actor) error {
+			_, err := uc.FindVitalSigns(ctx, donation.ID, actor)
+			return err
+		}},
+		{"CompleteFollowUp", func(uc *screeningUsecaseImpl, _ entity.Donation, deferral entity.DonorDeferral, actor dto.Actor) error {
+			_, err := uc.CompleteFollowUp(ctx, deferral.ID, dto.CompleteFollowUpRequest{Note: "konseling"}, actor)
+			return err
+		}},
+	}
+	actors := []struct {
+		name  string
+		actor dto.Actor
+		want  error
+	}{
+		{"own tenant admin", adminA, nil},
+		{"superadmin", superadmin, nil},
+		{"other tenant admin", adminB, entity.ErrForbidden},
+	}
+
+	for _, call := range calls {
+		for _, tt := range actors {
+			t.Run(call.name+"/"+tt.name, func(t *testing.T) {
+				uc, _, donation, deferral := newScreeningFixture()
+				err := call.call(uc, donation, deferral, tt.actor)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("err = %v, want %v", err, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestScreeningRecordForbiddenDoesNotWrite(t *testing.T) {
+	uc, repo, donation, _ := newScreeningFixture()
+	req := dto.RecordScreeningRequest{Results: []dto.ScreeningResultInput{{Marker: entity.ScreeningMarkers[0], Result: "reactive"}}}
+
+	if _, _, err := uc.Record(context.Background(), donation.ID, req, adminB); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("err = %v, want ErrForbidden", err)
+	}
+	if repo.recorded != 0 {
+		t.Fatalf("repository Record called %d times, want 0", repo.recorded)
+	}
+}
+
+func TestScreeningFindDeferralsScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      dto.Actor
+		wantTenant uuid.UUID
+		wantErr    error
+	}{
+		{"tenant admin sees own tenant", adminA, tenantA, nil},
+		{"superadmin keeps requested filter", superadmin, tenantB, nil},
+		{"admin without tenant", dto.Actor{UserID: uuid.New(), Role: "admin"}, uuid.Nil, entity.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, _, _ := newScreeningFixture()
+			filter := repository.DonorDeferralFilter{TenantID: tenantB}
+
+			_, _, err := uc.FindDeferrals(context.Background(), filter, tt.actor, 1, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && repo.filter.TenantID != tt.wantTenant {
+				t.Fatalf("filter.TenantID = %s, want %s", repo.filter.TenantID, tt.wantTenant)
+			}
+		})
+	}
+}
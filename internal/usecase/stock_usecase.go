@@ -5,61 +5,613 @@ import (
 	"donor-api/internal/delivery/http/dto"
 	"donor-api/internal/entity"
 	"donor-api/internal/repository"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
 )
 
 // --- Interface ---
 type StockUsecase interface {
-	Create(ctx context.Context, req dto.StockRequest) (entity.Stock, error)
-	FindAll(ctx context.Context, page, limit int) ([]entity.Stock, int64, error)
-	FindByID(ctx context.Context, id uuid.UUID) (entity.Stock, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.StockRequest) (entity.Stock, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Create(ctx context.Context, req dto.StockRequest, tenantID uuid.UUID) (entity.Stock, error)
+	FindAll(ctx context.Context, filter repository.StockFilter, page, limit int) ([]entity.Stock, int64, error)
+	Summary(ctx context.Context, filter repository.StockFilter) ([]dto.StockSummaryResponse, error)
+	Availability(ctx context.Context, filter repository.StockAvailabilityFilter, tenantID uuid.UUID) (dto.AvailabilityMatrixResponse, error)
+	Forecast(ctx context.Context, query dto.StockForecastQuery, tenantID uuid.UUID) (dto.StockForecastResponse, error)
+	FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Stock, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.StockRequest, tenantID uuid.UUID, version int) (entity.Stock, error)
+	Delete(ctx context.Context, id, tenantID uuid.UUID) error
+	Increment(ctx context.Context, id uuid.UUID, req dto.IncrementStockRequest, tenantID, actorID uuid.UUID) (entity.Stock, error)
+	Decrement(ctx context.Context, id uuid.UUID, req dto.UpdateQuantityRequest, tenantID, actorID uuid.UUID) (entity.Stock, error)
+	Allocate(ctx context.Context, req dto.AllocateStockRequest, tenantID, actorID uuid.UUID) ([]entity.BloodUnit, error)
+	ExpireUnits(ctx context.Context) (dto.ExpiryReportResponse, error)
+	FindMovements(ctx context.Context, stockID, tenantID uuid.UUID, page, limit int) ([]entity.StockMovement, int64, error)
+	Reconcile(ctx context.Context, stockID, tenantID uuid.UUID) (dto.StockReconciliationResponse, error)
+	SaveThreshold(ctx context.Context, req dto.StockThresholdRequest, tenantID uuid.UUID) (entity.StockThreshold, error)
+	FindThresholds(ctx context.Context, filter repository.StockThresholdFilter) ([]entity.StockThreshold, error)
+	DeleteThreshold(ctx context.Context, id, tenantID uuid.UUID) error
+	FindAlerts(ctx context.Context, filter repository.StockAlertFilter, page, limit int) ([]entity.StockAlert, int64, error)
+	AcknowledgeAlert(ctx context.Context, id, tenantID, actorID uuid.UUID) (entity.StockAlert, error)
 }
 
 // --- Implementation ---
 type stockUsecaseImpl struct {
-	repo repository.StockRepository
+	repo         repository.StockRepository
+	locationRepo repository.LocationRepository
+	donationRepo repository.DonationRepository
+	userRepo     repository.UserRepository
 }
 
-func NewStockUsecase(repo repository.StockRepository) StockUsecase {
-	return &stockUsecaseImpl{repo: repo}
+func NewStockUsecase(repo repository.StockRepository, locationRepo repository.LocationRepository, donationRepo repository.DonationRepository, userRepo repository.UserRepository) StockUsecase {
+	return &stockUsecaseImpl{
+		repo:         repo,
+		locationRepo: locationRepo,
+		donationRepo: donationRepo,
+		userRepo:     userRepo,
+	}
 }
 
-func (uc *stockUsecaseImpl) Create(ctx context.Context, req dto.StockRequest) (entity.Stock, error) {
+// Create menyiapkan baris stok kosong; kantong ditambahkan lewat unit, bukan lewat baris stok.
+func (uc *stockUsecaseImpl) Create(ctx context.Context, req dto.StockRequest, tenantID uuid.UUID) (entity.Stock, error) {
+	if err := checkLocationTenant(ctx, uc.locationRepo, req.LocationID, tenantID); err != nil {
+		return entity.Stock{}, err
+	}
+
 	var stock entity.Stock
 	copier.Copy(&stock, &req)
+	if stock.Component == "" {
+		stock.Component = entity.ComponentWholeBlood
+	}
 
 	err := uc.repo.Save(ctx, &stock)
 	return stock, err
 }
 
-func (uc *stockUsecaseImpl) FindAll(ctx context.Context, page, limit int) ([]entity.Stock, int64, error) {
+func (uc *stockUsecaseImpl) FindAll(ctx context.Context, filter repository.StockFilter, page, limit int) ([]entity.Stock, int64, error) {
 	offset := (page - 1) * limit
-	return uc.repo.FindAll(ctx, limit, offset)
+	return uc.repo.FindAll(ctx, filter, limit, offset)
 }
 
-func (uc *stockUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (entity.Stock, error) {
-	return uc.repo.FindByID(ctx, id)
+// Summary menjumlahkan kantong per golongan, rhesus, dan komponen.
+func (uc *stockUsecaseImpl) Summary(ctx context.Context, filter repository.StockFilter) ([]dto.StockSummaryResponse, error) {
+	aggregates, err := uc.repo.Summarize(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.StockSummaryResponse, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		res = append(res, dto.StockSummaryResponse{
+			BloodType:   aggregate.BloodType,
+			Rhesus:      aggregate.Rhesus,
+			Component:   aggregate.Component,
+			BagQuantity: aggregate.BagQuantity,
+		})
+	}
+	return res, nil
+}
+
+// Availability menyusun matriks golongan × rhesus × lokasi beserta total per tenant dan nasional.
+// Rincian lokasi dan tenant dibatasi pada tenant pemanggil, sedangkan total nasional selalu dihitung dari semua lokasi.
+func (uc *stockUsecaseImpl) Availability(ctx context.Context, filter repository.StockAvailabilityFilter, tenantID uuid.UUID) (dto.AvailabilityMatrixResponse, error) {
+	res := dto.AvailabilityMatrixResponse{
+		City:        filter.City,
+		Component:   filter.Component,
+		GeneratedAt: time.Now(),
+		Locations:   []dto.LocationAvailability{},
+		Tenants:     []dto.TenantAvailability{},
+		Platform:    dto.PlatformAvailability{Items: newAvailabilityCells()},
+	}
+
+	rows, err := uc.repo.Availability(ctx, filter)
+	if err != nil {
+		return res, err
+	}
+
+	locationIndex := map[uuid.UUID]int{}
+	tenantIndex := map[uuid.UUID]int{}
+	for _, row := range rows {
+		addAvailability(res.Platform.Items, &res.Platform.Total, row)
+		if tenantID != uuid.Nil && row.TenantID != tenantID {
+			continue
+		}
+
+		i, ok := locationIndex[row.LocationID]
+		if !ok {
+			i = len(res.Locations)
+			locationIndex[row.LocationID] = i
+			res.Locations = append(res.Locations, dto.LocationAvailability{
+				LocationID:   row.LocationID.String(),
+				LocationName: row.LocationName,
+				City:         row.City,
+				TenantID:     row.TenantID.String(),
+				Items:        newAvailabilityCells(),
+			})
+		}
+		addAvailability(res.Locations[i].Items, &res.Locations[i].Total, row)
+
+		j, ok := tenantIndex[row.TenantID]
+		if !ok {
+			j = len(res.Tenants)
+			tenantIndex[row.TenantID] = j
+			res.Tenants = append(res.Tenants, dto.TenantAvailability{
+				TenantID: row.TenantID.String(),
+				Items:    newAvailabilityCells(),
+			})
+		}
+		addAvailability(res.Tenants[j].Items, &res.Tenants[j].Total, row)
+	}
+
+	return res, nil
+}
+
+// newAvailabilityCells menyiapkan satu sel untuk setiap kombinasi golongan dan rhesus agar matriks selalu lengkap.
+func newAvailabilityCells() []dto.AvailabilityCell {
+	cells := make([]dto.AvailabilityCell, 0, len(entity.BloodTypes)*len(entity.RhesusTypes))
+	for _, bloodType := range entity.BloodTypes {
+		for _, rhesus := range entity.RhesusTypes {
+			cells = append(cells, dto.AvailabilityCell{BloodType: bloodType, Rhesus: rhesus})
+		}
+	}
+	return cells
+}
+
+func addAvailability(cells []dto.AvailabilityCell, total *int64, row repository.StockAvailabilityRow) {
+	for i := range cells {
+		if cells[i].BloodType == row.BloodType && cells[i].Rhesus == row.Rhesus {
+			cells[i].BagQuantity += row.BagQuantity
+			*total += row.BagQuantity
+			return
+		}
+	}
+}
+
+const (
+	defaultForecastWindowDays = 28
+	defaultForecastTargetDays = 14
+	forecastHorizonDays       = 365
+	// donorYieldRate adalah perkiraan proporsi calon pendonor yang lolos skrining dan menghasilkan satu kantong.
+	donorYieldRate = 0.8
+)
+
+// Forecast memperkirakan berapa hari stok tiap golongan darah dan rhesus akan bertahan.
+// Pemakaian harian adalah movement issue di ledger ditambah kantong yang diserahkan dari tahanan permintaan darah,
+// karena kantong yang ditahan sudah keluar dari stok sebelum diserahkan dan tidak tercatat sebagai issue.
+// Dengan jendela minimal 14 hari, rata-rata bergerak dikoreksi faktor musiman per hari dalam seminggu.
+func (uc *stockUsecaseImpl) Forecast(ctx context.Context, query dto.StockForecastQuery, tenantID uuid.UUID) (dto.StockForecastResponse, error) {
+	windowDays := query.WindowDays
+	if windowDays == 0 {
+		windowDays = defaultForecastWindowDays
+	}
+	targetDays := query.TargetDays
+	if targetDays == 0 {
+		targetDays = defaultForecastTargetDays
+	}
+
+	res := dto.StockForecastResponse{
+		LocationID:  query.LocationID,
+		Component:   query.Component,
+		Rhesus:      query.Rhesus,
+		Method:      "moving_average",
+		WindowDays:  windowDays,
+		TargetDays:  targetDays,
+		GeneratedAt: time.Now(),
+		Items:       []dto.BloodTypeForecast{},
+	}
+
+	filter := repository.ConsumptionFilter{TenantID: tenantID, Component: query.Component, Rhesus: query.Rhesus}
+	if query.LocationID != "" {
+		locationID, err := uuid.Parse(query.LocationID)
+		if err != nil {
+			return res, err
+		}
+		if err := checkLocationTenant(ctx, uc.locationRepo, locationID, tenantID); err != nil {
+			return res, err
+		}
+		filter.LocationID = locationID
+	}
+
+	today := startOfDay(res.GeneratedAt)
+	from := today.AddDate(0, 0, -windowDays)
+	issued, err := uc.repo.DailyIssued(ctx, filter, from, today)
+	if err != nil {
+		return res, err
+	}
+	reservedIssued, err := uc.repo.DailyReservedIssued(ctx, filter, from, today)
+	if err != nil {
+		return res, err
+	}
+	issued = append(issued, reservedIssued...)
+	aggregates, err := uc.repo.Summarize(ctx, repository.StockFilter{
+		TenantID:   filter.TenantID,
+		LocationID: filter.LocationID,
+		Component:  filter.Component,
+		Rhesus:     filter.Rhesus,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	rhesusTypes := entity.RhesusTypes
+	if query.Rhesus != "" {
+		rhesusTypes = []string{query.Rhesus}
+	}
+
+	series := map[string][]float64{}
+	for _, bloodType := range entity.BloodTypes {
+		for _, rhesus := range rhesusTypes {
+			series[bloodType+rhesus] = make([]float64, windowDays)
+		}
+	}
+	for _, row := range issued {
+		usage, ok := series[row.BloodType+row.Rhesus]
+		day := daysBetween(from, row.Day)
+		if !ok || day < 0 || day >= windowDays {
+			continue
+		}
+		usage[day] += float64(row.Quantity)
+	}
+
+	stockByGroup := map[string]int64{}
+	for _, aggregate := range aggregates {
+		stockByGroup[aggregate.BloodType+aggregate.Rhesus] += aggregate.BagQuantity
+	}
+
+	seasonal := windowDays >= 14
+	if seasonal {
+		res.Method = "seasonal"
+	}
+
+	for _, bloodType := range entity.BloodTypes {
+		for _, rhesus := range rhesusTypes {
+			usage := series[bloodType+rhesus]
+			average, factors := usageProfile(usage, from, seasonal)
+			item := dto.BloodTypeForecast{
+				BloodType:         bloodType,
+				Rhesus:            rhesus,
+				CurrentStock:      stockByGroup[bloodType+rhesus],
+				AverageDailyUsage: math.Round(average*100) / 100,
+			}
+
+			if average > 0 {
+				daysOfSupply := math.Round(float64(item.CurrentStock)/average*10) / 10
+				item.DaysOfSupply = &daysOfSupply
+
+				remaining := float64(item.CurrentStock)
+				targetDemand := 0.0
+				for t := 0; t < forecastHorizonDays; t++ {
+					day := today.AddDate(0, 0, t)
+					demand := average * factors[day.Weekday()]
+					if t < targetDays {
+						targetDemand += demand
+					}
+					remaining -= demand
+					if remaining <= 0 && item.ProjectedStockOutDate == nil {
+						item.ProjectedStockOutDate = &day
+					}
+					if item.ProjectedStockOutDate != nil && t >= targetDays {
+						break
+					}
+				}
+
+				if deficit := targetDemand - float64(item.CurrentStock); deficit > 0 {
+					item.RecommendedDonors = int(math.Ceil(deficit / donorYieldRate))
+				}
+			}
+
+			res.Items = append(res.Items, item)
+		}
+	}
+
+	return res, nil
 }
 
-func (uc *stockUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.StockRequest) (entity.Stock, error) {
+// usageProfile menghitung rata-rata pemakaian harian dan faktor pengali per hari dalam seminggu.
+// Tanpa pola musiman semua faktor bernilai 1.
+func usageProfile(usage []float64, from time.Time, seasonal bool) (float64, [7]float64) {
+	factors := [7]float64{1, 1, 1, 1, 1, 1, 1}
+	if len(usage) == 0 {
+		return 0, factors
+	}
+
+	var total float64
+	var weekdayTotal [7]float64
+	var weekdayCount [7]int
+	for i, quantity := range usage {
+		total += quantity
+		weekday := from.AddDate(0, 0, i).Weekday()
+		weekdayTotal[weekday] += quantity
+		weekdayCount[weekday]++
+	}
+
+	average := total / float64(len(usage))
+	if !seasonal || average == 0 {
+		return average, factors
+	}
+	for weekday := range factors {
+		if weekdayCount[weekday] > 0 {
+			factors[weekday] = weekdayTotal[weekday] / float64(weekdayCount[weekday]) / average
+		}
+	}
+	return average, factors
+}
+
+// daysBetween menghitung selisih hari kalender antara from dan day tanpa terpengaruh zona waktu.
+func daysBetween(from, day time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := day.Date()
+	start := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+func (uc *stockUsecaseImpl) FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Stock, error) {
 	stock, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return entity.Stock{}, err
 	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, stock.LocationID, tenantID); err != nil {
+		return entity.Stock{}, err
+	}
+	return stock, nil
+}
+
+// Update mengoreksi golongan, komponen, atau lokasi baris stok yang belum memiliki unit maupun ledger.
+// Jumlah kantong tidak pernah diubah di sini; jumlah selalu diturunkan dari unit.
+func (uc *stockUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.StockRequest, tenantID uuid.UUID, version int) (entity.Stock, error) {
+	stock, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.Stock{}, err
+	}
+	if err := checkVersion(stock.Version, version); err != nil {
+		return entity.Stock{}, err
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, req.LocationID, tenantID); err != nil {
+		return entity.Stock{}, err
+	}
 
 	copier.Copy(&stock, &req)
+	if stock.Component == "" {
+		stock.Component = entity.ComponentWholeBlood
+	}
 
 	return uc.repo.Update(ctx, stock)
 }
 
-func (uc *stockUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := uc.repo.FindByID(ctx, id)
+// Delete menghapus baris stok yang belum memiliki unit maupun ledger.
+func (uc *stockUsecaseImpl) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
+	_, err := uc.FindByID(ctx, id, tenantID)
 	if err != nil {
 		return err
 	}
 	return uc.repo.Delete(ctx, id)
 }
+
+// Increment menambah stok dengan mendaftarkan kantong baru sesuai golongan, komponen, dan lokasi
+// baris stok. Setiap kantong harus berasal dari donasi tenant yang sama, dengan golongan pendonor yang
+// cocok, yang sudah lolos skrining dan belum pernah menjadi unit; BagQuantity dan ledger bertambah per
+// kantong di transaksi yang sama.
+func (uc *stockUsecaseImpl) Increment(ctx context.Context, id uuid.UUID, req dto.IncrementStockRequest, tenantID, actorID uuid.UUID) (entity.Stock, error) {
+	stock, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.Stock{}, err
+	}
+
+	units := make([]entity.BloodUnit, 0, len(req.Bags))
+	for _, bag := range req.Bags {
+		donationID := bag.DonationID
+		unit := entity.BloodUnit{
+			BagNumber:      bag.BagNumber,
+			BloodType:      stock.BloodType,
+			Rhesus:         stock.Rhesus,
+			Component:      stock.Component,
+			CollectionDate: bag.CollectionDate,
+			LocationID:     stock.LocationID,
+			DonationID:     &donationID,
+		}
+		unit.ExpiryDate, _ = entity.ComponentExpiryDate(unit.Component, unit.CollectionDate)
+		if _, err := checkUnitDonation(ctx, uc.donationRepo, uc.userRepo, uc.locationRepo, donationID, unit); err != nil {
+			return entity.Stock{}, err
+		}
+		units = append(units, unit)
+	}
+
+	movement := entity.NewStockMovement(entity.StockMovementInbound, req.Reason, actorID, nil)
+	if err := uc.repo.ReceiveUnits(ctx, units, movement); err != nil {
+		return entity.Stock{}, err
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// Decrement mengurangi stok dengan memusnahkan kantong available berkedaluwarsa terdekat (FEFO);
+// ditolak bila kantong yang tersedia kurang dari jumlah yang diminta.
+func (uc *stockUsecaseImpl) Decrement(ctx context.Context, id uuid.UUID, req dto.UpdateQuantityRequest, tenantID, actorID uuid.UUID) (entity.Stock, error) {
+	stock, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.Stock{}, err
+	}
+
+	movement := entity.NewStockMovement(entity.StockMovementDiscard, req.Reason, actorID, nil)
+	if _, err := uc.repo.WriteOffUnits(ctx, stock, req.BagQuantity, startOfDay(time.Now()), movement); err != nil {
+		return entity.Stock{}, err
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// Allocate mengeluarkan kantong darah dengan prinsip FEFO (first expired, first out).
+func (uc *stockUsecaseImpl) Allocate(ctx context.Context, req dto.AllocateStockRequest, tenantID, actorID uuid.UUID) ([]entity.BloodUnit, error) {
+	if err := checkLocationTenant(ctx, uc.locationRepo, req.LocationID, tenantID); err != nil {
+		return nil, err
+	}
+
+	key := entity.Stock{
+		BloodType:  req.BloodType,
+		Rhesus:     req.Rhesus,
+		Component:  req.Component,
+		LocationID: req.LocationID,
+	}
+	if key.Component == "" {
+		key.Component = entity.ComponentWholeBlood
+	}
+	movement := entity.NewStockMovement(entity.StockMovementIssue, req.Reason, actorID, nil)
+	return uc.repo.AllocateUnits(ctx, key, req.Quantity, startOfDay(time.Now()), movement)
+}
+
+// FindMovements mengambil riwayat ledger sebuah baris stok, terbaru lebih dulu.
+func (uc *stockUsecaseImpl) FindMovements(ctx context.Context, stockID, tenantID uuid.UUID, page, limit int) ([]entity.StockMovement, int64, error) {
+	if _, err := uc.FindByID(ctx, stockID, tenantID); err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * limit
+	return uc.repo.FindMovements(ctx, stockID, limit, offset)
+}
+
+// Reconcile membandingkan BagQuantity dengan saldo ledger dan jumlah unit available. Baris stok lama
+// tanpa unit sudah disamakan oleh migrasi 000005 lewat pergerakan adjustment saldo awal.
+func (uc *stockUsecaseImpl) Reconcile(ctx context.Context, stockID, tenantID uuid.UUID) (dto.StockReconciliationResponse, error) {
+	var res dto.StockReconciliationResponse
+	stock, err := uc.FindByID(ctx, stockID, tenantID)
+	if err != nil {
+		return res, err
+	}
+
+	ledgerBalance, err := uc.repo.SumMovements(ctx, stock.ID)
+	if err != nil {
+		return res, err
+	}
+	availableUnits, err := uc.repo.CountAvailableUnits(ctx, stock)
+	if err != nil {
+		return res, err
+	}
+
+	res = dto.StockReconciliationResponse{
+		StockID:        stock.ID.String(),
+		BagQuantity:    stock.BagQuantity,
+		LedgerBalance:  ledgerBalance,
+		AvailableUnits: availableUnits,
+		Consistent:     int64(stock.BagQuantity) == ledgerBalance && ledgerBalance == availableUnits,
+	}
+	return res, nil
+}
+
+// SaveThreshold membuat atau memperbarui batas minimum dan kritis untuk lokasi milik tenant.
+func (uc *stockUsecaseImpl) SaveThreshold(ctx context.Context, req dto.StockThresholdRequest, tenantID uuid.UUID) (entity.StockThreshold, error) {
+	if req.CriticalLevel > req.MinimumLevel {
+		return entity.StockThreshold{}, entity.ErrInvalidStockThreshold
+	}
+
+	location, err := findTenantLocation(ctx, uc.locationRepo, req.LocationID, tenantID)
+	if err != nil {
+		return entity.StockThreshold{}, err
+	}
+
+	threshold := entity.StockThreshold{
+		TenantID:      location.TenantID,
+		LocationID:    location.ID,
+		BloodType:     req.BloodType,
+		Rhesus:        req.Rhesus,
+		MinimumLevel:  req.MinimumLevel,
+		CriticalLevel: req.CriticalLevel,
+	}
+	err = uc.repo.SaveThreshold(ctx, &threshold)
+	return threshold, err
+}
+
+func (uc *stockUsecaseImpl) FindThresholds(ctx context.Context, filter repository.StockThresholdFilter) ([]entity.StockThreshold, error) {
+	return uc.repo.FindThresholds(ctx, filter)
+}
+
+func (uc *stockUsecaseImpl) DeleteThreshold(ctx context.Context, id, tenantID uuid.UUID) error {
+	threshold, err := uc.repo.FindThresholdByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tenantID != uuid.Nil && threshold.TenantID != tenantID {
+		return gorm.ErrRecordNotFound
+	}
+	return uc.repo.DeleteThreshold(ctx, id)
+}
+
+// FindAlerts mengambil alert kekurangan stok, terbaru lebih dulu.
+func (uc *stockUsecaseImpl) FindAlerts(ctx context.Context, filter repository.StockAlertFilter, page, limit int) ([]entity.StockAlert, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAlerts(ctx, filter, limit, offset)
+}
+
+// AcknowledgeAlert menandai alert sudah ditindaklanjuti; alert yang sudah diakui tidak diubah lagi.
+func (uc *stockUsecaseImpl) AcknowledgeAlert(ctx context.Context, id, tenantID, actorID uuid.UUID) (entity.StockAlert, error) {
+	alert, err := uc.repo.FindAlertByID(ctx, id)
+	if err != nil {
+		return entity.StockAlert{}, err
+	}
+	if tenantID != uuid.Nil && alert.TenantID != tenantID {
+		return entity.StockAlert{}, gorm.ErrRecordNotFound
+	}
+	if alert.AcknowledgedAt != nil {
+		return alert, nil
+	}
+
+	now := time.Now()
+	alert.AcknowledgedAt = &now
+	if actorID != uuid.Nil {
+		alert.AcknowledgedBy = &actorID
+	}
+	return uc.repo.AcknowledgeAlert(ctx, alert)
+}
+
+// ExpireUnits menandai unit yang kedaluwarsa dan membuat laporan per lokasi.
+func (uc *stockUsecaseImpl) ExpireUnits(ctx context.Context) (dto.ExpiryReportResponse, error) {
+	now := time.Now()
+	report := dto.ExpiryReportResponse{RunAt: now, Locations: []dto.LocationExpiryReport{}}
+
+	units, err := uc.repo.ExpireUnits(ctx, startOfDay(now))
+	if err != nil {
+		return report, err
+	}
+
+	byLocation := map[uuid.UUID]*dto.LocationExpiryReport{}
+	for _, unit := range units {
+		location, ok := byLocation[unit.LocationID]
+		if !ok {
+			location = &dto.LocationExpiryReport{LocationID: unit.LocationID.String()}
+			byLocation[unit.LocationID] = location
+		}
+		location.Total++
+		report.TotalUnits++
+
+		found := false
+		for i := range location.Items {
+			item := &location.Items[i]
+			if item.BloodType == unit.BloodType && item.Rhesus == unit.Rhesus && item.Component == unit.Component {
+				item.Quantity++
+				found = true
+				break
+			}
+		}
+		if !found {
+			location.Items = append(location.Items, dto.ExpiredStockItem{
+				BloodType: unit.BloodType,
+				Rhesus:    unit.Rhesus,
+				Component: unit.Component,
+				Quantity:  1,
+			})
+		}
+	}
+
+	for _, location := range byLocation {
+		report.Locations = append(report.Locations, *location)
+	}
+	sort.Slice(report.Locations, func(i, j int) bool {
+		return report.Locations[i].LocationID < report.Locations[j].LocationID
+	})
+
+	return report, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// stockDonors adalah pendonor A+ dan O- yang donasinya tercatat di lokasi tenant A dan tenant B.
+type stockDonors struct {
+	aPositive, oNegative, otherTenant uuid.UUID
+}
+
+func newStockFixture() (*stockUsecaseImpl, *fakeStockRepo, entity.Stock, stockDonors) {
+	location := entity.Location{ID: uuid.New(), TenantID: tenantA}
+	otherLocation := entity.Location{ID: uuid.New(), TenantID: tenantB}
+	stock := entity.Stock{
+		ID:          uuid.New(),
+		BloodType:   "A",
+		Rhesus:      "+",
+		Component:   entity.ComponentPRC,
+		LocationID:  location.ID,
+		BagQuantity: 3,
+	}
+
+	aDonor, oDonor := uuid.New(), uuid.New()
+	a, positive, o, negative := "A", "positif", "O", "-"
+	users := &fakeUserRepo{details: map[uuid.UUID]entity.UserDetail{
+		aDonor: {BloodType: &a, Rhesus: &positive},
+		oDonor: {BloodType: &o, Rhesus: &negative},
+	}}
+	donors := stockDonors{aPositive: uuid.New(), oNegative: uuid.New(), otherTenant: uuid.New()}
+	donations := newFakeDonationRepo(
+		entity.Donation{ID: donors.aPositive, UserID: &aDonor, LocationID: location.ID},
+		entity.Donation{ID: donors.oNegative, UserID: &oDonor, LocationID: location.ID},
+		entity.Donation{ID: donors.otherTenant, UserID: &aDonor, LocationID: otherLocation.ID},
+	)
+
+	repo := newFakeStockRepo(stock)
+	uc := &stockUsecaseImpl{
+		repo:         repo,
+		locationRepo: newFakeLocationRepo(location, otherLocation),
+		donationRepo: donations,
+		userRepo:     users,
+	}
+	return uc, repo, stock, donors
+}
+
+func TestStockIncrementRegistersBagsInStockGroup(t *testing.T) {
+	uc, repo, stock, donors := newStockFixture()
+	collected := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	req := dto.IncrementStockRequest{
+		Bags:   []dto.StockBagInput{{BagNumber: "BAG-1", DonationID: donors.aPositive, CollectionDate: collected}},
+		Reason: "Penerimaan kantong",
+	}
+
+	if _, err := uc.Increment(context.Background(), stock.ID, req, tenantA, adminA.UserID); err != nil {
+		t.Fatalf("Increment() err = %v", err)
+	}
+	if len(repo.received) != 1 {
+		t.Fatalf("received %d units, want 1", len(repo.received))
+	}
+	unit := repo.received[0]
+	if unit.BloodType != stock.BloodType || unit.Rhesus != stock.Rhesus ||
+		unit.Component != stock.Component || unit.LocationID != stock.LocationID {
+		t.Errorf("unit %s = %s%s %s at %s, want stock group", unit.BagNumber, unit.BloodType, unit.Rhesus, unit.Component, unit.LocationID)
+	}
+	if unit.DonationID == nil || *unit.DonationID != donors.aPositive {
+		t.Errorf("unit donation = %v, want %s", unit.DonationID, donors.aPositive)
+	}
+	wantExpiry, _ := entity.ComponentExpiryDate(entity.ComponentPRC, collected)
+	if !unit.ExpiryDate.Equal(wantExpiry) {
+		t.Errorf("expiry = %s, want %s", unit.ExpiryDate, wantExpiry)
+	}
+	if repo.movement.Type != entity.StockMovementInbound {
+		t.Errorf("movement type = %s, want %s", repo.movement.Type, entity.StockMovementInbound)
+	}
+}
+
+func TestStockIncrementChecksDonation(t *testing.T) {
+	uc, repo, stock, donors := newStockFixture()
+	tests := []struct {
+		name       string
+		donationID uuid.UUID
+		want       error
+	}{
+		{"donation of another tenant", donors.otherTenant, gorm.ErrRecordNotFound},
+		{"donor blood group differs from stock", donors.oNegative, entity.ErrDonorBloodGroupMismatch},
+		{"unknown donation", uuid.New(), gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.IncrementStockRequest{Bags: []dto.StockBagInput{
+				{BagNumber: "BAG-1", DonationID: tt.donationID, CollectionDate: time.Now()},
+			}}
+			_, err := uc.Increment(context.Background(), stock.ID, req, tenantA, adminA.UserID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Increment() err = %v, want %v", err, tt.want)
+			}
+			if len(repo.received) != 0 {
+				t.Fatalf("received %d units, want none", len(repo.received))
+			}
+		})
+	}
+}
+
+func TestStockDecrement(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID uuid.UUID
+		quantity int
+		want     error
+	}{
+		{"writes off available bags", tenantA, 2, nil},
+		{"refuses to go negative", tenantA, 4, entity.ErrInsufficientStock},
+		{"other tenant", tenantB, 1, gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, stock, _ := newStockFixture()
+
+			_, err := uc.Decrement(context.Background(), stock.ID, dto.UpdateQuantityRequest{BagQuantity: tt.quantity}, tt.tenantID, uuid.New())
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Decrement() err = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (repo.writtenOff != tt.quantity || repo.movement.Type != entity.StockMovementDiscard) {
+				t.Fatalf("written off %d as %q, want %d as discard", repo.writtenOff, repo.movement.Type, tt.quantity)
+			}
+		})
+	}
+}
+
+func TestStockForecastCountsReservedIssues(t *testing.T) {
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+	repo := &fakeConsumptionRepo{
+		issued:         []repository.DailyConsumption{{Day: yesterday, BloodType: "A", Rhesus: "+", Quantity: 7}},
+		reservedIssued: []repository.DailyConsumption{{Day: yesterday, BloodType: "A", Rhesus: "+", Quantity: 7}},
+		aggregates:     []repository.StockAggregate{{BloodType: "A", Rhesus: "+", Component: entity.ComponentPRC, BagQuantity: 10}},
+	}
+	uc := &stockUsecaseImpl{repo: repo, locationRepo: newFakeLocationRepo()}
+
+	res, err := uc.Forecast(context.Background(), dto.StockForecastQuery{WindowDays: 7, Rhesus: "+"}, tenantA)
+	if err != nil {
+		t.Fatalf("Forecast() err = %v", err)
+	}
+	for _, item := range res.Items {
+		if item.BloodType != "A" {
+			continue
+		}
+		if item.AverageDailyUsage != 2 {
+			t.Errorf("average daily usage = %v, want 2 including bags issued from reservations", item.AverageDailyUsage)
+		}
+		if item.DaysOfSupply == nil || *item.DaysOfSupply != 5 {
+			t.Errorf("days of supply = %v, want 5", item.DaysOfSupply)
+		}
+		return
+	}
+	t.Fatal("forecast has no A+ item")
+}
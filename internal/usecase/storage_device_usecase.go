@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// --- Interface ---
+type StorageDeviceUsecase interface {
+	Create(ctx context.Context, req dto.StorageDeviceRequest, tenantID uuid.UUID) (entity.StorageDevice, error)
+	FindAll(ctx context.Context, filter repository.StorageDeviceFilter, page, limit int) ([]entity.StorageDevice, int64, error)
+	FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.StorageDevice, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.StorageDeviceRequest, tenantID uuid.UUID) (entity.StorageDevice, error)
+	Delete(ctx context.Context, id, tenantID uuid.UUID) error
+	RecordReadings(ctx context.Context, id uuid.UUID, req dto.RecordTemperatureRequest, tenantID uuid.UUID) ([]entity.TemperatureReading, []entity.TemperatureExcursion, error)
+	FindReadings(ctx context.Context, id uuid.UUID, filter repository.TemperatureReadingFilter, tenantID uuid.UUID, page, limit int) ([]entity.TemperatureReading, int64, error)
+	FindExcursions(ctx context.Context, filter repository.TemperatureExcursionFilter, page, limit int) ([]entity.TemperatureExcursion, int64, error)
+}
+
+// --- Implementation ---
+type storageDeviceUsecaseImpl struct {
+	repo         repository.StorageDeviceRepository
+	locationRepo repository.LocationRepository
+}
+
+func NewStorageDeviceUsecase(repo repository.StorageDeviceRepository, locationRepo repository.LocationRepository) StorageDeviceUsecase {
+	return &storageDeviceUsecaseImpl{
+		repo:         repo,
+		locationRepo: locationRepo,
+	}
+}
+
+func (uc *storageDeviceUsecaseImpl) Create(ctx context.Context, req dto.StorageDeviceRequest, tenantID uuid.UUID) (entity.StorageDevice, error) {
+	location, err := findTenantLocation(ctx, uc.locationRepo, req.LocationID, tenantID)
+	if err != nil {
+		return entity.StorageDevice{}, err
+	}
+
+	device := entity.StorageDevice{
+		TenantID:     location.TenantID,
+		LocationID:   location.ID,
+		Name:         req.Name,
+		DeviceClass:  req.DeviceClass,
+		SerialNumber: req.SerialNumber,
+		Active:       req.Active == nil || *req.Active,
+	}
+	err = uc.repo.Save(ctx, &device)
+	return device, err
+}
+
+func (uc *storageDeviceUsecaseImpl) FindAll(ctx context.Context, filter repository.StorageDeviceFilter, page, limit int) ([]entity.StorageDevice, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, filter, limit, offset)
+}
+
+func (uc *storageDeviceUsecaseImpl) FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.StorageDevice, error) {
+	device, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.StorageDevice{}, err
+	}
+	if tenantID != uuid.Nil && device.TenantID != tenantID {
+		return entity.StorageDevice{}, gorm.ErrRecordNotFound
+	}
+	return device, nil
+}
+
+func (uc *storageDeviceUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.StorageDeviceRequest, tenantID uuid.UUID) (entity.StorageDevice, error) {
+	device, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.StorageDevice{}, err
+	}
+	location, err := findTenantLocation(ctx, uc.locationRepo, req.LocationID, tenantID)
+	if err != nil {
+		return entity.StorageDevice{}, err
+	}
+
+	// Unit di dalam alat harus berada di lokasi alat dan disimpan pada suhu kelasnya,
+	// jadi lokasi dan kelas alat tidak boleh berubah selama masih ada unit yang tersimpan.
+	if device.LocationID != location.ID || device.DeviceClass != req.DeviceClass {
+		assigned, err := uc.repo.CountAssignedUnits(ctx, device.ID)
+		if err != nil {
+			return entity.StorageDevice{}, err
+		}
+		if assigned > 0 {
+			return entity.StorageDevice{}, entity.ErrStorageDeviceInUse
+		}
+	}
+
+	device.TenantID = location.TenantID
+	device.LocationID = location.ID
+	device.Name = req.Name
+	device.DeviceClass = req.DeviceClass
+	device.SerialNumber = req.SerialNumber
+	if req.Active != nil {
+		device.Active = *req.Active
+	}
+	return uc.repo.Update(ctx, device)
+}
+
+// Delete menghapus alat yang sudah kosong. Unit yang masih tersimpan akan kehilangan alatnya dan
+// tidak lagi ditandai saat terjadi excursion, jadi alat yang masih berisi unit ditolak.
+func (uc *storageDeviceUsecaseImpl) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
+	if _, err := uc.FindByID(ctx, id, tenantID); err != nil {
+		return err
+	}
+	assigned, err := uc.repo.CountAssignedUnits(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assigned > 0 {
+		return entity.ErrStorageDeviceInUse
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+// RecordReadings menerima bacaan suhu periodik dari alat. Bacaan diurutkan menurut waktu
+// agar excursion dibuka dan ditutup sesuai urutan kejadian, bukan urutan pengiriman.
+func (uc *storageDeviceUsecaseImpl) RecordReadings(ctx context.Context, id uuid.UUID, req dto.RecordTemperatureRequest, tenantID uuid.UUID) ([]entity.TemperatureReading, []entity.TemperatureExcursion, error) {
+	device, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	readings := make([]entity.TemperatureReading, 0, len(req.Readings))
+	for _, input := range req.Readings {
+		recordedAt := input.RecordedAt
+		if recordedAt.IsZero() {
+			recordedAt = now
+		}
+		readings = append(readings, entity.TemperatureReading{
+			Temperature: *input.Temperature,
+			RecordedAt:  recordedAt,
+		})
+	}
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].RecordedAt.Before(readings[j].RecordedAt)
+	})
+
+	excursions, err := uc.repo.RecordReadings(ctx, device, readings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return readings, excursions, nil
+}
+
+func (uc *storageDeviceUsecaseImpl) FindReadings(ctx context.Context, id uuid.UUID, filter repository.TemperatureReadingFilter, tenantID uuid.UUID, page, limit int) ([]entity.TemperatureReading, int64, error) {
+	if _, err := uc.FindByID(ctx, id, tenantID); err != nil {
+		return nil, 0, err
+	}
+	filter.DeviceID = id
+	offset := (page - 1) * limit
+	return uc.repo.FindReadings(ctx, filter, limit, offset)
+}
+
+// FindExcursions mengambil riwayat excursion suhu, terbaru lebih dulu.
+func (uc *storageDeviceUsecaseImpl) FindExcursions(ctx context.Context, filter repository.TemperatureExcursionFilter, page, limit int) ([]entity.TemperatureExcursion, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindExcursions(ctx, filter, limit, offset)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// findTenantLocation mengambil lokasi dan memastikan lokasi tersebut milik tenant pemanggil.
+// Lokasi tenant lain diperlakukan sebagai tidak ditemukan; tenantID kosong (superadmin) melewati pemeriksaan.
+func findTenantLocation(ctx context.Context, repo repository.LocationRepository, locationID, tenantID uuid.UUID) (entity.Location, error) {
+	location, err := repo.FindByID(ctx, locationID)
+	if err != nil {
+		return entity.Location{}, err
+	}
+	if tenantID != uuid.Nil && location.TenantID != tenantID {
+		return entity.Location{}, gorm.ErrRecordNotFound
+	}
+	return location, nil
+}
+
+func checkLocationTenant(ctx context.Context, repo repository.LocationRepository, locationID, tenantID uuid.UUID) error {
+	if tenantID == uuid.Nil {
+		return nil
+	}
+	_, err := findTenantLocation(ctx, repo, locationID, tenantID)
+	return err
+}
+
+// scopeOf menentukan batas data yang boleh dilihat pemanggil pada daftar: superadmin melihat semua,
+// pengguna tenant melihat data tenantnya, dan pengguna tanpa tenant hanya melihat data yang dibuatnya.
+func scopeOf(actor dto.Actor) (tenantID, createdBy uuid.UUID) {
+	switch {
+	case actor.Role == "superadmin":
+		return uuid.Nil, uuid.Nil
+	case actor.TenantID != uuid.Nil:
+		return actor.TenantID, uuid.Nil
+	default:
+		return uuid.Nil, actor.UserID
+	}
+}
+
+// canView menandakan pemanggil boleh melihat data milik tenant dan pembuat tertentu.
+func canView(actor dto.Actor, createdBy, tenantID uuid.UUID) bool {
+	scopeTenant, scopeCreator := scopeOf(actor)
+	switch {
+	case scopeTenant == uuid.Nil && scopeCreator == uuid.Nil:
+		return true
+	case scopeTenant != uuid.Nil:
+		return scopeTenant == tenantID || actor.UserID == createdBy
+	default:
+		return scopeCreator == createdBy
+	}
+}
+
+// canManage menandakan pemanggil boleh mengubah atau menghapus data: pembuatnya,
+// admin tenant yang sama, atau superadmin.
+func canManage(actor dto.Actor, createdBy, tenantID uuid.UUID) bool {
+	switch {
+	case actor.Role == "superadmin", actor.UserID == createdBy:
+		return true
+	default:
+		return actor.Role == "admin" && actor.TenantID != uuid.Nil && actor.TenantID == tenantID
+	}
+}
@@ -15,7 +15,7 @@ type TenantUsecase interface {
 	Create(ctx context.Context, req dto.TenantRequest) (dto.TenantResponse, error)
 	FindAll(ctx context.Context, page, limit int) (dto.PaginatedResponse[dto.TenantResponse], error)
 	FindByID(ctx context.Context, id uuid.UUID) (dto.TenantResponse, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.TenantRequest) (dto.TenantResponse, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.TenantRequest, version int) (dto.TenantResponse, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -81,12 +81,15 @@ func (uc *tenantUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (dto.Te
 	return res, nil
 }
 
-func (uc *tenantUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.TenantRequest) (dto.TenantResponse, error) {
+func (uc *tenantUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.TenantRequest, version int) (dto.TenantResponse, error) {
 	var res dto.TenantResponse
 	tenant, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return res, err
 	}
+	if err := checkVersion(tenant.Version, version); err != nil {
+		return res, err
+	}
 
 	copier.Copy(&tenant, &req)
 
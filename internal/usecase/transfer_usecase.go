@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"donor-api/internal/delivery/http/dto"
+	"donor-api/internal/entity"
+	"donor-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/copier"
+)
+
+// --- Interface ---
+type TransferUsecase interface {
+	Dispatch(ctx context.Context, req dto.CreateTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error)
+	FindAll(ctx context.Context, filter repository.TransferFilter, page, limit int) ([]entity.Transfer, int64, error)
+	FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Transfer, error)
+	Ship(ctx context.Context, id, tenantID uuid.UUID) (entity.Transfer, error)
+	Receive(ctx context.Context, id uuid.UUID, req dto.ReceiveTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error)
+	Reject(ctx context.Context, id uuid.UUID, req dto.RejectTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error)
+}
+
+// --- Implementation ---
+type transferUsecaseImpl struct {
+	repo         repository.TransferRepository
+	locationRepo repository.LocationRepository
+}
+
+func NewTransferUsecase(repo repository.TransferRepository, locationRepo repository.LocationRepository) TransferUsecase {
+	return &transferUsecaseImpl{
+		repo:         repo,
+		locationRepo: locationRepo,
+	}
+}
+
+// Dispatch membuat transfer dari lokasi milik tenant pemanggil. Kantong yang dikirim dikunci
+// dan ditandai in_transit oleh repository sehingga tidak bisa dialokasikan selama perjalanan.
+func (uc *transferUsecaseImpl) Dispatch(ctx context.Context, req dto.CreateTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error) {
+	if req.SourceLocationID == req.DestinationLocationID {
+		return entity.Transfer{}, entity.ErrSameTransferLocation
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, req.SourceLocationID, tenantID); err != nil {
+		return entity.Transfer{}, err
+	}
+	if _, err := uc.locationRepo.FindByID(ctx, req.DestinationLocationID); err != nil {
+		return entity.Transfer{}, err
+	}
+
+	var transfer entity.Transfer
+	copier.Copy(&transfer, &req)
+	if transfer.Component == "" {
+		transfer.Component = entity.ComponentWholeBlood
+	}
+
+	transfer.Status = entity.TransferStatusDispatched
+	transfer.DispatchedBy = &actorID
+	transfer.DispatchedAt = time.Now()
+
+	err := uc.repo.Dispatch(ctx, &transfer, startOfDay(transfer.DispatchedAt), actorID)
+	return transfer, err
+}
+
+func (uc *transferUsecaseImpl) FindAll(ctx context.Context, filter repository.TransferFilter, page, limit int) ([]entity.Transfer, int64, error) {
+	offset := (page - 1) * limit
+	return uc.repo.FindAll(ctx, filter, limit, offset)
+}
+
+// FindByID mengambil transfer yang lokasi asal atau tujuannya milik tenant pemanggil.
+func (uc *transferUsecaseImpl) FindByID(ctx context.Context, id, tenantID uuid.UUID) (entity.Transfer, error) {
+	transfer, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, transfer.SourceLocationID, tenantID); err == nil {
+		return transfer, nil
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, transfer.DestinationLocationID, tenantID); err != nil {
+		return entity.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+// Ship menandai kiriman sudah berangkat (dispatched -> in_transit). Hanya tenant lokasi asal yang boleh.
+func (uc *transferUsecaseImpl) Ship(ctx context.Context, id, tenantID uuid.UUID) (entity.Transfer, error) {
+	transfer, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, transfer.SourceLocationID, tenantID); err != nil {
+		return entity.Transfer{}, err
+	}
+
+	return uc.repo.Ship(ctx, id)
+}
+
+// Receive mencatat penerimaan penuh atau sebagian oleh tenant lokasi tujuan.
+// Kantong yang diterima adalah kantong yang dikunci saat dispatch.
+func (uc *transferUsecaseImpl) Receive(ctx context.Context, id uuid.UUID, req dto.ReceiveTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error) {
+	transfer, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+	if err := checkLocationTenant(ctx, uc.locationRepo, transfer.DestinationLocationID, tenantID); err != nil {
+		return entity.Transfer{}, err
+	}
+	if req.ReceivedQuantity > transfer.Quantity {
+		return entity.Transfer{}, entity.ErrInvalidReceivedQuantity
+	}
+
+	transfer.ReceivedQuantity = req.ReceivedQuantity
+	transfer.ReceiptNote = req.Note
+
+	return uc.repo.Receive(ctx, transfer, actorID)
+}
+
+// Reject membatalkan kiriman; kantong dikembalikan ke stok lokasi asal.
+// Tenant lokasi asal maupun tujuan boleh menolak kiriman yang belum diterima.
+func (uc *transferUsecaseImpl) Reject(ctx context.Context, id uuid.UUID, req dto.RejectTransferRequest, tenantID, actorID uuid.UUID) (entity.Transfer, error) {
+	transfer, err := uc.FindByID(ctx, id, tenantID)
+	if err != nil {
+		return entity.Transfer{}, err
+	}
+
+	transfer.ReceiptNote = req.Note
+	return uc.repo.Reject(ctx, transfer, actorID)
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "donor-api/internal/entity"
+
+// checkVersion membandingkan versi resource dengan versi dari If-Match.
+// expected 0 berarti klien tidak mengirim prasyarat.
+func checkVersion(current, expected int) error {
+	if expected != 0 && current != expected {
+		return entity.ErrVersionConflict
+	}
+	return nil
+}
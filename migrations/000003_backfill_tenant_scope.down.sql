@@ -0,0 +1,2 @@
+-- Backfill tenant_id tidak dibatalkan: nilai lama tidak tersimpan dan kolomnya dipakai aplikasi.
+SELECT 1;
@@ -0,0 +1,36 @@
+-- Mengisi tenant_id permintaan darah dan event lama agar tetap terlihat setelah pembatasan tenant.
+-- Tenant diambil dari lokasi; permintaan tanpa lokasi bertenant memakai tenant pembuatnya.
+ALTER TABLE blood_requests ADD COLUMN IF NOT EXISTS tenant_id uuid;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS tenant_id uuid;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS created_by uuid;
+
+UPDATE blood_requests
+SET tenant_id = locations.tenant_id
+FROM locations
+WHERE locations.id = blood_requests.location_id
+  AND locations.tenant_id IS NOT NULL
+  AND (blood_requests.tenant_id IS NULL OR blood_requests.tenant_id = '00000000-0000-0000-0000-000000000000');
+
+UPDATE blood_requests
+SET tenant_id = users.tenant_id
+FROM users
+WHERE users.id = blood_requests.created_by
+  AND users.tenant_id IS NOT NULL
+  AND (blood_requests.tenant_id IS NULL OR blood_requests.tenant_id = '00000000-0000-0000-0000-000000000000');
+
+UPDATE events
+SET tenant_id = locations.tenant_id
+FROM locations
+WHERE locations.id = events.location_id
+  AND locations.tenant_id IS NOT NULL
+  AND (events.tenant_id IS NULL OR events.tenant_id = '00000000-0000-0000-0000-000000000000');
+
+UPDATE events
+SET tenant_id = users.tenant_id
+FROM users
+WHERE users.id = events.created_by
+  AND users.tenant_id IS NOT NULL
+  AND (events.tenant_id IS NULL OR events.tenant_id = '00000000-0000-0000-0000-000000000000');
+
+CREATE INDEX IF NOT EXISTS idx_blood_requests_tenant_id ON blood_requests (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_events_tenant_id ON events (tenant_id);
@@ -0,0 +1,4 @@
+-- Mengembalikan indeks unik lama per golongan darah. Gagal bila golongan yang sama sudah
+-- tercatat di lebih dari satu lokasi atau komponen; gabungkan baris tersebut terlebih dahulu.
+DROP INDEX IF EXISTS idx_stock_location;
+CREATE UNIQUE INDEX idx_stock_location ON stocks (blood_type, rhesus);
@@ -0,0 +1,7 @@
+-- idx_stock_location dulu unik per golongan darah secara global. Stok sekarang dicatat per golongan,
+-- komponen, dan lokasi, tetapi AutoMigrate tidak membangun ulang indeks yang namanya sudah ada.
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS component varchar(10) NOT NULL DEFAULT 'WB';
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+DROP INDEX IF EXISTS idx_stock_location;
+CREATE UNIQUE INDEX idx_stock_location ON stocks (blood_type, rhesus, component, location_id);
@@ -0,0 +1,2 @@
+-- Saldo awal tidak dibatalkan: ledger bersifat append-only dan bag_quantity lama tidak didukung unit.
+SELECT 1;
@@ -0,0 +1,47 @@
+-- Stok lama hanya tersimpan sebagai angka bag_quantity tanpa unit kantong maupun ledger, sehingga
+-- ketersediaan, rencana pemenuhan, dan ringkasan melaporkan kantong yang tidak pernah bisa dialokasikan.
+-- Migrasi ini tidak membuat unit saldo awal karena nomor kantong, tanggal kedaluwarsa, dan donasi asalnya
+-- tidak diketahui. bag_quantity disamakan dengan jumlah unit available yang benar-benar ada (nol untuk
+-- baris yang seluruhnya lama), dan setiap koreksi dicatat sebagai pergerakan adjustment "Saldo awal" agar
+-- saldo ledger sama dengan bag_quantity. Kantong fisik yang masih ada harus didaftarkan ulang sebagai unit.
+CREATE TEMP TABLE stock_opening_balances AS
+SELECT stocks.id AS stock_id,
+       stocks.bag_quantity,
+       COALESCE(ledger.balance, 0) AS ledger_balance,
+       COALESCE(units.available, 0) AS available
+FROM stocks
+LEFT JOIN (
+  SELECT stock_id, SUM(quantity) AS balance
+  FROM stock_movements
+  GROUP BY stock_id
+) ledger ON ledger.stock_id = stocks.id
+LEFT JOIN (
+  SELECT blood_type, rhesus, component, location_id, COUNT(*) AS available
+  FROM blood_units
+  WHERE status = 'available' AND needs_review = false
+  GROUP BY blood_type, rhesus, component, location_id
+) units ON units.blood_type = stocks.blood_type
+  AND units.rhesus = stocks.rhesus
+  AND units.component = stocks.component
+  AND units.location_id = stocks.location_id;
+
+INSERT INTO stock_movements (id, stock_id, type, quantity, balance_after, reason, created_at)
+SELECT gen_random_uuid(),
+       stock_id,
+       'adjustment',
+       available - ledger_balance,
+       available,
+       'Saldo awal: ' || bag_quantity || ' kantong lama tanpa unit disesuaikan menjadi ' || available || ' unit available',
+       NOW()
+FROM stock_opening_balances
+WHERE bag_quantity <> available OR ledger_balance <> available;
+
+UPDATE stocks
+SET bag_quantity = stock_opening_balances.available,
+    version = stocks.version + 1,
+    updated_at = NOW()
+FROM stock_opening_balances
+WHERE stock_opening_balances.stock_id = stocks.id
+  AND stocks.bag_quantity <> stock_opening_balances.available;
+
+DROP TABLE stock_opening_balances;
@@ -0,0 +1,141 @@
+// Package compatibility berisi aturan kecocokan ABO/Rh donor–resipien untuk tiap komponen darah.
+//
+// Sel darah merah (WB, PRC) mengikuti antigen sel donor: O- dapat diberikan ke semua golongan.
+// Whole blood juga membawa plasma sehingga hanya diberikan ke ABO yang identik.
+// Plasma (FFP, CRYO) mengikuti antibodi plasma donor: AB adalah plasma universal dan rhesus tidak berpengaruh.
+// Trombosit (TC) mengikuti aturan plasma untuk ABO dan aturan sel darah merah untuk rhesus.
+package compatibility
+
+import "sort"
+
+// Kode komponen darah yang dikenali aturan kecocokan.
+const (
+	ComponentWholeBlood = "WB"   // Whole Blood
+	ComponentPRC        = "PRC"  // Packed Red Cells
+	ComponentFFP        = "FFP"  // Fresh Frozen Plasma
+	ComponentTC         = "TC"   // Thrombocyte Concentrate
+	ComponentCryo       = "CRYO" // Cryoprecipitate
+)
+
+var rhesusTypes = []string{"+", "-"}
+
+// Match adalah tingkat kecocokan antara golongan donor dan resipien.
+type Match int
+
+const (
+	Incompatible Match = iota
+	Substitute         // golongan berbeda namun aman diberikan
+	Exact              // golongan dan rhesus sama persis
+)
+
+func (m Match) String() string {
+	switch m {
+	case Exact:
+		return "exact"
+	case Substitute:
+		return "substitute"
+	default:
+		return "incompatible"
+	}
+}
+
+// BloodGroup adalah pasangan golongan ABO dan rhesus.
+type BloodGroup struct {
+	BloodType string
+	Rhesus    string
+}
+
+type rule struct {
+	// abo memetakan golongan resipien ke golongan donor yang boleh diberikan.
+	abo map[string][]string
+	// rhesusMatters menandakan donor Rh+ tidak boleh diberikan ke resipien Rh-.
+	rhesusMatters bool
+}
+
+var redCellABO = map[string][]string{
+	"O":  {"O"},
+	"A":  {"A", "O"},
+	"B":  {"B", "O"},
+	"AB": {"AB", "A", "B", "O"},
+}
+
+var plasmaABO = map[string][]string{
+	"O":  {"O", "A", "B", "AB"},
+	"A":  {"A", "AB"},
+	"B":  {"B", "AB"},
+	"AB": {"AB"},
+}
+
+var identicalABO = map[string][]string{
+	"O":  {"O"},
+	"A":  {"A"},
+	"B":  {"B"},
+	"AB": {"AB"},
+}
+
+var rules = map[string]rule{
+	ComponentWholeBlood: {abo: identicalABO, rhesusMatters: true},
+	ComponentPRC:        {abo: redCellABO, rhesusMatters: true},
+	ComponentFFP:        {abo: plasmaABO},
+	ComponentCryo:       {abo: plasmaABO},
+	ComponentTC:         {abo: plasmaABO, rhesusMatters: true},
+}
+
+// Valid menandakan golongan darah dikenal. Rhesus boleh kosong bila belum diketahui.
+func Valid(bloodType, rhesus string) bool {
+	if _, ok := redCellABO[bloodType]; !ok {
+		return false
+	}
+	return rhesus == "" || rhesus == "+" || rhesus == "-"
+}
+
+// Check menilai kecocokan komponen dari donor untuk resipien.
+// Rhesus resipien yang belum diketahui diperlakukan sebagai Rh- agar tetap aman.
+func Check(component string, donor, recipient BloodGroup) Match {
+	r, ok := rules[component]
+	if !ok {
+		return Incompatible
+	}
+	if !contains(r.abo[recipient.BloodType], donor.BloodType) {
+		return Incompatible
+	}
+	if r.rhesusMatters && donor.Rhesus == "+" && recipient.Rhesus != "+" {
+		return Incompatible
+	}
+	if donor.BloodType == recipient.BloodType && donor.Rhesus == recipient.Rhesus {
+		return Exact
+	}
+	return Substitute
+}
+
+// Donors mengembalikan semua golongan donor yang cocok untuk resipien,
+// dengan golongan yang sama persis di urutan pertama lalu pengganti yang ABO-nya paling dekat.
+func Donors(component string, recipient BloodGroup) []BloodGroup {
+	r, ok := rules[component]
+	if !ok {
+		return nil
+	}
+
+	var groups []BloodGroup
+	for _, bloodType := range r.abo[recipient.BloodType] {
+		for _, rhesus := range rhesusTypes {
+			group := BloodGroup{BloodType: bloodType, Rhesus: rhesus}
+			if Check(component, group, recipient) != Incompatible {
+				groups = append(groups, group)
+			}
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return Check(component, groups[i], recipient) > Check(component, groups[j], recipient)
+	})
+	return groups
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package compatibility
+
+import (
+	"slices"
+	"testing"
+)
+
+var allGroups = []string{"O+", "O-", "A+", "A-", "B+", "B-", "AB+", "AB-"}
+
+func group(s string) BloodGroup {
+	return BloodGroup{BloodType: s[:len(s)-1], Rhesus: s[len(s)-1:]}
+}
+
+func TestDonors(t *testing.T) {
+	plasma := map[string][]string{
+		"O":  allGroups,
+		"A":  {"A+", "A-", "AB+", "AB-"},
+		"B":  {"B+", "B-", "AB+", "AB-"},
+		"AB": {"AB+", "AB-"},
+	}
+
+	tests := []struct {
+		component string
+		recipient string
+		donors    []string
+	}{
+		// Whole blood: ABO identik, donor Rh+ hanya untuk resipien Rh+.
+		{ComponentWholeBlood, "O+", []string{"O+", "O-"}},
+		{ComponentWholeBlood, "O-", []string{"O-"}},
+		{ComponentWholeBlood, "A+", []string{"A+", "A-"}},
+		{ComponentWholeBlood, "A-", []string{"A-"}},
+		{ComponentWholeBlood, "B+", []string{"B+", "B-"}},
+		{ComponentWholeBlood, "B-", []string{"B-"}},
+		{ComponentWholeBlood, "AB+", []string{"AB+", "AB-"}},
+		{ComponentWholeBlood, "AB-", []string{"AB-"}},
+
+		// PRC: aturan sel darah merah, O- donor universal.
+		{ComponentPRC, "O+", []string{"O+", "O-"}},
+		{ComponentPRC, "O-", []string{"O-"}},
+		{ComponentPRC, "A+", []string{"A+", "A-", "O+", "O-"}},
+		{ComponentPRC, "A-", []string{"A-", "O-"}},
+		{ComponentPRC, "B+", []string{"B+", "B-", "O+", "O-"}},
+		{ComponentPRC, "B-", []string{"B-", "O-"}},
+		{ComponentPRC, "AB+", allGroups},
+		{ComponentPRC, "AB-", []string{"AB-", "A-", "B-", "O-"}},
+
+		// FFP dan CRYO: aturan plasma, rhesus tidak berpengaruh.
+		{ComponentFFP, "O+", plasma["O"]},
+		{ComponentFFP, "O-", plasma["O"]},
+		{ComponentFFP, "A+", plasma["A"]},
+		{ComponentFFP, "A-", plasma["A"]},
+		{ComponentFFP, "B+", plasma["B"]},
+		{ComponentFFP, "B-", plasma["B"]},
+		{ComponentFFP, "AB+", plasma["AB"]},
+		{ComponentFFP, "AB-", plasma["AB"]},
+		{ComponentCryo, "O+", plasma["O"]},
+		{ComponentCryo, "O-", plasma["O"]},
+		{ComponentCryo, "A+", plasma["A"]},
+		{ComponentCryo, "A-", plasma["A"]},
+		{ComponentCryo, "B+", plasma["B"]},
+		{ComponentCryo, "B-", plasma["B"]},
+		{ComponentCryo, "AB+", plasma["AB"]},
+		{ComponentCryo, "AB-", plasma["AB"]},
+
+		// TC: ABO mengikuti plasma, rhesus mengikuti sel darah merah.
+		{ComponentTC, "O+", allGroups},
+		{ComponentTC, "O-", []string{"O-", "A-", "B-", "AB-"}},
+		{ComponentTC, "A+", []string{"A+", "A-", "AB+", "AB-"}},
+		{ComponentTC, "A-", []string{"A-", "AB-"}},
+		{ComponentTC, "B+", []string{"B+", "B-", "AB+", "AB-"}},
+		{ComponentTC, "B-", []string{"B-", "AB-"}},
+		{ComponentTC, "AB+", []string{"AB+", "AB-"}},
+		{ComponentTC, "AB-", []string{"AB-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.component+" "+tt.recipient, func(t *testing.T) {
+			recipient := group(tt.recipient)
+
+			var got []string
+			for _, donor := range Donors(tt.component, recipient) {
+				got = append(got, donor.BloodType+donor.Rhesus)
+			}
+			if len(got) == 0 || got[0] != tt.recipient {
+				t.Fatalf("Donors() = %v, want exact match %s first", got, tt.recipient)
+			}
+			sorted, want := slices.Sorted(slices.Values(got)), slices.Sorted(slices.Values(tt.donors))
+			if !slices.Equal(sorted, want) {
+				t.Fatalf("Donors() = %v, want %v", got, tt.donors)
+			}
+
+			for _, donor := range allGroups {
+				match := Check(tt.component, group(donor), recipient)
+				switch {
+				case donor == tt.recipient && match != Exact:
+					t.Errorf("Check(%s) = %s, want exact", donor, match)
+				case donor != tt.recipient && slices.Contains(tt.donors, donor) && match != Substitute:
+					t.Errorf("Check(%s) = %s, want substitute", donor, match)
+				case !slices.Contains(tt.donors, donor) && match != Incompatible:
+					t.Errorf("Check(%s) = %s, want incompatible", donor, match)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckUnknownRecipientRhesus(t *testing.T) {
+	recipient := BloodGroup{BloodType: "A"}
+
+	if got := Check(ComponentPRC, group("A+"), recipient); got != Incompatible {
+		t.Errorf("PRC A+ to A? = %s, want incompatible", got)
+	}
+	if got := Check(ComponentTC, group("A+"), recipient); got != Incompatible {
+		t.Errorf("TC A+ to A? = %s, want incompatible", got)
+	}
+	if got := Check(ComponentFFP, group("A+"), recipient); got != Substitute {
+		t.Errorf("FFP A+ to A? = %s, want substitute", got)
+	}
+}
+
+func TestUnknownComponent(t *testing.T) {
+	if got := Check("XX", group("O-"), group("O-")); got != Incompatible {
+		t.Errorf("Check() = %s, want incompatible", got)
+	}
+	if got := Donors("XX", group("O-")); got != nil {
+		t.Errorf("Donors() = %v, want nil", got)
+	}
+}